@@ -3,9 +3,18 @@ package v1alpha2
 import (
 	"context"
 	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -20,12 +29,22 @@ type HookSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	EventConfigurations []EventConfiguration `json:"eventConfigurations"`
+
+	// LifecycleNotifications calls the agent when an event first fires and again when it
+	// resolves, labeling each call with its lifecycle stage
+	// +kubebuilder:validation:Optional
+	LifecycleNotifications bool `json:"lifecycleNotifications,omitempty"`
+
+	// Suspended pauses the hook: while set, no events are matched against it and its
+	// configuration and status are kept
+	// +kubebuilder:validation:Optional
+	Suspended bool `json:"suspended,omitempty"`
 }
 
 // EventConfiguration defines a single event type configuration
 type EventConfiguration struct {
 	// EventType specifies the type of Kubernetes event to monitor
-	// +kubebuilder:validation:Enum=pod-restart;pod-pending;oom-kill;probe-failed
+	// +kubebuilder:validation:Enum=pod-restart;pod-pending;oom-kill;probe-failed;deployment-unavailable;deployment-scaled;container-started;node-cordoned;node-drained
 	// +kubebuilder:validation:Required
 	EventType string `json:"eventType"`
 
@@ -37,6 +56,472 @@ type EventConfiguration struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	Prompt string `json:"prompt"`
+
+	// Prompts optionally maps event severities (low, medium, high, critical) to prompt
+	// templates used instead of Prompt for events of that severity, e.g. a terse prompt for
+	// low severity events and a detailed one for critical events
+	// +kubebuilder:validation:Optional
+	Prompts map[string]string `json:"prompts,omitempty"`
+
+	// Schedule optionally routes events to a different agent outside business hours.
+	// AgentRef is used during business hours and Schedule.OffHoursAgentRef otherwise.
+	// +kubebuilder:validation:Optional
+	Schedule *AgentSchedule `json:"schedule,omitempty"`
+
+	// ResourceNamePattern optionally restricts this configuration to resources whose name matches.
+	// The pattern is a glob (e.g. "payment-*") or, when prefixed with "re:", a regular expression.
+	// Empty matches every resource.
+	// +kubebuilder:validation:Optional
+	ResourceNamePattern string `json:"resourceNamePattern,omitempty"`
+
+	// ResourceKind optionally restricts this configuration to events about resources of this
+	// kind (e.g. "Pod" or a kind configured through eventTypeKinds). Empty matches every kind.
+	// +kubebuilder:validation:Optional
+	ResourceKind string `json:"resourceKind,omitempty"`
+
+	// ResourceSelector optionally restricts this configuration to events about resources whose
+	// labels match, e.g. matchLabels {"khook.io/watch": "true"}. The involved object is fetched
+	// to read its labels; events about objects that cannot be fetched do not match.
+	// +kubebuilder:validation:Optional
+	ResourceSelector *metav1.LabelSelector `json:"resourceSelector,omitempty"`
+
+	// ResourceAnnotations optionally restricts this configuration to events about resources
+	// carrying every one of these annotations with exactly the given value
+	// +kubebuilder:validation:Optional
+	ResourceAnnotations map[string]string `json:"resourceAnnotations,omitempty"`
+
+	// ProbeType optionally restricts a probe-failed configuration to failures of one kind of
+	// probe. Empty matches every probe failure.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=liveness;readiness;startup
+	ProbeType string `json:"probeType,omitempty"`
+
+	// ExcludeReasons skips events whose Kubernetes reason (e.g. "BackOff") is in the list,
+	// compared case-insensitively, for events that are expected and should not fire the hook
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:MinLength=1
+	ExcludeReasons []string `json:"excludeReasons,omitempty"`
+
+	// MinEventCount only fires the hook once Kubernetes has observed the event at least this
+	// many times (its series count), e.g. 5 to ignore a BackOff that happened once
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinEventCount int32 `json:"minEventCount,omitempty"`
+
+	// AgentCallTimeout bounds processing of this event, including the agent call, instead of
+	// the controller's default. Use it for agents that take longer to analyze, up to 30m.
+	// +kubebuilder:validation:Optional
+	AgentCallTimeout *metav1.Duration `json:"agentCallTimeout,omitempty"`
+
+	// AggregationWindow collects matching events for this configuration for the given
+	// duration and sends them to the agent in one call, with the batched events available
+	// to the prompt as {{.Resources}}. Use it for bursts such as a node failure. Up to 10m.
+	// +kubebuilder:validation:Optional
+	AggregationWindow *metav1.Duration `json:"aggregationWindow,omitempty"`
+
+	// MinActiveDuration holds a new event as pending until it is observed again at least
+	// this long after it was first seen, so transient conditions such as a pod pending for
+	// a few seconds never call the agent. Up to 30m.
+	// +kubebuilder:validation:Optional
+	MinActiveDuration *metav1.Duration `json:"minActiveDuration,omitempty"`
+
+	// ContextMetadataKeys lists the event metadata keys (e.g. "kind", "count") sent to the
+	// agent in the request context. Empty sends all metadata.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:MinLength=1
+	ContextMetadataKeys []string `json:"contextMetadataKeys,omitempty"`
+
+	// AgentRefTemplate expands template placeholders such as {{.Namespace}} in the agent
+	// name with the event context used for prompts, e.g. "{{.Namespace}}-agent"
+	// +kubebuilder:validation:Optional
+	AgentRefTemplate bool `json:"agentRefTemplate,omitempty"`
+
+	// DisableDeduplication calls the agent for every occurrence of this event,
+	// bypassing the deduplication window. Use for rare, always-actionable events.
+	// +kubebuilder:validation:Optional
+	DisableDeduplication bool `json:"disableDeduplication,omitempty"`
+
+	// SlackWebhookURL optionally posts a message to this Slack incoming webhook whenever
+	// the event fires. Delivery runs independently of the agent call.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https://`
+	SlackWebhookURL string `json:"slackWebhookURL,omitempty"`
+
+	// OutputFormat selects how the prompt is sent to the agent: "text" sends the expanded
+	// prompt, "json" sends a JSON object with the event context and the expanded prompt
+	// in its "prompt" field. Defaults to text.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=text;json
+	OutputFormat string `json:"outputFormat,omitempty"`
+}
+
+// Output formats for EventConfiguration.OutputFormat
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+)
+
+// isValidOutputFormat reports whether format is empty or a supported output format
+func isValidOutputFormat(format string) bool {
+	return format == "" || format == OutputFormatText || format == OutputFormatJSON
+}
+
+// templatePlaceholderPattern matches a single template placeholder such as {{.Namespace}}
+var templatePlaceholderPattern = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+
+// resourceNameRegexPrefix marks a ResourceNamePattern as a regular expression
+const resourceNameRegexPrefix = "re:"
+
+// MatchesResourceName reports whether name matches the configuration's ResourceNamePattern
+func (c *EventConfiguration) MatchesResourceName(name string) (bool, error) {
+	if c.ResourceNamePattern == "" {
+		return true, nil
+	}
+	if expr, ok := strings.CutPrefix(c.ResourceNamePattern, resourceNameRegexPrefix); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return false, fmt.Errorf("invalid resourceNamePattern regexp '%s': %w", expr, err)
+		}
+		return re.MatchString(name), nil
+	}
+	matched, err := path.Match(c.ResourceNamePattern, name)
+	if err != nil {
+		return false, fmt.Errorf("invalid resourceNamePattern glob '%s': %w", c.ResourceNamePattern, err)
+	}
+	return matched, nil
+}
+
+// MatchesResourceKind reports whether kind matches the configuration's ResourceKind
+func (c *EventConfiguration) MatchesResourceKind(kind string) bool {
+	return c.ResourceKind == "" || c.ResourceKind == kind
+}
+
+// HasResourceSelector reports whether the configuration restricts events by the labels or
+// annotations of the involved object, which then has to be fetched to match
+func (c *EventConfiguration) HasResourceSelector() bool {
+	return c.ResourceSelector != nil || len(c.ResourceAnnotations) > 0
+}
+
+// MatchesResourceMetadata reports whether the labels and annotations of an event's involved
+// object match the configuration's ResourceSelector and ResourceAnnotations
+func (c *EventConfiguration) MatchesResourceMetadata(labels, annotations map[string]string) (bool, error) {
+	for key, value := range c.ResourceAnnotations {
+		if actual, ok := annotations[key]; !ok || actual != value {
+			return false, nil
+		}
+	}
+	if c.ResourceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(c.ResourceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid resourceSelector: %w", err)
+	}
+	return selector.Matches(k8slabels.Set(labels)), nil
+}
+
+// validateResourceSelector checks that ResourceSelector parses and ResourceAnnotations has
+// no empty keys
+func (c *EventConfiguration) validateResourceSelector() error {
+	if c.ResourceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(c.ResourceSelector); err != nil {
+			return fmt.Errorf("resourceSelector is invalid: %w", err)
+		}
+	}
+	for key := range c.ResourceAnnotations {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("resourceAnnotations cannot contain an empty key")
+		}
+	}
+	return nil
+}
+
+// MatchesProbeType reports whether probeType matches the configuration's ProbeType
+func (c *EventConfiguration) MatchesProbeType(probeType string) bool {
+	return c.ProbeType == "" || c.ProbeType == probeType
+}
+
+// MatchesReason reports whether reason is not excluded by the configuration's ExcludeReasons
+func (c *EventConfiguration) MatchesReason(reason string) bool {
+	for _, excluded := range c.ExcludeReasons {
+		if strings.EqualFold(excluded, reason) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesEventCount reports whether count, the event's series count, reaches the
+// configuration's MinEventCount. Unparsable counts are treated as a single occurrence.
+func (c *EventConfiguration) MatchesEventCount(count string) bool {
+	if c.MinEventCount <= 1 {
+		return true
+	}
+	n, err := strconv.ParseInt(count, 10, 32)
+	if err != nil || n < 1 {
+		n = 1
+	}
+	return int32(n) >= c.MinEventCount
+}
+
+// validateExcludeReasons checks that ExcludeReasons has no empty reasons
+func (c *EventConfiguration) validateExcludeReasons() error {
+	for i, reason := range c.ExcludeReasons {
+		if strings.TrimSpace(reason) == "" {
+			return fmt.Errorf("excludeReasons[%d] cannot be empty", i)
+		}
+	}
+	return nil
+}
+
+// promptSeverities lists the severities Prompts may be keyed by
+var promptSeverities = []string{"low", "medium", "high", "critical"}
+
+// PromptFor returns the prompt template for events of the given severity: its entry in
+// Prompts, or Prompt when there is none
+func (c *EventConfiguration) PromptFor(severity string) string {
+	if prompt := c.Prompts[severity]; prompt != "" {
+		return prompt
+	}
+	return c.Prompt
+}
+
+// validatePrompts checks that Prompts is keyed by known severities and holds valid,
+// non-empty prompt templates
+func (c *EventConfiguration) validatePrompts() error {
+	severities := make([]string, 0, len(c.Prompts))
+	for severity := range c.Prompts {
+		severities = append(severities, severity)
+	}
+	slices.Sort(severities)
+
+	for _, severity := range severities {
+		prompt := c.Prompts[severity]
+		if !slices.Contains(promptSeverities, severity) {
+			return fmt.Errorf("prompts: invalid severity '%s', must be one of: %s", severity, strings.Join(promptSeverities, ", "))
+		}
+		if strings.TrimSpace(prompt) == "" {
+			return fmt.Errorf("prompts[%s] cannot be empty", severity)
+		}
+		if len(prompt) > 10000 {
+			return fmt.Errorf("prompts[%s] too long: %d characters (max 10000)", severity, len(prompt))
+		}
+		if err := checkPromptTemplate(prompt); err != nil {
+			return fmt.Errorf("prompts[%s] %w", severity, err)
+		}
+	}
+	return nil
+}
+
+// validateContextMetadataKeys checks that ContextMetadataKeys has no empty keys
+func (c *EventConfiguration) validateContextMetadataKeys() error {
+	for i, key := range c.ContextMetadataKeys {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("contextMetadataKeys[%d] cannot be empty", i)
+		}
+	}
+	return nil
+}
+
+// FilterContextMetadata returns the entries of metadata the configuration sends to the agent
+func (c *EventConfiguration) FilterContextMetadata(metadata map[string]string) map[string]string {
+	if len(c.ContextMetadataKeys) == 0 {
+		return metadata
+	}
+	filtered := make(map[string]string, len(c.ContextMetadataKeys))
+	for _, key := range c.ContextMetadataKeys {
+		if value, ok := metadata[key]; ok {
+			filtered[key] = value
+		}
+	}
+	return filtered
+}
+
+// MaxAgentCallTimeout is the longest AgentCallTimeout an event configuration may set
+const MaxAgentCallTimeout = 30 * time.Minute
+
+// validateAgentCallTimeout checks that AgentCallTimeout, when set, is positive and at most
+// MaxAgentCallTimeout
+func (c *EventConfiguration) validateAgentCallTimeout() error {
+	if c.AgentCallTimeout == nil {
+		return nil
+	}
+	if c.AgentCallTimeout.Duration <= 0 {
+		return fmt.Errorf("agentCallTimeout must be positive, got %v", c.AgentCallTimeout.Duration)
+	}
+	if c.AgentCallTimeout.Duration > MaxAgentCallTimeout {
+		return fmt.Errorf("agentCallTimeout %v exceeds the maximum of %v", c.AgentCallTimeout.Duration, MaxAgentCallTimeout)
+	}
+	return nil
+}
+
+// MaxAggregationWindow is the longest AggregationWindow an event configuration may set
+const MaxAggregationWindow = 10 * time.Minute
+
+// validateAggregationWindow checks that AggregationWindow, when set, is positive and at most
+// MaxAggregationWindow
+func (c *EventConfiguration) validateAggregationWindow() error {
+	if c.AggregationWindow == nil {
+		return nil
+	}
+	if c.AggregationWindow.Duration <= 0 {
+		return fmt.Errorf("aggregationWindow must be positive, got %v", c.AggregationWindow.Duration)
+	}
+	if c.AggregationWindow.Duration > MaxAggregationWindow {
+		return fmt.Errorf("aggregationWindow %v exceeds the maximum of %v", c.AggregationWindow.Duration, MaxAggregationWindow)
+	}
+	return nil
+}
+
+// MaxMinActiveDuration is the longest MinActiveDuration an event configuration may set
+const MaxMinActiveDuration = 30 * time.Minute
+
+// validateMinActiveDuration checks that MinActiveDuration, when set, is positive and at most
+// MaxMinActiveDuration
+func (c *EventConfiguration) validateMinActiveDuration() error {
+	if c.MinActiveDuration == nil {
+		return nil
+	}
+	if c.MinActiveDuration.Duration <= 0 {
+		return fmt.Errorf("minActiveDuration must be positive, got %v", c.MinActiveDuration.Duration)
+	}
+	if c.MinActiveDuration.Duration > MaxMinActiveDuration {
+		return fmt.Errorf("minActiveDuration %v exceeds the maximum of %v", c.MinActiveDuration.Duration, MaxMinActiveDuration)
+	}
+	return nil
+}
+
+// validateProbeType checks that ProbeType is a known probe kind set on a probe-failed configuration
+func (c *EventConfiguration) validateProbeType() error {
+	switch c.ProbeType {
+	case "":
+		return nil
+	case "liveness", "readiness", "startup":
+	default:
+		return fmt.Errorf("invalid probeType '%s', must be one of: liveness, readiness, startup", c.ProbeType)
+	}
+	if c.EventType != "probe-failed" {
+		return fmt.Errorf("probeType can only be set for probe-failed events")
+	}
+	return nil
+}
+
+// AgentSchedule defines business hours used to select between agents
+type AgentSchedule struct {
+	// Timezone is the IANA time zone the business hours are expressed in (e.g. "Europe/Berlin").
+	// Defaults to UTC.
+	// +kubebuilder:validation:Optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// BusinessHours lists the windows during which AgentRef handles events
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	BusinessHours []BusinessHoursWindow `json:"businessHours"`
+
+	// OffHoursAgentRef specifies the Kagent agent to call outside business hours
+	// +kubebuilder:validation:Required
+	OffHoursAgentRef ObjectReference `json:"offHoursAgentRef"`
+}
+
+// BusinessHoursWindow defines a daily time window on a set of weekdays
+type BusinessHoursWindow struct {
+	// Days the window applies to (Mon, Tue, Wed, Thu, Fri, Sat, Sun). Empty means every day.
+	// A window that ends before it starts spans midnight and belongs to the day it starts on.
+	// +kubebuilder:validation:Optional
+	Days []string `json:"days,omitempty"`
+
+	// Start is the start of the window in 24h HH:MM format (inclusive)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the end of the window in 24h HH:MM format (exclusive)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+}
+
+// weekdays maps schedule day names to time.Weekday
+var weekdays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// Location returns the time zone of the schedule
+func (s *AgentSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone '%s': %w", s.Timezone, err)
+	}
+	return loc, nil
+}
+
+// InBusinessHours reports whether t falls within any of the schedule's business hours windows
+func (s *AgentSchedule) InBusinessHours(t time.Time) (bool, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return false, err
+	}
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	for _, window := range s.BusinessHours {
+		start, err := parseClock(window.Start)
+		if err != nil {
+			return false, err
+		}
+		end, err := parseClock(window.End)
+		if err != nil {
+			return false, err
+		}
+
+		if start < end {
+			if window.appliesTo(local.Weekday()) && minute >= start && minute < end {
+				return true, nil
+			}
+			continue
+		}
+
+		// Overnight window: the part after midnight belongs to the previous day
+		if window.appliesTo(local.Weekday()) && minute >= start {
+			return true, nil
+		}
+		if window.appliesTo((local.Weekday()+6)%7) && minute < end {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// appliesTo reports whether the window is active on the given weekday
+func (w BusinessHoursWindow) appliesTo(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if weekdays[d] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses an HH:MM string into minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s', must be in HH:MM format", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
 }
 
 type ObjectReference struct {
@@ -83,15 +568,8 @@ func (h *Hook) Validate() error {
 // validateEventConfiguration validates a single event configuration
 func (h *Hook) validateEventConfiguration(config EventConfiguration, index int) error {
 	// Validate EventType
-	validEventTypes := map[string]bool{
-		"pod-restart":  true,
-		"pod-pending":  true,
-		"oom-kill":     true,
-		"probe-failed": true,
-	}
-
-	if !validEventTypes[config.EventType] {
-		return fmt.Errorf("event configuration %d: invalid event type '%s', must be one of: pod-restart, pod-pending, oom-kill, probe-failed", index, config.EventType)
+	if !isValidEventType(config.EventType) {
+		return fmt.Errorf("event configuration %d: invalid event type '%s', must be one of: %s", index, config.EventType, supportedEventTypeList())
 	}
 
 	// Validate AgentRef
@@ -103,13 +581,86 @@ func (h *Hook) validateEventConfiguration(config EventConfiguration, index int)
 		return fmt.Errorf("event configuration %d: agentId too long: %d characters (max 100)", index, len(config.AgentRef.Name))
 	}
 
-	// Validate agent ID format (alphanumeric, hyphens, underscores only)
-	for _, r := range config.AgentRef.Name {
+	// Validate agent ID format (alphanumeric, hyphens, underscores only); templated
+	// names are checked outside their placeholders and validated again once expanded
+	agentName := config.AgentRef.Name
+	if config.AgentRefTemplate {
+		if strings.Count(agentName, "{{") != strings.Count(agentName, "}}") {
+			return fmt.Errorf("event configuration %d: agentRef.name has unmatched template brackets", index)
+		}
+		if err := validateTemplateFields(agentName); err != nil {
+			return fmt.Errorf("event configuration %d: agentRef.name %w", index, err)
+		}
+		agentName = templatePlaceholderPattern.ReplaceAllString(agentName, "")
+	}
+	for _, r := range agentName {
 		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_') {
 			return fmt.Errorf("event configuration %d: agentId contains invalid character '%c', only alphanumeric, hyphens, and underscores allowed", index, r)
 		}
 	}
 
+	// Validate Schedule
+	if config.Schedule != nil {
+		if err := validateSchedule(config.Schedule); err != nil {
+			return fmt.Errorf("event configuration %d: %w", index, err)
+		}
+	}
+
+	// Validate ResourceNamePattern
+	if _, err := config.MatchesResourceName(""); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate SlackWebhookURL
+	if config.SlackWebhookURL != "" && !strings.HasPrefix(config.SlackWebhookURL, "https://") {
+		return fmt.Errorf("event configuration %d: slackWebhookURL must start with https://", index)
+	}
+
+	// Validate ProbeType
+	if err := config.validateProbeType(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate ExcludeReasons
+	if err := config.validateExcludeReasons(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate ContextMetadataKeys
+	if err := config.validateContextMetadataKeys(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate ResourceSelector and ResourceAnnotations
+	if err := config.validateResourceSelector(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate MinEventCount
+	if config.MinEventCount < 0 {
+		return fmt.Errorf("event configuration %d: minEventCount must be positive, got %d", index, config.MinEventCount)
+	}
+
+	// Validate AgentCallTimeout
+	if err := config.validateAgentCallTimeout(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate AggregationWindow
+	if err := config.validateAggregationWindow(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate MinActiveDuration
+	if err := config.validateMinActiveDuration(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	// Validate OutputFormat
+	if !isValidOutputFormat(config.OutputFormat) {
+		return fmt.Errorf("event configuration %d: invalid outputFormat '%s', must be one of: text, json", index, config.OutputFormat)
+	}
+
 	// Validate Prompt
 	if strings.TrimSpace(config.Prompt) == "" {
 		return fmt.Errorf("event configuration %d: prompt cannot be empty", index)
@@ -124,6 +675,47 @@ func (h *Hook) validateEventConfiguration(config EventConfiguration, index int)
 		return err
 	}
 
+	// Validate the severity prompts
+	if err := config.validatePrompts(); err != nil {
+		return fmt.Errorf("event configuration %d: %w", index, err)
+	}
+
+	return nil
+}
+
+// validateSchedule validates the business hours schedule of an event configuration
+func validateSchedule(schedule *AgentSchedule) error {
+	if _, err := schedule.Location(); err != nil {
+		return fmt.Errorf("schedule: %w", err)
+	}
+
+	if len(schedule.BusinessHours) == 0 {
+		return fmt.Errorf("schedule: at least one business hours window is required")
+	}
+
+	for i, window := range schedule.BusinessHours {
+		start, err := parseClock(window.Start)
+		if err != nil {
+			return fmt.Errorf("schedule.businessHours[%d].start: %w", i, err)
+		}
+		end, err := parseClock(window.End)
+		if err != nil {
+			return fmt.Errorf("schedule.businessHours[%d].end: %w", i, err)
+		}
+		if start == end {
+			return fmt.Errorf("schedule.businessHours[%d]: start and end cannot be equal", i)
+		}
+		for _, day := range window.Days {
+			if _, ok := weekdays[day]; !ok {
+				return fmt.Errorf("schedule.businessHours[%d]: invalid day '%s', must be one of: Mon, Tue, Wed, Thu, Fri, Sat, Sun", i, day)
+			}
+		}
+	}
+
+	if strings.TrimSpace(schedule.OffHoursAgentRef.Name) == "" {
+		return fmt.Errorf("schedule.offHoursAgentRef.name cannot be empty")
+	}
+
 	return nil
 }
 
@@ -132,13 +724,21 @@ func (h *Hook) validatePromptTemplate(prompt string, index int) error {
 	if prompt == "" {
 		return fmt.Errorf("event configuration %d: prompt cannot be empty", index)
 	}
+	if err := checkPromptTemplate(prompt); err != nil {
+		return fmt.Errorf("event configuration %d: prompt %w", index, err)
+	}
+	return nil
+}
 
+// checkPromptTemplate checks a prompt template for unbalanced brackets, dangerous
+// constructs and fields the event processor does not provide
+func checkPromptTemplate(prompt string) error {
 	// Check for balanced brackets
 	openCount := strings.Count(prompt, "{{")
 	closeCount := strings.Count(prompt, "}}")
 
 	if openCount != closeCount {
-		return fmt.Errorf("event configuration %d: prompt has unmatched template brackets: %d opens, %d closes", index, openCount, closeCount)
+		return fmt.Errorf("has unmatched template brackets: %d opens, %d closes", openCount, closeCount)
 	}
 
 	// Check for potentially dangerous template constructs
@@ -158,13 +758,243 @@ func (h *Hook) validatePromptTemplate(prompt string, index int) error {
 
 	for _, pattern := range dangerousPatterns {
 		if strings.Contains(prompt, pattern) {
-			return fmt.Errorf("event configuration %d: prompt contains potentially dangerous template construct: %s", index, pattern)
+			return fmt.Errorf("contains potentially dangerous template construct: %s", pattern)
+		}
+	}
+
+	return validateTemplateFields(prompt)
+}
+
+// templateFields lists the fields available to prompt templates, matching the data the
+// event processor expands templates with
+var templateFields = []string{
+	"EventType", "ResourceName", "Namespace", "Reason", "Message",
+	"Timestamp", "EventTime", "EventMessage", "ProbeType", "OwnerKind", "OwnerName", "Event",
+	"Resources",
+}
+
+// templateEventFields lists the fields available under {{.Event}}
+var templateEventFields = []string{
+	"Type", "ResourceName", "Timestamp", "Namespace", "Reason", "Message", "UID", "Metadata",
+}
+
+// sampleTemplateData is a placeholder event used to execute templates at admission
+var sampleTemplateData = map[string]interface{}{
+	"EventType":    "pod-restart",
+	"ResourceName": "sample-pod",
+	"Namespace":    "default",
+	"Reason":       "BackOff",
+	"Message":      "Back-off restarting failed container",
+	"Timestamp":    "2024-01-15T10:30:00Z",
+	"EventTime":    "2024-01-15T10:30:00Z",
+	"EventMessage": "Back-off restarting failed container",
+	"ProbeType":    "",
+	"OwnerKind":    "Deployment",
+	"OwnerName":    "sample-app",
+	"Event": map[string]interface{}{
+		"Type":         "pod-restart",
+		"ResourceName": "sample-pod",
+		"Timestamp":    "2024-01-15T10:30:00Z",
+		"Namespace":    "default",
+		"Reason":       "BackOff",
+		"Message":      "Back-off restarting failed container",
+		"UID":          "00000000-0000-0000-0000-000000000000",
+		"Metadata":     map[string]string{"kind": "Pod"},
+	},
+	"Resources": []map[string]interface{}{{
+		"Type":         "pod-restart",
+		"ResourceName": "sample-pod",
+		"Timestamp":    "2024-01-15T10:30:00Z",
+		"Namespace":    "default",
+		"Reason":       "BackOff",
+		"Message":      "Back-off restarting failed container",
+		"UID":          "00000000-0000-0000-0000-000000000000",
+		"Metadata":     map[string]string{"kind": "Pod"},
+	}},
+}
+
+// promptTemplateFuncs are the functions available to prompt templates in addition to the
+// text/template builtins
+var promptTemplateFuncs = template.FuncMap{
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"trim":     strings.TrimSpace,
+	"default":  templateDefault,
+	"truncate": templateTruncate,
+	"replace":  templateReplace,
+}
+
+// promptTemplateFuncNames lists promptTemplateFuncs for error messages
+var promptTemplateFuncNames = []string{"upper", "lower", "trim", "default", "truncate", "replace"}
+
+// disallowedTemplateFuncs are text/template builtins that prompt templates may not call,
+// including through pipes such as {{.Message | printf "%q"}}
+var disallowedTemplateFuncs = []string{"call", "html", "js", "print", "printf", "println", "urlquery"}
+
+// templateDefault returns value, or fallback when value is empty: {{.Reason | default "unknown"}}
+func templateDefault(fallback, value interface{}) interface{} {
+	if value == nil {
+		return fallback
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return fallback
+	}
+	return value
+}
+
+// templateTruncate shortens s to at most n characters: {{.Message | truncate 80}}
+func templateTruncate(n int, s string) string {
+	runes := []rune(s)
+	if n < 0 || len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// templateReplace replaces all occurrences of old in s: {{.ResourceName | replace "-" "_"}}
+func templateReplace(old, replacement, s string) string {
+	return strings.ReplaceAll(s, old, replacement)
+}
+
+// ParsePromptTemplate parses a prompt template with the prompt template functions and
+// rejects calls to disallowed builtins. Unknown functions are reported together with the
+// available ones.
+func ParsePromptTemplate(templateStr string) (*template.Template, error) {
+	tmpl, err := template.New("prompt").Funcs(promptTemplateFuncs).Parse(templateStr)
+	if err != nil {
+		if strings.Contains(err.Error(), "not defined") {
+			return nil, fmt.Errorf("%w; available functions are %s", err, strings.Join(promptTemplateFuncNames, ", "))
 		}
+		return nil, err
 	}
 
+	for _, name := range collectTemplateIdentifiers(tmpl.Root) {
+		if slices.Contains(disallowedTemplateFuncs, name) {
+			return nil, fmt.Errorf("function %q is not allowed; available functions are %s",
+				name, strings.Join(promptTemplateFuncNames, ", "))
+		}
+	}
+	return tmpl, nil
+}
+
+// collectTemplateIdentifiers returns the names of all functions called in a template
+func collectTemplateIdentifiers(node parse.Node) []string {
+	var names []string
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			names = append(names, collectTemplateIdentifiers(child)...)
+		}
+	case *parse.ActionNode:
+		names = collectTemplateIdentifiers(n.Pipe)
+	case *parse.IfNode:
+		names = collectTemplateBranchIdentifiers(&n.BranchNode)
+	case *parse.RangeNode:
+		names = collectTemplateBranchIdentifiers(&n.BranchNode)
+	case *parse.WithNode:
+		names = collectTemplateBranchIdentifiers(&n.BranchNode)
+	case *parse.TemplateNode:
+		names = collectTemplateIdentifiers(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			names = append(names, collectTemplateIdentifiers(cmd)...)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			names = append(names, collectTemplateIdentifiers(arg)...)
+		}
+	case *parse.ChainNode:
+		names = collectTemplateIdentifiers(n.Node)
+	case *parse.IdentifierNode:
+		names = append(names, n.Ident)
+	}
+	return names
+}
+
+// collectTemplateBranchIdentifiers returns the functions called in an if, range or with block
+func collectTemplateBranchIdentifiers(n *parse.BranchNode) []string {
+	names := collectTemplateIdentifiers(n.Pipe)
+	names = append(names, collectTemplateIdentifiers(n.List)...)
+	return append(names, collectTemplateIdentifiers(n.ElseList)...)
+}
+
+// validateTemplateFields parses templateStr with text/template, rejects references to fields
+// that events do not provide, and executes it against a sample event
+func validateTemplateFields(templateStr string) error {
+	tmpl, err := ParsePromptTemplate(templateStr)
+	if err != nil {
+		return fmt.Errorf("is not a valid template: %w", err)
+	}
+
+	var unknown []string
+	for _, field := range collectTemplateFields(tmpl.Root) {
+		if !isKnownTemplateField(field) {
+			unknown = append(unknown, "."+strings.Join(field, "."))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("references unknown template fields %s; available fields are .%s",
+			strings.Join(unknown, ", "), strings.Join(templateFields, ", ."))
+	}
+
+	if err := tmpl.Execute(io.Discard, sampleTemplateData); err != nil {
+		return fmt.Errorf("failed to render for a sample event: %w", err)
+	}
 	return nil
 }
 
+// isKnownTemplateField reports whether the field path is provided by template data
+func isKnownTemplateField(field []string) bool {
+	if !slices.Contains(templateFields, field[0]) {
+		return false
+	}
+	return field[0] != "Event" || len(field) == 1 || slices.Contains(templateEventFields, field[1])
+}
+
+// collectTemplateFields returns the field paths referenced against the event in a template.
+// Bodies of range and with blocks change the data in scope, so only their pipelines are checked.
+func collectTemplateFields(node parse.Node) [][]string {
+	var fields [][]string
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			fields = append(fields, collectTemplateFields(child)...)
+		}
+	case *parse.ActionNode:
+		fields = collectTemplateFields(n.Pipe)
+	case *parse.IfNode:
+		fields = append(collectTemplateFields(n.Pipe), collectTemplateFields(n.List)...)
+		fields = append(fields, collectTemplateFields(n.ElseList)...)
+	case *parse.RangeNode:
+		fields = collectTemplateFields(n.Pipe)
+	case *parse.WithNode:
+		fields = collectTemplateFields(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			fields = append(fields, collectTemplateFields(cmd)...)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			fields = append(fields, collectTemplateFields(arg)...)
+		}
+	case *parse.FieldNode:
+		fields = append(fields, n.Ident)
+	}
+	return fields
+}
+
 // ActiveEventStatus represents the status of an active event
 type ActiveEventStatus struct {
 	// EventType is the type of the active event
@@ -175,6 +1005,14 @@ type ActiveEventStatus struct {
 	// +kubebuilder:validation:Required
 	ResourceName string `json:"resourceName"`
 
+	// Namespace is the namespace of the Kubernetes resource involved
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Reason is the Kubernetes reason of the event, e.g. OOMKilling
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
 	// FirstSeen is when the event was first observed
 	// +kubebuilder:validation:Required
 	FirstSeen metav1.Time `json:"firstSeen"`
@@ -187,10 +1025,23 @@ type ActiveEventStatus struct {
 	// +kubebuilder:validation:Enum=firing;resolved
 	// +kubebuilder:validation:Required
 	Status string `json:"status"`
+
+	// OccurrenceCount is how many times the event has been observed while active
+	// +kubebuilder:validation:Optional
+	OccurrenceCount int32 `json:"occurrenceCount,omitempty"`
+
+	// SessionID is the Kagent session the agent was last called in for this event
+	// +kubebuilder:validation:Optional
+	SessionID string `json:"sessionId,omitempty"`
+
+	// RemediationSummary is the agent's reply to the last call for this event, truncated
+	// +kubebuilder:validation:Optional
+	RemediationSummary string `json:"remediationSummary,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspended`
 //+kubebuilder:webhook:path=/validate-kagent-dev-v1alpha2-hook,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=hooks,verbs=create;update,versions=v1alpha2,name=vhook.kb.io,admissionReviewVersions=v1
 
 // Hook is the Schema for the hooks API
@@ -276,7 +1127,9 @@ func (in *HookSpec) DeepCopyInto(out *HookSpec) {
 	if in.EventConfigurations != nil {
 		in, out := &in.EventConfigurations, &out.EventConfigurations
 		*out = make([]EventConfiguration, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -316,6 +1169,56 @@ func (in *HookStatus) DeepCopy() *HookStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventConfiguration) DeepCopyInto(out *EventConfiguration) {
 	*out = *in
+	in.AgentRef.DeepCopyInto(&out.AgentRef)
+	if in.Prompts != nil {
+		in, out := &in.Prompts, &out.Prompts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(AgentSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeReasons != nil {
+		in, out := &in.ExcludeReasons, &out.ExcludeReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AgentCallTimeout != nil {
+		in, out := &in.AgentCallTimeout, &out.AgentCallTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AggregationWindow != nil {
+		in, out := &in.AggregationWindow, &out.AggregationWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MinActiveDuration != nil {
+		in, out := &in.MinActiveDuration, &out.MinActiveDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ContextMetadataKeys != nil {
+		in, out := &in.ContextMetadataKeys, &out.ContextMetadataKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceSelector != nil {
+		in, out := &in.ResourceSelector, &out.ResourceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceAnnotations != nil {
+		in, out := &in.ResourceAnnotations, &out.ResourceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventConfiguration.
@@ -328,6 +1231,69 @@ func (in *EventConfiguration) DeepCopy() *EventConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSchedule) DeepCopyInto(out *AgentSchedule) {
+	*out = *in
+	if in.BusinessHours != nil {
+		in, out := &in.BusinessHours, &out.BusinessHours
+		*out = make([]BusinessHoursWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.OffHoursAgentRef.DeepCopyInto(&out.OffHoursAgentRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSchedule.
+func (in *AgentSchedule) DeepCopy() *AgentSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BusinessHoursWindow) DeepCopyInto(out *BusinessHoursWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BusinessHoursWindow.
+func (in *BusinessHoursWindow) DeepCopy() *BusinessHoursWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BusinessHoursWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveEventStatus) DeepCopyInto(out *ActiveEventStatus) {
 	*out = *in
@@ -369,6 +1335,58 @@ func (r *Hook) ValidateDelete(ctx context.Context, obj runtime.Object) (admissio
 	return nil, nil
 }
 
+// HookWarner reports admission warnings for a hook that depend on cluster state, such as
+// the other hooks in its namespace
+type HookWarner interface {
+	HookWarnings(ctx context.Context, hook *Hook) (admission.Warnings, error)
+}
+
+// HookValidator validates hooks like Hook's own validator and adds the warnings of its
+// warners to admitted hooks. A warner that fails never rejects the hook.
+type HookValidator struct {
+	Warners []HookWarner
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *HookValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	hook, ok := obj.(*Hook)
+	if !ok {
+		return nil, fmt.Errorf("expected a Hook object, got %T", obj)
+	}
+	return v.validate(ctx, hook)
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *HookValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	hook, ok := newObj.(*Hook)
+	if !ok {
+		return nil, fmt.Errorf("expected a Hook object, got %T", newObj)
+	}
+	return v.validate(ctx, hook)
+}
+
+// ValidateDelete implements admission.CustomValidator
+func (v *HookValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	// Allow all deletions
+	return nil, nil
+}
+
+// validate runs validateHook and, for valid hooks, the warners
+func (v *HookValidator) validate(ctx context.Context, hook *Hook) (admission.Warnings, error) {
+	warnings, err := validateHook(hook)
+	if err != nil {
+		return warnings, err
+	}
+	for _, warner := range v.Warners {
+		extra, err := warner.HookWarnings(ctx, hook)
+		warnings = append(warnings, extra...)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not complete admission checks: %v", err))
+		}
+	}
+	return warnings, nil
+}
+
 // validateHook performs validation logic for Hook resources
 func validateHook(hook *Hook) (admission.Warnings, error) {
 	var allErrs []string
@@ -382,15 +1400,18 @@ func validateHook(hook *Hook) (admission.Warnings, error) {
 	// Validate each event configuration
 	eventTypes := make(map[string]bool)
 	for i, config := range hook.Spec.EventConfigurations {
-		// Check for duplicate event types
-		if eventTypes[config.EventType] {
+		// Check for duplicate event types; configurations scoped to different resource
+		// name patterns, kinds, probe types or minimum counts may share an event type
+		eventKey := config.EventType + "/" + config.ResourceKind + "/" + config.ProbeType + "/" + config.ResourceNamePattern +
+			"/" + strconv.Itoa(int(config.MinEventCount))
+		if eventTypes[eventKey] {
 			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d]: duplicate eventType '%s'", i, config.EventType))
 		}
-		eventTypes[config.EventType] = true
+		eventTypes[eventKey] = true
 
 		// Validate event type
 		if !isValidEventType(config.EventType) {
-			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].eventType: invalid event type '%s', must be one of: pod-restart, pod-pending, oom-kill, probe-failed", i, config.EventType))
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].eventType: invalid event type '%s', must be one of: %s", i, config.EventType, supportedEventTypeList()))
 		}
 
 		// Validate agentId is not empty
@@ -398,9 +1419,73 @@ func validateHook(hook *Hook) (admission.Warnings, error) {
 			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].agentId: cannot be empty", i))
 		}
 
-		// Validate prompt is not empty
+		// Validate prompt is not empty and is a valid template referencing known fields
 		if strings.TrimSpace(config.Prompt) == "" {
 			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].prompt: cannot be empty", i))
+		} else if err := checkPromptTemplate(config.Prompt); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].prompt: %v", i, err))
+		}
+
+		// Validate the severity prompts
+		if err := config.validatePrompts(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+		}
+
+		// Validate the business hours schedule
+		if config.Schedule != nil {
+			if err := validateSchedule(config.Schedule); err != nil {
+				allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+			}
+		}
+
+		// Validate the resource name pattern
+		if _, err := config.MatchesResourceName(""); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].resourceNamePattern: %v", i, err))
+		}
+
+		// Validate the probe type
+		if err := config.validateProbeType(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].probeType: %v", i, err))
+		}
+
+		// Validate the excluded reasons
+		if err := config.validateExcludeReasons(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+		}
+
+		// Validate the context metadata keys
+		if err := config.validateContextMetadataKeys(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+		}
+
+		// Validate the resource selector
+		if err := config.validateResourceSelector(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+		}
+
+		// Validate the minimum event count
+		if config.MinEventCount < 0 {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].minEventCount: must be positive, got %d", i, config.MinEventCount))
+		}
+
+		// Validate the agent call timeout
+		if err := config.validateAgentCallTimeout(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+		}
+
+		// Validate the aggregation window
+		if err := config.validateAggregationWindow(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+		}
+
+		// Validate the minimum active duration
+		if err := config.validateMinActiveDuration(); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].%v", i, err))
+		}
+
+		// Validate the output format
+		if !isValidOutputFormat(config.OutputFormat) {
+			allErrs = append(allErrs, fmt.Sprintf("spec.eventConfigurations[%d].outputFormat: invalid output format '%s', must be one of: text, json", i, config.OutputFormat))
 		}
 
 		// Warn about potentially long prompts
@@ -416,13 +1501,49 @@ func validateHook(hook *Hook) (admission.Warnings, error) {
 	return warnings, nil
 }
 
+// EventTypeInfo describes an event type hooks can respond to
+// +kubebuilder:object:generate=false
+type EventTypeInfo struct {
+	// Name is the value of EventConfiguration.EventType
+	Name string `json:"name"`
+
+	// Description explains which Kubernetes events map to the event type
+	Description string `json:"description"`
+
+	// ResourceKind is the kind of resource the event type applies to by default
+	ResourceKind string `json:"resourceKind"`
+}
+
+// SupportedEventTypes lists the event types hooks can respond to, in the order of the
+// EventConfiguration.EventType enum. Validation and error messages are derived from it;
+// keep the enum marker in sync when adding a type.
+var SupportedEventTypes = []EventTypeInfo{
+	{Name: "pod-restart", ResourceKind: "Pod", Description: "A container of the pod failed and is being restarted, e.g. BackOff"},
+	{Name: "pod-pending", ResourceKind: "Pod", Description: "The pod cannot be scheduled, e.g. FailedScheduling"},
+	{Name: "oom-kill", ResourceKind: "Pod", Description: "A container of the pod was killed for running out of memory"},
+	{Name: "probe-failed", ResourceKind: "Pod", Description: "A liveness, readiness or startup probe of the pod failed"},
+	{Name: "deployment-unavailable", ResourceKind: "Deployment", Description: "The deployment has too few available replicas or its rollout failed"},
+	{Name: "deployment-scaled", ResourceKind: "Deployment", Description: "The deployment scaled one of its replica sets"},
+	{Name: "container-started", ResourceKind: "Pod", Description: "A container of the pod started; only reported when enabled in the controller"},
+	{Name: "node-cordoned", ResourceKind: "Node", Description: "The node was marked unschedulable"},
+	{Name: "node-drained", ResourceKind: "Node", Description: "The node is being or has been drained"},
+}
+
 // isValidEventType checks if the provided event type is valid
 func isValidEventType(eventType string) bool {
-	validTypes := map[string]bool{
-		"pod-restart":  true,
-		"pod-pending":  true,
-		"oom-kill":     true,
-		"probe-failed": true,
+	for _, info := range SupportedEventTypes {
+		if info.Name == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// supportedEventTypeList returns the supported event type names for error messages
+func supportedEventTypeList() string {
+	names := make([]string, 0, len(SupportedEventTypes))
+	for _, info := range SupportedEventTypes {
+		names = append(names, info.Name)
 	}
-	return validTypes[eventType]
+	return strings.Join(names, ", ")
 }
@@ -18,9 +18,13 @@ package v1alpha2
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestHookValidation(t *testing.T) {
@@ -125,3 +129,850 @@ func TestHookDeepCopy(t *testing.T) {
 		t.Errorf("DeepCopyObject() name mismatch: got %v, want %v", hookObj.Name, original.Name)
 	}
 }
+
+func TestAgentScheduleInBusinessHours(t *testing.T) {
+	schedule := &AgentSchedule{
+		BusinessHours: []BusinessHoursWindow{
+			{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00"},
+			{Days: []string{"Sat"}, Start: "22:00", End: "02:00"},
+		},
+		OffHoursAgentRef: ObjectReference{Name: "paging-agent"},
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{name: "start of window is inclusive", now: time.Date(2024, 1, 17, 9, 0, 0, 0, time.UTC), expected: true},
+		{name: "end of window is exclusive", now: time.Date(2024, 1, 17, 17, 0, 0, 0, time.UTC), expected: false},
+		{name: "before window", now: time.Date(2024, 1, 17, 8, 59, 0, 0, time.UTC), expected: false},
+		{name: "overnight window before midnight", now: time.Date(2024, 1, 20, 23, 0, 0, 0, time.UTC), expected: true},
+		{name: "overnight window after midnight", now: time.Date(2024, 1, 21, 1, 0, 0, 0, time.UTC), expected: true},
+		{name: "overnight window on wrong day", now: time.Date(2024, 1, 21, 23, 0, 0, 0, time.UTC), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := schedule.InBusinessHours(tt.now)
+			if err != nil {
+				t.Fatalf("InBusinessHours() unexpected error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("InBusinessHours() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHookValidationSchedule(t *testing.T) {
+	newHook := func(schedule *AgentSchedule) *Hook {
+		return &Hook{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+			Spec: HookSpec{
+				EventConfigurations: []EventConfiguration{
+					{
+						EventType: "pod-restart",
+						AgentRef:  ObjectReference{Name: "agent-123"},
+						Prompt:    "Pod has restarted",
+						Schedule:  schedule,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		schedule  *AgentSchedule
+		expectErr bool
+	}{
+		{
+			name: "valid schedule",
+			schedule: &AgentSchedule{
+				Timezone:         "UTC",
+				BusinessHours:    []BusinessHoursWindow{{Days: []string{"Mon"}, Start: "09:00", End: "17:00"}},
+				OffHoursAgentRef: ObjectReference{Name: "paging-agent"},
+			},
+		},
+		{
+			name: "invalid timezone",
+			schedule: &AgentSchedule{
+				Timezone:         "Mars/Olympus_Mons",
+				BusinessHours:    []BusinessHoursWindow{{Start: "09:00", End: "17:00"}},
+				OffHoursAgentRef: ObjectReference{Name: "paging-agent"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid time",
+			schedule: &AgentSchedule{
+				BusinessHours:    []BusinessHoursWindow{{Start: "9am", End: "17:00"}},
+				OffHoursAgentRef: ObjectReference{Name: "paging-agent"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid day",
+			schedule: &AgentSchedule{
+				BusinessHours:    []BusinessHoursWindow{{Days: []string{"Funday"}, Start: "09:00", End: "17:00"}},
+				OffHoursAgentRef: ObjectReference{Name: "paging-agent"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "missing off-hours agent",
+			schedule: &AgentSchedule{
+				BusinessHours: []BusinessHoursWindow{{Start: "09:00", End: "17:00"}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := newHook(tt.schedule)
+
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateCreate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+
+			err = hook.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestEventConfigurationMatchesResourceName(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		resource  string
+		expected  bool
+		expectErr bool
+	}{
+		{name: "empty pattern matches all", pattern: "", resource: "anything", expected: true},
+		{name: "glob match", pattern: "payment-*", resource: "payment-api-7d9f", expected: true},
+		{name: "glob mismatch", pattern: "payment-*", resource: "checkout-api-7d9f", expected: false},
+		{name: "regexp match", pattern: "re:^payment-(api|worker)-", resource: "payment-worker-1", expected: true},
+		{name: "regexp mismatch", pattern: "re:^payment-(api|worker)-", resource: "payment-db-0", expected: false},
+		{name: "invalid glob", pattern: "payment-[", resource: "payment-1", expectErr: true},
+		{name: "invalid regexp", pattern: "re:payment-(", resource: "payment-1", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := EventConfiguration{ResourceNamePattern: tt.pattern}
+			matched, err := config.MatchesResourceName(tt.resource)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("MatchesResourceName() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if matched != tt.expected {
+				t.Errorf("MatchesResourceName() = %v, expected %v", matched, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHookValidationResourceNamePattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		expectErr bool
+	}{
+		{name: "no pattern", pattern: ""},
+		{name: "valid glob", pattern: "payment-*"},
+		{name: "valid regexp", pattern: "re:^payment-.+$"},
+		{name: "invalid glob", pattern: "payment-[", expectErr: true},
+		{name: "invalid regexp", pattern: "re:(", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{
+					EventConfigurations: []EventConfiguration{
+						{
+							EventType:           "pod-restart",
+							AgentRef:            ObjectReference{Name: "agent-123"},
+							Prompt:              "Pod has restarted",
+							ResourceNamePattern: tt.pattern,
+						},
+					},
+				},
+			}
+
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateCreate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+
+			err = hook.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationResourceKind(t *testing.T) {
+	newHook := func(kinds ...string) *Hook {
+		hook := &Hook{ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"}}
+		for _, kind := range kinds {
+			hook.Spec.EventConfigurations = append(hook.Spec.EventConfigurations, EventConfiguration{
+				EventType:    "pod-restart",
+				AgentRef:     ObjectReference{Name: "agent-123"},
+				Prompt:       "Pod has restarted",
+				ResourceKind: kind,
+			})
+		}
+		return hook
+	}
+
+	// Configurations scoped to different kinds may share an event type
+	hook := newHook("Pod", "SandboxWorkload", "")
+	if _, err := hook.ValidateCreate(context.Background(), hook); err != nil {
+		t.Errorf("ValidateCreate() unexpected error = %v", err)
+	}
+
+	hook = newHook("Pod", "Pod")
+	if _, err := hook.ValidateCreate(context.Background(), hook); err == nil || !strings.Contains(err.Error(), "duplicate eventType") {
+		t.Errorf("ValidateCreate() error = %v, want duplicate eventType error", err)
+	}
+
+	config := EventConfiguration{ResourceKind: "Pod"}
+	if !config.MatchesResourceKind("Pod") || config.MatchesResourceKind("Deployment") {
+		t.Errorf("MatchesResourceKind() did not match only kind Pod")
+	}
+	if config := (EventConfiguration{}); !config.MatchesResourceKind("Deployment") {
+		t.Errorf("MatchesResourceKind() with empty kind should match every kind")
+	}
+}
+
+func TestHookValidationProbeType(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		probeType string
+		wantErr   bool
+	}{
+		{name: "empty", eventType: "probe-failed"},
+		{name: "liveness", eventType: "probe-failed", probeType: "liveness"},
+		{name: "startup", eventType: "probe-failed", probeType: "startup"},
+		{name: "unknown probe", eventType: "probe-failed", probeType: "exec", wantErr: true},
+		{name: "other event type", eventType: "pod-restart", probeType: "readiness", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType: tt.eventType,
+					AgentRef:  ObjectReference{Name: "agent-123"},
+					Prompt:    "Probe failed",
+					ProbeType: tt.probeType,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	config := EventConfiguration{ProbeType: "liveness"}
+	if !config.MatchesProbeType("liveness") || config.MatchesProbeType("readiness") {
+		t.Errorf("MatchesProbeType() did not match only liveness probes")
+	}
+	if config := (EventConfiguration{}); !config.MatchesProbeType("startup") {
+		t.Errorf("MatchesProbeType() with empty probe type should match every probe")
+	}
+}
+
+func TestHookValidationExcludeReasons(t *testing.T) {
+	tests := []struct {
+		name    string
+		reasons []string
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "reasons", reasons: []string{"BackOff", "Unhealthy"}},
+		{name: "empty reason", reasons: []string{"BackOff", ""}, wantErr: true},
+		{name: "blank reason", reasons: []string{"  "}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType:      "pod-restart",
+					AgentRef:       ObjectReference{Name: "agent-123"},
+					Prompt:         "Pod has restarted",
+					ExcludeReasons: tt.reasons,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	config := EventConfiguration{ExcludeReasons: []string{"BackOff"}}
+	if config.MatchesReason("backoff") || !config.MatchesReason("Killing") {
+		t.Errorf("MatchesReason() did not exclude only BackOff case-insensitively")
+	}
+}
+
+func TestSupportedEventTypes(t *testing.T) {
+	for _, info := range SupportedEventTypes {
+		if info.Description == "" || info.ResourceKind == "" {
+			t.Errorf("event type %s is missing its description or resource kind", info.Name)
+		}
+		hook := &Hook{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+			Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+				EventType: info.Name,
+				AgentRef:  ObjectReference{Name: "agent-123"},
+				Prompt:    "Handle the event",
+			}}},
+		}
+		if err := hook.Validate(); err != nil {
+			t.Errorf("Validate() rejected supported event type %s: %v", info.Name, err)
+		}
+	}
+
+	hook := &Hook{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+		Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+			EventType: "pod-evicted",
+			AgentRef:  ObjectReference{Name: "agent-123"},
+			Prompt:    "Handle the event",
+		}}},
+	}
+	err := hook.Validate()
+	if err == nil || !strings.Contains(err.Error(), supportedEventTypeList()) {
+		t.Errorf("Validate() error = %v, want it to list the supported event types", err)
+	}
+}
+
+func TestHookValidationPrompts(t *testing.T) {
+	tests := []struct {
+		name    string
+		prompts map[string]string
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "severity prompts", prompts: map[string]string{"low": "FYI: {{.ResourceName}}", "critical": "Investigate {{.ResourceName}} now"}},
+		{name: "unknown severity", prompts: map[string]string{"urgent": "Investigate now"}, wantErr: true},
+		{name: "empty prompt", prompts: map[string]string{"high": " "}, wantErr: true},
+		{name: "unknown template field", prompts: map[string]string{"high": "Pod {{.PodName}} restarted"}, wantErr: true},
+		{name: "dangerous construct", prompts: map[string]string{"high": "{{printf \"%s\" .ResourceName}}"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType: "pod-restart",
+					AgentRef:  ObjectReference{Name: "agent-123"},
+					Prompt:    "Pod has restarted",
+					Prompts:   tt.prompts,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	config := EventConfiguration{Prompt: "default", Prompts: map[string]string{"critical": "detailed"}}
+	if config.PromptFor("critical") != "detailed" || config.PromptFor("low") != "default" {
+		t.Errorf("PromptFor() did not fall back to Prompt for severities without a prompt")
+	}
+}
+
+func TestHookValidationContextMetadataKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "keys", keys: []string{"kind", "count"}},
+		{name: "empty key", keys: []string{"kind", ""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType:           "pod-restart",
+					AgentRef:            ObjectReference{Name: "agent-123"},
+					Prompt:              "Pod has restarted",
+					ContextMetadataKeys: tt.keys,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationMinEventCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int32
+		wantErr bool
+	}{
+		{name: "unset"},
+		{name: "threshold", count: 5},
+		{name: "negative", count: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType:     "pod-restart",
+					AgentRef:      ObjectReference{Name: "agent-123"},
+					Prompt:        "Pod has restarted",
+					MinEventCount: tt.count,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	config := EventConfiguration{MinEventCount: 5}
+	if config.MatchesEventCount("4") || !config.MatchesEventCount("5") || config.MatchesEventCount("") {
+		t.Errorf("MatchesEventCount() did not match only counts of at least 5")
+	}
+	if config := (EventConfiguration{}); !config.MatchesEventCount("") {
+		t.Errorf("MatchesEventCount() without a minimum should match every event")
+	}
+}
+
+func TestHookValidationAgentCallTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout *metav1.Duration
+		wantErr bool
+	}{
+		{name: "unset"},
+		{name: "ten minutes", timeout: &metav1.Duration{Duration: 10 * time.Minute}},
+		{name: "maximum", timeout: &metav1.Duration{Duration: MaxAgentCallTimeout}},
+		{name: "zero", timeout: &metav1.Duration{}, wantErr: true},
+		{name: "negative", timeout: &metav1.Duration{Duration: -time.Second}, wantErr: true},
+		{name: "above maximum", timeout: &metav1.Duration{Duration: time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType:        "pod-restart",
+					AgentRef:         ObjectReference{Name: "agent-123"},
+					Prompt:           "Pod has restarted",
+					AgentCallTimeout: tt.timeout,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationAggregationWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  *metav1.Duration
+		prompt  string
+		wantErr bool
+	}{
+		{name: "unset"},
+		{name: "thirty seconds", window: &metav1.Duration{Duration: 30 * time.Second}},
+		{name: "maximum", window: &metav1.Duration{Duration: MaxAggregationWindow}},
+		{name: "range over resources", window: &metav1.Duration{Duration: 30 * time.Second},
+			prompt: "Pods restarted: {{range .Resources}}{{.ResourceName}} ({{.Reason}}) {{end}}"},
+		{name: "zero", window: &metav1.Duration{}, wantErr: true},
+		{name: "negative", window: &metav1.Duration{Duration: -time.Second}, wantErr: true},
+		{name: "above maximum", window: &metav1.Duration{Duration: time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt := tt.prompt
+			if prompt == "" {
+				prompt = "Pod has restarted"
+			}
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType:         "pod-restart",
+					AgentRef:          ObjectReference{Name: "agent-123"},
+					Prompt:            prompt,
+					AggregationWindow: tt.window,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationMinActiveDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration *metav1.Duration
+		wantErr  bool
+	}{
+		{name: "unset"},
+		{name: "one minute", duration: &metav1.Duration{Duration: time.Minute}},
+		{name: "maximum", duration: &metav1.Duration{Duration: MaxMinActiveDuration}},
+		{name: "zero", duration: &metav1.Duration{}, wantErr: true},
+		{name: "negative", duration: &metav1.Duration{Duration: -time.Second}, wantErr: true},
+		{name: "above maximum", duration: &metav1.Duration{Duration: time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType:         "pod-pending",
+					AgentRef:          ObjectReference{Name: "agent-123"},
+					Prompt:            "Pod is pending",
+					MinActiveDuration: tt.duration,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationAgentRefTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		agentName string
+		template  bool
+		expectErr bool
+	}{
+		{name: "plain name", agentName: "agent-123"},
+		{name: "template without templating enabled", agentName: "{{.Namespace}}-agent", expectErr: true},
+		{name: "template with templating enabled", agentName: "{{.Namespace}}-agent", template: true},
+		{name: "invalid characters outside placeholders", agentName: "{{.Namespace}}/agent", template: true, expectErr: true},
+		{name: "unmatched brackets", agentName: "{{.Namespace-agent", template: true, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{
+					EventConfigurations: []EventConfiguration{
+						{
+							EventType:        "pod-restart",
+							AgentRef:         ObjectReference{Name: tt.agentName},
+							Prompt:           "Pod has restarted",
+							AgentRefTemplate: tt.template,
+						},
+					},
+				},
+			}
+
+			err := hook.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationSlackWebhookURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		expectErr bool
+	}{
+		{name: "unset", url: ""},
+		{name: "https URL", url: "https://hooks.slack.com/services/T000/B000/XXXX"},
+		{name: "http URL", url: "http://hooks.slack.com/services/T000/B000/XXXX", expectErr: true},
+		{name: "not a URL", url: "hooks.slack.com", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{
+					EventConfigurations: []EventConfiguration{
+						{
+							EventType:       "pod-restart",
+							AgentRef:        ObjectReference{Name: "agent-123"},
+							Prompt:          "Pod has restarted",
+							SlackWebhookURL: tt.url,
+						},
+					},
+				},
+			}
+
+			err := hook.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationOutputFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		expectErr bool
+	}{
+		{name: "unset", format: ""},
+		{name: "text", format: OutputFormatText},
+		{name: "json", format: OutputFormatJSON},
+		{name: "unsupported", format: "yaml", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{
+					EventConfigurations: []EventConfiguration{
+						{
+							EventType:    "pod-restart",
+							AgentRef:     ObjectReference{Name: "agent-123"},
+							Prompt:       "Pod has restarted",
+							OutputFormat: tt.format,
+						},
+					},
+				},
+			}
+
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateCreate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+
+			err = hook.Validate()
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Validate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestHookValidationPromptTemplateFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		prompt    string
+		errSubstr string
+	}{
+		{name: "known fields", prompt: "Pod {{.ResourceName}} in {{.Namespace}} failed: {{.Reason}} {{.EventMessage}} at {{.EventTime}}"},
+		{name: "event fields", prompt: "{{.Event.Type}} on {{.Event.Metadata.kind}} {{.Event.ResourceName}}"},
+		{name: "conditional", prompt: "{{if .Message}}Message: {{.Message}}{{else}}No message for {{.EventType}}{{end}}"},
+		{name: "with block uses its own scope", prompt: "{{with .Event.Metadata}}{{.kind}}{{end}}"},
+		{name: "misspelled field", prompt: "Pod {{.Resourcename}} restarted", errSubstr: ".Resourcename"},
+		{name: "all unknown fields listed", prompt: "{{.Pod}} {{.Namespace}} {{.Node}}", errSubstr: ".Pod, .Node"},
+		{name: "unknown event field", prompt: "{{.Event.PodName}}", errSubstr: ".Event.PodName"},
+		{name: "unknown field in conditional", prompt: "{{if .Severity}}urgent{{end}}", errSubstr: ".Severity"},
+		{name: "parse error", prompt: "{{if .ResourceName}}restarted", errSubstr: "not a valid template"},
+		{name: "execution error", prompt: "{{index .ResourceName 100}}", errSubstr: "sample event"},
+		{name: "template functions", prompt: "{{.ResourceName | upper}} {{.Reason | default \"unknown\" | lower}} {{.Message | trim | truncate 80 | replace \"\\n\" \" \"}}"},
+		{name: "unknown function", prompt: "{{.ResourceName | capitalize}}", errSubstr: "available functions are upper, lower, trim, default, truncate, replace"},
+		{name: "piped builtin not allowed", prompt: "{{.Message | printf \"%q\"}}", errSubstr: `function "printf" is not allowed`},
+		{name: "piped builtin in conditional not allowed", prompt: "{{if .Message}}{{.Message | urlquery}}{{end}}", errSubstr: `function "urlquery" is not allowed`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{
+					EventConfigurations: []EventConfiguration{
+						{
+							EventType: "pod-restart",
+							AgentRef:  ObjectReference{Name: "agent-123"},
+							Prompt:    tt.prompt,
+						},
+					},
+				},
+			}
+
+			// The admission webhook and Validate must agree
+			_, createErr := hook.ValidateCreate(context.Background(), hook)
+			for name, err := range map[string]error{"ValidateCreate()": createErr, "Validate()": hook.Validate()} {
+				if tt.errSubstr == "" {
+					if err != nil {
+						t.Errorf("%s unexpected error = %v", name, err)
+					}
+					continue
+				}
+				if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+					t.Errorf("%s error = %v, want error containing %q", name, err, tt.errSubstr)
+				}
+			}
+		})
+	}
+}
+
+func TestHookValidationResourceSelector(t *testing.T) {
+	tests := []struct {
+		name        string
+		selector    *metav1.LabelSelector
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{name: "unset"},
+		{name: "match labels", selector: &metav1.LabelSelector{MatchLabels: map[string]string{"khook.io/watch": "true"}}},
+		{name: "match expressions", selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend", "backend"}},
+		}}},
+		{name: "annotations", annotations: map[string]string{"team": "payments"}},
+		{name: "invalid operator", selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: "Like", Values: []string{"frontend"}},
+		}}, wantErr: true},
+		{name: "invalid label key", selector: &metav1.LabelSelector{MatchLabels: map[string]string{"bad key": "true"}}, wantErr: true},
+		{name: "empty annotation key", annotations: map[string]string{"": "payments"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &Hook{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+				Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+					EventType:           "pod-restart",
+					AgentRef:            ObjectReference{Name: "agent-123"},
+					Prompt:              "Pod has restarted",
+					ResourceSelector:    tt.selector,
+					ResourceAnnotations: tt.annotations,
+				}}},
+			}
+			_, err := hook.ValidateCreate(context.Background(), hook)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := hook.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	config := EventConfiguration{
+		ResourceSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"khook.io/watch": "true"}},
+		ResourceAnnotations: map[string]string{"team": "payments"},
+	}
+	matchTests := []struct {
+		labels      map[string]string
+		annotations map[string]string
+		want        bool
+	}{
+		{labels: map[string]string{"khook.io/watch": "true"}, annotations: map[string]string{"team": "payments"}, want: true},
+		{labels: map[string]string{"khook.io/watch": "false"}, annotations: map[string]string{"team": "payments"}},
+		{labels: map[string]string{"khook.io/watch": "true"}, annotations: map[string]string{"team": "search"}},
+		{},
+	}
+	for _, tt := range matchTests {
+		if got, err := config.MatchesResourceMetadata(tt.labels, tt.annotations); err != nil || got != tt.want {
+			t.Errorf("MatchesResourceMetadata(%v, %v) = %v, %v, want %v", tt.labels, tt.annotations, got, err, tt.want)
+		}
+	}
+
+	copied := config.DeepCopy()
+	copied.ResourceSelector.MatchLabels["khook.io/watch"] = "false"
+	copied.ResourceAnnotations["team"] = "search"
+	if config.ResourceSelector.MatchLabels["khook.io/watch"] != "true" || config.ResourceAnnotations["team"] != "payments" {
+		t.Errorf("DeepCopy() shares the resource selector with the original")
+	}
+}
+
+// staticWarner returns fixed warnings or an error
+type staticWarner struct {
+	warnings admission.Warnings
+	err      error
+}
+
+func (w staticWarner) HookWarnings(ctx context.Context, hook *Hook) (admission.Warnings, error) {
+	return w.warnings, w.err
+}
+
+func TestHookValidatorWarners(t *testing.T) {
+	validator := &HookValidator{Warners: []HookWarner{
+		staticWarner{warnings: admission.Warnings{"overlaps with hook other"}},
+		staticWarner{err: errors.New("connection refused")},
+	}}
+	hook := &Hook{
+		ObjectMeta: metav1.ObjectMeta{Name: "hook", Namespace: "default"},
+		Spec: HookSpec{EventConfigurations: []EventConfiguration{{
+			EventType: "pod-restart",
+			AgentRef:  ObjectReference{Name: "agent"},
+			Prompt:    "prompt",
+		}}},
+	}
+
+	warnings, err := validator.ValidateCreate(context.Background(), hook)
+	if err != nil {
+		t.Fatalf("expected hook to be admitted, got %v", err)
+	}
+	if len(warnings) != 2 || warnings[0] != "overlaps with hook other" ||
+		!strings.Contains(warnings[1], "connection refused") {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	// Invalid hooks are rejected before the warners run
+	hook.Spec.EventConfigurations[0].EventType = "unknown"
+	if _, err := validator.ValidateUpdate(context.Background(), hook, hook); err == nil {
+		t.Error("expected invalid hook to be rejected")
+	}
+}
@@ -3,7 +3,17 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -13,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
 	kclient "github.com/kagent-dev/khook/internal/client"
@@ -35,28 +46,61 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var configFile string
+	var logFormat string
+	var logLevel string
+	var configReload bool
+	var enableWebhooks bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
 	flag.StringVar(&configFile, "config", "", "The controller will load its initial configuration from this file.")
+	flag.StringVar(&logFormat, "log-format", "",
+		"The log output format: console for human-readable development logs or json for structured logs. "+
+			"Defaults to logging.format in the configuration file, or console.")
+	flag.StringVar(&logLevel, "log-level", "",
+		"The log level (debug, info, warn or error). Takes precedence over --zap-log-level.")
+	flag.BoolVar(&configReload, "config-reload", false,
+		"Reload the configuration file on SIGHUP, applying the log level, sinks and deduplication windows without a restart.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the Hook validating admission webhook. Requires serving certificates in the webhook server's certificate directory.")
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
-
 	// Load configuration
-	_, err := config.Load(configFile)
+	controllerCfg, err := config.Load(configFile)
 	if err != nil {
+		ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 		setupLog.Error(err, "unable to load configuration")
 		os.Exit(1)
 	}
 
+	// The flags take precedence over the configured logging options
+	if logFormat == "" {
+		logFormat = controllerCfg.Logging.Format
+	}
+	if err := applyLogFlags(&opts, logFormat, logLevel); err != nil {
+		ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+		setupLog.Error(err, "invalid logging options")
+		os.Exit(1)
+	}
+
+	// Use the configured log level unless --log-level or --zap-log-level is set, so reloads can change it
+	var reloadableLevel *uberzap.AtomicLevel
+	defaultLevel := defaultLogLevel(&opts)
+	if opts.Level == nil {
+		level := uberzap.NewAtomicLevelAt(parseLogLevel(controllerCfg.Logging.Level, defaultLevel))
+		reloadableLevel = &level
+		opts.Level = level
+	}
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "khook",
@@ -76,30 +120,240 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Initialize Kagent client
+	kagentCli, err := kclient.NewClientFromEnv(log.Log.WithName("kagent-client"))
+	if err != nil {
+		setupLog.Error(err, "failed to initialize Kagent client from env")
+		os.Exit(1)
+	}
+
+	// Report not ready while the Kagent API is unreachable
+	if err := mgr.AddReadyzCheck("kagent", kagentCli.ReadyzCheck()); err != nil {
+		setupLog.Error(err, "unable to set up kagent ready check")
+		os.Exit(1)
+	}
+
 	// Add workflow coordinator to manage hooks and event processing
-	if err := mgr.Add(newWorkflowCoordinator(mgr)); err != nil {
+	coordinator := newWorkflowCoordinator(mgr, controllerCfg, kagentCli)
+	if err := mgr.Add(coordinator); err != nil {
 		setupLog.Error(err, "unable to add workflow coordinator")
 		os.Exit(1)
 	}
 
+	// Report not ready while a namespace workflow cannot watch events
+	if err := mgr.AddReadyzCheck("workflows", coordinator.ReadyzCheck()); err != nil {
+		setupLog.Error(err, "unable to set up workflows ready check")
+		os.Exit(1)
+	}
+
+	// Validate hooks on admission, warning about problems that need cluster state to detect
+	if enableWebhooks {
+		validator := &kagentv1alpha2.HookValidator{}
+		if controllerCfg.Controller.DetectHookConflicts {
+			validator.Warners = append(validator.Warners, &workflow.HookConflictWarner{Reader: mgr.GetAPIReader()})
+		}
+		if controllerCfg.Controller.VerifyAgentRefs {
+			validator.Warners = append(validator.Warners, &workflow.MissingAgentWarner{Checker: kagentCli})
+		}
+		if err := ctrl.NewWebhookManagedBy(mgr).For(&kagentv1alpha2.Hook{}).WithValidator(validator).Complete(); err != nil {
+			setupLog.Error(err, "unable to set up hook admission webhook")
+			os.Exit(1)
+		}
+	}
+
+	// Serve debug endpoints next to the metrics
+	if controllerCfg.Controller.EnableDiagnosticsEndpoints {
+		if err := mgr.AddMetricsServerExtraHandler(workflow.DedupDiagnosticsPath, coordinator.DedupDiagnosticsHandler()); err != nil {
+			setupLog.Error(err, "unable to set up deduplication diagnostics endpoint")
+			os.Exit(1)
+		}
+		if err := mgr.AddMetricsServerExtraHandler(workflow.EventPreviewPath, coordinator.EventPreviewHandler()); err != nil {
+			setupLog.Error(err, "unable to set up event preview endpoint")
+			os.Exit(1)
+		}
+		if err := mgr.AddMetricsServerExtraHandler(workflow.HookLatencyPath, coordinator.HookLatencyHandler()); err != nil {
+			setupLog.Error(err, "unable to set up hook latency endpoint")
+			os.Exit(1)
+		}
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	if configReload {
+		go reloadOnSIGHUP(ctx, configFile, coordinator, reloadableLevel, defaultLevel)
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
+// applyLogFlags configures opts from --log-format and --log-level. The json format replaces
+// the development console logger with structured production logging; text is the same as
+// console.
+func applyLogFlags(opts *zap.Options, format, level string) error {
+	switch format {
+	case "", "console", "text":
+	case "json":
+		opts.Development = false
+	default:
+		return fmt.Errorf("unsupported log format %q, must be console or json", format)
+	}
+
+	if level != "" {
+		parsed, err := zapcore.ParseLevel(level)
+		if err != nil {
+			return fmt.Errorf("invalid log level: %w", err)
+		}
+		opts.Level = parsed
+	}
+	return nil
+}
+
+// defaultLogLevel is the level logged at when no level is configured: debug for the
+// development console logger and info for structured logs, as zap would
+func defaultLogLevel(opts *zap.Options) zapcore.Level {
+	if opts.Development {
+		return zapcore.DebugLevel
+	}
+	return zapcore.InfoLevel
+}
+
+// parseLogLevel converts a configured log level to a zap level, using fallback when the
+// level is unset or invalid
+func parseLogLevel(level string, fallback zapcore.Level) zapcore.Level {
+	if level == "" {
+		return fallback
+	}
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		setupLog.Error(err, "Ignoring invalid log level", "level", level)
+		return fallback
+	}
+	return parsed
+}
+
+// reloadOnSIGHUP reloads the configuration file whenever the process receives SIGHUP until
+// ctx is done. Options that cannot change at runtime are logged as ignored. logLevel is nil
+// when the log level is set by flag and cannot be reloaded; an unset level reverts to
+// defaultLevel.
+func reloadOnSIGHUP(ctx context.Context, configFile string, coordinator *workflowCoordinator, logLevel *uberzap.AtomicLevel, defaultLevel zapcore.Level) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+		}
+
+		setupLog.Info("Reloading configuration", "config", configFile)
+		updated, err := config.Load(configFile)
+		if err != nil {
+			setupLog.Error(err, "Failed to reload configuration; keeping the current configuration")
+			continue
+		}
+
+		reloaded, applied, ignored := coordinator.Reload(updated)
+		if logLevel != nil {
+			logLevel.SetLevel(parseLogLevel(reloaded.Logging.Level, defaultLevel))
+		} else if slices.Contains(applied, "logging.level") {
+			setupLog.Info("Ignoring reloaded log level; it is set by the --log-level or --zap-log-level flag")
+		}
+		setupLog.Info("Reloaded configuration", "applied", applied)
+		if len(ignored) > 0 {
+			setupLog.Info("Ignoring changed options that require a restart", "options", ignored)
+		}
+	}
+}
+
 // workflowCoordinator manages the complete workflow lifecycle using proper services
 type workflowCoordinator struct {
-	mgr ctrl.Manager
+	mgr       ctrl.Manager
+	kagentCli *kclient.Client
+
+	// cfgMutex guards cfg, which reloads replace while the coordinator starts
+	cfgMutex sync.Mutex
+	cfg      *config.Config
+
+	// coordinator is set once this replica starts processing events as the leader
+	coordinator atomic.Pointer[workflow.Coordinator]
 }
 
-func newWorkflowCoordinator(mgr ctrl.Manager) *workflowCoordinator {
-	return &workflowCoordinator{mgr: mgr}
+func newWorkflowCoordinator(mgr ctrl.Manager, cfg *config.Config, kagentCli *kclient.Client) *workflowCoordinator {
+	return &workflowCoordinator{mgr: mgr, cfg: cfg, kagentCli: kagentCli}
 }
 
 func (w *workflowCoordinator) NeedLeaderElection() bool { return true }
 
+// ReadyzCheck returns a readiness check that fails while any namespace workflow has stopped
+// processing events. Replicas that are not the leader run no workflows and are ready.
+func (w *workflowCoordinator) ReadyzCheck() healthz.Checker {
+	return func(_ *http.Request) error {
+		if coordinator := w.coordinator.Load(); coordinator != nil {
+			return coordinator.CheckHealth()
+		}
+		return nil
+	}
+}
+
+// DedupDiagnosticsHandler returns a debug handler serving the deduplication state of a hook.
+// Replicas that are not the leader track no events and respond with 503.
+func (w *workflowCoordinator) DedupDiagnosticsHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		coordinator := w.coordinator.Load()
+		if coordinator == nil {
+			http.Error(rw, "this replica is not processing events", http.StatusServiceUnavailable)
+			return
+		}
+		coordinator.ServeDedupDiagnostics(rw, req)
+	})
+}
+
+// EventPreviewHandler returns a debug handler previewing the agent requests of a posted
+// event. Replicas that are not the leader run no coordinator and respond with 503.
+func (w *workflowCoordinator) EventPreviewHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		coordinator := w.coordinator.Load()
+		if coordinator == nil {
+			http.Error(rw, "this replica is not processing events", http.StatusServiceUnavailable)
+			return
+		}
+		coordinator.ServeEventPreview(rw, req)
+	})
+}
+
+// HookLatencyHandler returns a debug handler serving the recent processing latencies of a
+// hook. Replicas that are not the leader process no events and respond with 503.
+func (w *workflowCoordinator) HookLatencyHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		coordinator := w.coordinator.Load()
+		if coordinator == nil {
+			http.Error(rw, "this replica is not processing events", http.StatusServiceUnavailable)
+			return
+		}
+		coordinator.ServeHookLatency(rw, req)
+	})
+}
+
+// Reload replaces the configuration with the options of updated that can change at runtime
+// and applies them to the running coordinator, if any. It returns the reloaded configuration
+// and the changed options that were applied and ignored.
+func (w *workflowCoordinator) Reload(updated *config.Config) (reloaded *config.Config, applied, ignored []string) {
+	w.cfgMutex.Lock()
+	defer w.cfgMutex.Unlock()
+
+	reloaded, applied, ignored = w.cfg.Reload(updated)
+	w.cfg = reloaded
+	if coordinator := w.coordinator.Load(); coordinator != nil {
+		coordinator.ApplyConfig(reloaded)
+	}
+	return reloaded, applied, ignored
+}
+
 func (w *workflowCoordinator) Start(ctx context.Context) error {
 	logger := log.Log.WithName("workflow-coordinator")
 	logger.Info("Starting workflow coordinator")
@@ -112,16 +366,13 @@ func (w *workflowCoordinator) Start(ctx context.Context) error {
 		return err
 	}
 
-	// Initialize Kagent client
-	kagentCli, err := kclient.NewClientFromEnv(log.Log.WithName("kagent-client"))
-	if err != nil {
-		logger.Error(err, "failed to initialize Kagent client from env")
-		return err
-	}
-
-	// Create workflow coordinator
+	// Create workflow coordinator. Hold the config while it is published so a concurrent
+	// reload either precedes it or is applied to it.
 	eventRecorder := w.mgr.GetEventRecorderFor("khook")
-	coordinator := workflow.NewCoordinator(k8s, w.mgr.GetClient(), kagentCli, eventRecorder)
+	w.cfgMutex.Lock()
+	coordinator := workflow.NewCoordinator(k8s, w.mgr.GetClient(), w.kagentCli, eventRecorder, w.cfg)
+	w.coordinator.Store(coordinator)
+	w.cfgMutex.Unlock()
 
 	// Start the coordinator
 	return coordinator.Start(ctx)
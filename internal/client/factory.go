@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -31,6 +32,30 @@ func NewClientFromEnv(logger logr.Logger) (*Client, error) {
 		config.Timeout = timeout
 	}
 
+	if attemptsStr := os.Getenv("KAGENT_API_RETRY_ATTEMPTS"); attemptsStr != "" {
+		attempts, err := strconv.Atoi(attemptsStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KAGENT_API_RETRY_ATTEMPTS format: %w", err)
+		}
+		config.Retry.MaxAttempts = attempts
+	}
+
+	if backoffStr := os.Getenv("KAGENT_API_RETRY_BACKOFF"); backoffStr != "" {
+		backoff, err := time.ParseDuration(backoffStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KAGENT_API_RETRY_BACKOFF format: %w", err)
+		}
+		config.Retry.BaseDelay = backoff
+	}
+
+	if coalesceStr := os.Getenv("KAGENT_API_COALESCE_REQUESTS"); coalesceStr != "" {
+		coalesce, err := strconv.ParseBool(coalesceStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KAGENT_API_COALESCE_REQUESTS format: %w", err)
+		}
+		config.CoalesceRequests = coalesce
+	}
+
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid client configuration: %w", err)
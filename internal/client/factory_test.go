@@ -69,6 +69,31 @@ func TestNewClientFromEnv(t *testing.T) {
 		assert.Equal(t, "https://new.api.com", client.config.BaseURL)
 	})
 
+	t.Run("retry policy from environment", func(t *testing.T) {
+		os.Setenv("KAGENT_API_RETRY_ATTEMPTS", "5")
+		os.Setenv("KAGENT_API_RETRY_BACKOFF", "250ms")
+		defer func() {
+			os.Unsetenv("KAGENT_API_RETRY_ATTEMPTS")
+			os.Unsetenv("KAGENT_API_RETRY_BACKOFF")
+		}()
+
+		client, err := NewClientFromEnv(logger)
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, client.config.Retry.MaxAttempts)
+		assert.Equal(t, 250*time.Millisecond, client.config.Retry.BaseDelay)
+	})
+
+	t.Run("request coalescing from environment", func(t *testing.T) {
+		os.Setenv("KAGENT_API_COALESCE_REQUESTS", "true")
+		defer os.Unsetenv("KAGENT_API_COALESCE_REQUESTS")
+
+		client, err := NewClientFromEnv(logger)
+		require.NoError(t, err)
+
+		assert.True(t, client.config.CoalesceRequests)
+	})
+
 	t.Run("invalid timeout format", func(t *testing.T) {
 		os.Setenv("KAGENT_API_TIMEOUT", "invalid")
 		defer os.Unsetenv("KAGENT_API_TIMEOUT")
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+// FakeClient is an in-memory KagentClient for tests. It records every agent call and
+// answers with the response or error programmed for the agent, or a successful response
+// naming the agent otherwise. It is safe for concurrent use.
+type FakeClient struct {
+	responses map[types.NamespacedName]*interfaces.AgentResponse
+	errors    map[types.NamespacedName]error
+	calls     []interfaces.AgentRequest
+	mutex     sync.Mutex
+}
+
+var _ interfaces.KagentClient = (*FakeClient)(nil)
+
+// NewFakeClient creates a fake client answering every agent call successfully
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		responses: make(map[types.NamespacedName]*interfaces.AgentResponse),
+		errors:    make(map[types.NamespacedName]error),
+	}
+}
+
+// CallAgent records request and returns the response or error programmed for its agent
+func (f *FakeClient) CallAgent(ctx context.Context, request interfaces.AgentRequest) (*interfaces.AgentResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls = append(f.calls, request)
+	if err, ok := f.errors[request.AgentRef]; ok {
+		return nil, err
+	}
+	if response, ok := f.responses[request.AgentRef]; ok {
+		// Give each caller its own copy so callers cannot change the programmed response
+		copied := *response
+		return &copied, nil
+	}
+	return &interfaces.AgentResponse{
+		Success:   true,
+		Message:   "Fake response",
+		RequestId: "fake-request-" + request.AgentRef.String(),
+		SessionID: "fake-session-" + request.AgentRef.String(),
+	}, nil
+}
+
+// Authenticate always succeeds
+func (f *FakeClient) Authenticate() error {
+	return nil
+}
+
+// SetResponse makes calls to agentRef return response
+func (f *FakeClient) SetResponse(agentRef types.NamespacedName, response *interfaces.AgentResponse) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.errors, agentRef)
+	f.responses[agentRef] = response
+}
+
+// SetError makes calls to agentRef fail with err
+func (f *FakeClient) SetError(agentRef types.NamespacedName, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.responses, agentRef)
+	f.errors[agentRef] = err
+}
+
+// Calls returns the agent calls made so far, oldest first
+func (f *FakeClient) Calls() []interfaces.AgentRequest {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]interfaces.AgentRequest(nil), f.calls...)
+}
+
+// ClearCalls forgets the recorded agent calls
+func (f *FakeClient) ClearCalls() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls = nil
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+func TestFakeClient(t *testing.T) {
+	fake := NewFakeClient()
+	ctx := context.Background()
+	working := types.NamespacedName{Namespace: "default", Name: "working-agent"}
+	failing := types.NamespacedName{Namespace: "default", Name: "failing-agent"}
+	programmed := types.NamespacedName{Namespace: "default", Name: "programmed-agent"}
+
+	fake.SetError(failing, errors.New("agent unavailable"))
+	fake.SetResponse(programmed, &interfaces.AgentResponse{Success: true, RequestId: "programmed"})
+
+	response, err := fake.CallAgent(ctx, interfaces.AgentRequest{AgentRef: working, EventName: "pod-restart"})
+	require.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "fake-request-default/working-agent", response.RequestId)
+
+	_, err = fake.CallAgent(ctx, interfaces.AgentRequest{AgentRef: failing, EventName: "oom-kill"})
+	assert.ErrorContains(t, err, "agent unavailable")
+
+	response, err = fake.CallAgent(ctx, interfaces.AgentRequest{AgentRef: programmed})
+	require.NoError(t, err)
+	assert.Equal(t, "programmed", response.RequestId)
+
+	// Changing a returned response does not change later responses
+	response.RequestId = "changed"
+	response, err = fake.CallAgent(ctx, interfaces.AgentRequest{AgentRef: programmed})
+	require.NoError(t, err)
+	assert.Equal(t, "programmed", response.RequestId)
+
+	calls := fake.Calls()
+	require.Len(t, calls, 4)
+	assert.Equal(t, working, calls[0].AgentRef)
+	assert.Equal(t, "oom-kill", calls[1].EventName)
+
+	// A programmed response replaces a programmed error
+	fake.SetResponse(failing, &interfaces.AgentResponse{Success: true})
+	_, err = fake.CallAgent(ctx, interfaces.AgentRequest{AgentRef: failing})
+	assert.NoError(t, err)
+
+	fake.ClearCalls()
+	assert.Empty(t, fake.Calls())
+	assert.NoError(t, fake.Authenticate())
+}
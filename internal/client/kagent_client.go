@@ -3,22 +3,44 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kagent-dev/kagent/go/pkg/client"
 	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	khookerrors "github.com/kagent-dev/khook/internal/errors"
 	"github.com/kagent-dev/khook/internal/interfaces"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
+// HealthCheckTimeout bounds readiness checks so they answer within the kubelet's default
+// 1s probe timeout
+const HealthCheckTimeout = 800 * time.Millisecond
+
+// IdempotencyKeyHeader carries a request's idempotency key so the Kagent backend can
+// recognize a retried agent call whose earlier attempt already succeeded
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxReplySummaryLength bounds the agent reply kept in hook status
+const maxReplySummaryLength = 1024
+
 // Config holds the configuration for the Kagent API client
 type Config struct {
 	BaseURL string
 	UserID  string
 	Timeout time.Duration
+	Retry   RetryPolicy
+
+	// CoalesceRequests shares a single in-flight call between concurrent requests with the
+	// same coalesce key, e.g. from hooks calling the same agent about the same event
+	CoalesceRequests bool
 }
 
 // Validate validates the client configuration
@@ -74,6 +96,15 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("Timeout too long: %v (max 300s)", c.Timeout)
 	}
 
+	// Validate Retry
+	if c.Retry.MaxAttempts > 10 {
+		return fmt.Errorf("Retry.MaxAttempts too large: %d (max 10)", c.Retry.MaxAttempts)
+	}
+
+	if c.Retry.BaseDelay < 0 || c.Retry.MaxDelay < 0 {
+		return fmt.Errorf("Retry delays cannot be negative")
+	}
+
 	return nil
 }
 
@@ -83,6 +114,7 @@ func DefaultConfig() *Config {
 		BaseURL: "http://kagent-controller.kagent.svc.local:8083",
 		UserID:  "admin@kagent.dev",
 		Timeout: 120 * time.Second,
+		Retry:   DefaultRetryPolicy(),
 	}
 }
 
@@ -91,6 +123,12 @@ type Client struct {
 	config    *Config
 	clientSet *client.ClientSet
 	logger    logr.Logger
+
+	// inflight coalesces concurrent calls by coalesce key
+	inflight singleflight.Group
+
+	// disconnected records the outcome of the last health check to log connectivity changes
+	disconnected atomic.Bool
 }
 
 // NewClient creates a new Kagent API client
@@ -129,38 +167,98 @@ func (c *Client) Authenticate() error {
 	return nil
 }
 
-// CallAgent makes a request to the Kagent API to trigger an agent
-func (c *Client) CallAgent(ctx context.Context, request interfaces.AgentRequest) (*interfaces.AgentResponse, error) {
-	// Create a session for this agent call
-	sessionName := fmt.Sprintf("hook-%s-%d", request.EventName, time.Now().Unix())
-	agentRefString := request.AgentRef.String()
-	sessionReq := &api.SessionRequest{
-		AgentRef: &agentRefString,
-		Name:     &sessionName,
+// CheckHealth verifies that the Kagent API is reachable, giving up after HealthCheckTimeout.
+// Changes between connected and disconnected are logged.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	if err := c.clientSet.Health.Get(ctx); err != nil {
+		if !c.disconnected.Swap(true) {
+			c.logger.Error(err, "Kagent API disconnected", "baseURL", c.config.BaseURL)
+		}
+		return fmt.Errorf("failed to connect to Kagent API: %w", err)
 	}
 
-	c.logger.Info("Creating session for agent call",
-		"sessionName", sessionName,
-		"agentId", request.AgentRef.String(),
-		"eventName", request.EventName)
+	if c.disconnected.Swap(false) {
+		c.logger.Info("Kagent API connected", "baseURL", c.config.BaseURL)
+	}
+	return nil
+}
 
-	sessionResp, err := c.clientSet.Session.CreateSession(ctx, sessionReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create session: %w", err)
+// ReadyzCheck returns a readiness check that fails while the Kagent API is unreachable
+func (c *Client) ReadyzCheck() healthz.Checker {
+	return func(req *http.Request) error {
+		return c.CheckHealth(req.Context())
 	}
+}
 
-	if sessionResp.Error {
-		return nil, fmt.Errorf("session creation failed: %s", sessionResp.Message)
+// AgentExists reports whether the agent is known to the Kagent API. A missing agent is
+// not an error; failing to reach the API is.
+func (c *Client) AgentExists(ctx context.Context, agentRef types.NamespacedName) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	if _, err := c.clientSet.Agent.GetAgent(ctx, agentRef.String()); err != nil {
+		if status, ok := httpStatus(err); ok && status == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get agent %s: %w", agentRef, err)
 	}
+	return true, nil
+}
 
-	sessionNameStr := ""
-	if sessionResp.Data.Name != nil {
-		sessionNameStr = *sessionResp.Data.Name
+// CallAgent makes a request to the Kagent API to trigger an agent
+func (c *Client) CallAgent(ctx context.Context, request interfaces.AgentRequest) (*interfaces.AgentResponse, error) {
+	if !c.config.CoalesceRequests || request.CoalesceKey == "" {
+		return c.callAgent(ctx, request)
 	}
 
-	c.logger.Info("Session created successfully",
-		"sessionId", sessionResp.Data.ID,
-		"sessionName", sessionNameStr)
+	// Concurrent callers with the same key share the first caller's in-flight call. The call
+	// is not cancelled with the first caller, as others may be waiting on it; it is bounded by
+	// the call timeout and retry policy instead, and each caller stops waiting when its own
+	// context is done.
+	results := c.inflight.DoChan(request.CoalesceKey, func() (interface{}, error) {
+		return c.callAgent(context.WithoutCancel(ctx), request)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.Shared {
+			c.logger.V(1).Info("Coalesced agent call with in-flight request",
+				"agentRef", request.AgentRef.String(),
+				"coalesceKey", request.CoalesceKey)
+		}
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		// Give each caller its own copy so callers cannot observe each other's changes
+		response := *result.Val.(*interfaces.AgentResponse)
+		return &response, nil
+	}
+}
+
+// callAgent sends a single agent request to the Kagent API
+func (c *Client) callAgent(ctx context.Context, request interfaces.AgentRequest) (*interfaces.AgentResponse, error) {
+	// Continue the prior task's session, or create a session for this agent call
+	sessionID := request.TaskID
+	sessionNameStr := ""
+	if sessionID == "" {
+		session, err := c.createSession(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		sessionID = session.ID
+		if session.Name != nil {
+			sessionNameStr = *session.Name
+		}
+	} else {
+		c.logger.Info("Continuing existing session for agent call",
+			"sessionId", sessionID,
+			"agentId", request.AgentRef.String(),
+			"eventName", request.EventName)
+	}
 
 	// Compose message from prompt and event context
 	text := request.Prompt
@@ -174,31 +272,55 @@ func (c *Client) CallAgent(ctx context.Context, request interfaces.AgentRequest)
 		if msg, ok := request.Context["message"].(string); ok && msg != "" {
 			text += fmt.Sprintf("\nMessage: %s", msg)
 		}
+		if schedulingReason, ok := request.Context["schedulingReason"].(string); ok && schedulingReason != "" {
+			text += fmt.Sprintf("\nScheduling reason: %s", schedulingReason)
+		}
+		if probeType, ok := request.Context["probeType"].(string); ok && probeType != "" {
+			text += fmt.Sprintf("\nProbe type: %s", probeType)
+		}
+		if owner, ok := request.Context["owner"].(string); ok && owner != "" {
+			text += fmt.Sprintf("\nOwner: %s", owner)
+		}
+		if lifecycle, ok := request.Context["lifecycle"].(string); ok && lifecycle != "" {
+			text += fmt.Sprintf("\nLifecycle: %s", lifecycle)
+		}
 	}
 
 	// Use A2A SendMessage (POST). Provide a clean base URL with trailing slash; no query params.
 	a2aURL := fmt.Sprintf("%s/api/a2a/%s/", c.config.BaseURL, request.AgentRef.String())
-	a2a, err := a2aclient.NewA2AClient(a2aURL)
+	var a2aOptions []a2aclient.Option
+	if request.IdempotencyKey != "" {
+		a2aOptions = append(a2aOptions, a2aclient.WithHTTPReqHandler(idempotencyKeyHandler{key: request.IdempotencyKey}))
+	}
+	a2a, err := a2aclient.NewA2AClient(a2aURL, a2aOptions...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create A2A client: %w", err)
+		return nil, khookerrors.New(khookerrors.KindInvalidConfig, fmt.Errorf("failed to create A2A client: %w", err))
 	}
 
-	sendCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
-	defer cancel()
-
-	sessionID := sessionResp.Data.ID
-	res, err := a2a.SendMessage(sendCtx, protocol.SendMessageParams{
-		Message: protocol.Message{
-			Role:      protocol.MessageRoleUser,
-			ContextID: &sessionID,
-			Parts:     []protocol.Part{protocol.NewTextPart(text)},
-		},
+	var res *protocol.MessageResult
+	err = c.withRetry(ctx, "send message", isSendRetryable, func() error {
+		timeout := c.config.Timeout
+		if request.Timeout > 0 {
+			timeout = request.Timeout
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		var err error
+		res, err = a2a.SendMessage(sendCtx, protocol.SendMessageParams{
+			Message: protocol.Message{
+				Role:      protocol.MessageRoleUser,
+				ContextID: &sessionID,
+				Parts:     []protocol.Part{protocol.NewTextPart(text)},
+			},
+		})
+		return err
 	})
 	if err != nil {
 		c.logger.Error(err, "Failed to send message to agent",
 			"agentRef", request.AgentRef.String(),
-			"sessionId", sessionResp.Data.ID)
-		return nil, fmt.Errorf("failed to send A2A message: %w", err)
+			"sessionId", sessionID)
+		return nil, fmt.Errorf("failed to send A2A message: %w", classify(err))
 	}
 
 	_, isTask := res.Result.(*protocol.Task)
@@ -208,10 +330,17 @@ func (c *Client) CallAgent(ctx context.Context, request interfaces.AgentRequest)
 		"sessionId", sessionID,
 		"taskReturned", isTask)
 
+	message := fmt.Sprintf("Session created successfully: %s", sessionNameStr)
+	if request.TaskID != "" {
+		message = fmt.Sprintf("Continued session: %s", sessionID)
+	}
+
 	response := &interfaces.AgentResponse{
 		Success:   true,
-		Message:   fmt.Sprintf("Session created successfully: %s", sessionNameStr),
+		Message:   message,
 		RequestId: sessionID,
+		SessionID: sessionID,
+		Summary:   replySummary(res),
 	}
 
 	c.logger.Info("Agent call completed successfully",
@@ -220,3 +349,91 @@ func (c *Client) CallAgent(ctx context.Context, request interfaces.AgentRequest)
 
 	return response, nil
 }
+
+// replySummary returns the text of the agent's reply in res, truncated to
+// maxReplySummaryLength. Tasks still running when the call returns have no reply yet.
+func replySummary(res *protocol.MessageResult) string {
+	var parts []protocol.Part
+	switch result := res.Result.(type) {
+	case *protocol.Message:
+		parts = result.Parts
+	case *protocol.Task:
+		if result.Status.Message != nil {
+			parts = result.Status.Message.Parts
+		}
+		for _, artifact := range result.Artifacts {
+			parts = append(parts, artifact.Parts...)
+		}
+	}
+
+	var texts []string
+	for _, part := range parts {
+		switch text := part.(type) {
+		case protocol.TextPart:
+			texts = append(texts, text.Text)
+		case *protocol.TextPart:
+			texts = append(texts, text.Text)
+		}
+	}
+
+	summary := strings.TrimSpace(strings.Join(texts, "\n"))
+	if runes := []rune(summary); len(runes) > maxReplySummaryLength {
+		summary = string(runes[:maxReplySummaryLength-3]) + "..."
+	}
+	return summary
+}
+
+// createSession creates a new Kagent session for the agent call
+func (c *Client) createSession(ctx context.Context, request interfaces.AgentRequest) (*api.Session, error) {
+	sessionName := fmt.Sprintf("hook-%s-%d", request.EventName, time.Now().Unix())
+	agentRefString := request.AgentRef.String()
+	sessionReq := &api.SessionRequest{
+		AgentRef: &agentRefString,
+		Name:     &sessionName,
+	}
+
+	c.logger.Info("Creating session for agent call",
+		"sessionName", sessionName,
+		"agentId", request.AgentRef.String(),
+		"eventName", request.EventName)
+
+	var sessionResp *api.StandardResponse[*api.Session]
+	err := c.withRetry(ctx, "create session", isRetryable, func() error {
+		var err error
+		sessionResp, err = c.clientSet.Session.CreateSession(ctx, sessionReq)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", classify(err))
+	}
+
+	if sessionResp.Error {
+		return nil, khookerrors.New(khookerrors.KindAgentRejected, fmt.Errorf("session creation failed: %s", sessionResp.Message))
+	}
+
+	sessionNameStr := ""
+	if sessionResp.Data.Name != nil {
+		sessionNameStr = *sessionResp.Data.Name
+	}
+
+	c.logger.Info("Session created successfully",
+		"sessionId", sessionResp.Data.ID,
+		"sessionName", sessionNameStr)
+
+	return sessionResp.Data, nil
+}
+
+// idempotencyKeyHandler sends A2A requests with the request's idempotency key header
+type idempotencyKeyHandler struct {
+	key string
+}
+
+// Handle sets the idempotency key header and sends the request
+func (h idempotencyKeyHandler) Handle(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	req.Header.Set(IdempotencyKeyHeader, h.key)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http request failed: %w", err)
+	}
+	return resp, nil
+}
@@ -2,6 +2,12 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 )
 
 func TestNewClient(t *testing.T) {
@@ -35,6 +42,82 @@ func TestNewClient(t *testing.T) {
 	})
 }
 
+func TestClient_CheckHealth(t *testing.T) {
+	logger := log.Log.WithName("test")
+
+	var healthy atomic.Bool
+	healthy.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/health", r.URL.Path)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{BaseURL: server.URL, UserID: "test-user", Timeout: 5 * time.Second}, logger)
+	check := client.ReadyzCheck()
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	assert.NoError(t, check(req))
+
+	healthy.Store(false)
+	assert.Error(t, check(req))
+	assert.True(t, client.disconnected.Load())
+
+	healthy.Store(true)
+	assert.NoError(t, check(req))
+	assert.False(t, client.disconnected.Load())
+}
+
+func TestClient_CheckHealth_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(&Config{BaseURL: server.URL, UserID: "test-user", Timeout: 5 * time.Second}, log.Log.WithName("test"))
+
+	start := time.Now()
+	assert.Error(t, client.CheckHealth(context.Background()))
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestClient_AgentExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/agents/kagent/k8s-agent":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		case "/api/agents/kagent/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"agent not found"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{BaseURL: server.URL, UserID: "test-user", Timeout: 5 * time.Second}, log.Log.WithName("test"))
+
+	exists, err := client.AgentExists(context.Background(), types.NamespacedName{Namespace: "kagent", Name: "k8s-agent"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.AgentExists(context.Background(), types.NamespacedName{Namespace: "kagent", Name: "missing"})
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Server errors are reported rather than treated as a missing agent
+	_, err = client.AgentExists(context.Background(), types.NamespacedName{Namespace: "kagent", Name: "broken"})
+	assert.Error(t, err)
+}
+
 func TestClient_Authenticate(t *testing.T) {
 	logger := log.Log.WithName("test")
 
@@ -109,9 +192,161 @@ func TestClient_CallAgent(t *testing.T) {
 	})
 }
 
+func TestReplySummary(t *testing.T) {
+	reply := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart(" Restarted the pod ")})
+	tests := []struct {
+		name     string
+		result   protocol.UnaryMessageResult
+		expected string
+	}{
+		{name: "message reply", result: &reply, expected: "Restarted the pod"},
+		{
+			name: "completed task",
+			result: &protocol.Task{
+				Status:    protocol.TaskStatus{State: protocol.TaskStateCompleted, Message: &reply},
+				Artifacts: []protocol.Artifact{{Parts: []protocol.Part{protocol.NewTextPart("Memory limit raised")}}},
+			},
+			expected: "Restarted the pod \nMemory limit raised",
+		},
+		{name: "running task", result: &protocol.Task{Status: protocol.TaskStatus{State: protocol.TaskStateWorking}}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, replySummary(&protocol.MessageResult{Result: tt.result}))
+		})
+	}
+
+	long := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart(strings.Repeat("a", 2*maxReplySummaryLength))})
+	summary := replySummary(&protocol.MessageResult{Result: &long})
+	assert.Len(t, summary, maxReplySummaryLength)
+	assert.True(t, strings.HasSuffix(summary, "..."))
+}
+
 func TestDefaultConfig(t *testing.T) {
 	config := DefaultConfig()
 	assert.Equal(t, "http://kagent-controller.kagent.svc.local:8083", config.BaseURL)
 	assert.Equal(t, "admin@kagent.dev", config.UserID)
 	assert.Equal(t, 120*time.Second, config.Timeout)
+	assert.Equal(t, DefaultRetryPolicy(), config.Retry)
+}
+
+func TestClient_CallAgent_Coalescing(t *testing.T) {
+	const callers = 5
+
+	// Each hook calls the agent with its own idempotency key about the same event
+	request := func(i int) interfaces.AgentRequest {
+		return interfaces.AgentRequest{
+			AgentRef:       types.NamespacedName{Name: "test-agent", Namespace: "default"},
+			Prompt:         "Test prompt",
+			EventName:      "pod-restart",
+			TaskID:         "session-1",
+			IdempotencyKey: fmt.Sprintf("hook-%d", i),
+			CoalesceKey:    "default/test-agent|pod-restart|default/test-pod|2024-01-15T10:30:00Z",
+		}
+	}
+
+	run := func(coalesce bool, cancelled int) ([]*interfaces.AgentResponse, []error, int32) {
+		var calls int32
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"kind":"message","messageId":"m1","role":"agent","parts":[{"kind":"text","text":"ok"}]}}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(&Config{
+			BaseURL:          server.URL,
+			UserID:           "test-user",
+			Timeout:          5 * time.Second,
+			CoalesceRequests: coalesce,
+		}, log.Log.WithName("test"))
+
+		responses := make([]*interfaces.AgentResponse, callers)
+		errs := make([]error, callers)
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				callCtx := context.Background()
+				if i < cancelled {
+					callCtx = ctx
+				}
+				responses[i], errs[i] = client.CallAgent(callCtx, request(i))
+			}(i)
+		}
+
+		// Let all callers reach the client before the in-flight call completes
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		return responses, errs, atomic.LoadInt32(&calls)
+	}
+
+	t.Run("concurrent requests about the same event share one call", func(t *testing.T) {
+		responses, errs, made := run(true, 0)
+		assert.Equal(t, int32(1), made)
+		for i, response := range responses {
+			require.NoError(t, errs[i])
+			require.NotNil(t, response)
+			assert.True(t, response.Success)
+			assert.Equal(t, "session-1", response.RequestId)
+		}
+	})
+
+	t.Run("callers stop waiting when their own context is done", func(t *testing.T) {
+		responses, errs, made := run(true, 2)
+		assert.Equal(t, int32(1), made)
+		for i := range responses {
+			if i < 2 {
+				assert.ErrorIs(t, errs[i], context.Canceled)
+				continue
+			}
+			require.NoError(t, errs[i], "the shared call outlives cancelled callers")
+			assert.Equal(t, "session-1", responses[i].RequestId)
+		}
+	})
+
+	t.Run("coalescing disabled makes every call", func(t *testing.T) {
+		_, _, made := run(false, 0)
+		assert.Equal(t, int32(callers), made)
+	})
+}
+
+func TestClient_CallAgent_IdempotencyKeyHeader(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get(IdempotencyKeyHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"1","result":{"kind":"message","messageId":"m1","role":"agent","parts":[{"kind":"text","text":"ok"}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{
+		BaseURL: server.URL,
+		UserID:  "test-user",
+		Timeout: 5 * time.Second,
+	}, log.Log.WithName("test"))
+
+	request := interfaces.AgentRequest{
+		AgentRef:       types.NamespacedName{Name: "test-agent", Namespace: "default"},
+		Prompt:         "Test prompt",
+		EventName:      "pod-restart",
+		TaskID:         "session-1",
+		IdempotencyKey: "3f2a9c",
+	}
+	_, err := client.CallAgent(context.Background(), request)
+	require.NoError(t, err)
+
+	request.IdempotencyKey = ""
+	_, err = client.CallAgent(context.Background(), request)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"3f2a9c", ""}, headers)
 }
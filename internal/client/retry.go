@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/pkg/client"
+	khookerrors "github.com/kagent-dev/khook/internal/errors"
+)
+
+// RetryPolicy controls how agent calls are retried on transient failures
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 1 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry; it doubles on each subsequent retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between retries. Zero means no cap.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the default retry policy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   1 * time.Second,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// delay returns the backoff before the given retry (1 for the first retry)
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// a2aStatusPattern extracts the HTTP status from A2A client errors, which are not typed
+var a2aStatusPattern = regexp.MustCompile(`unexpected http status (\d{3})`)
+
+// httpStatus returns the HTTP status of a failed Kagent API response, if err carries one
+func httpStatus(err error) (int, bool) {
+	var clientErr *client.ClientError
+	if errors.As(err, &clientErr) {
+		return clientErr.StatusCode, true
+	}
+
+	if m := a2aStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+		status, _ := strconv.Atoi(m[1])
+		return status, true
+	}
+	return 0, false
+}
+
+// isRetryable reports whether err is a transient failure worth retrying:
+// network errors and 5xx responses. 4xx responses fail immediately.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if status, ok := httpStatus(err); ok {
+		return status >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// isSendRetryable reports whether a failed message send is safe to retry: connection
+// refused errors and 5xx or 429 responses, which the agent rejected before starting work.
+// Timeouts and other network errors are not retried, as the agent may already be running
+// and a retry would start it again.
+func isSendRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if status, ok := httpStatus(err); ok {
+		return status >= 500 || status == http.StatusTooManyRequests
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// classify wraps a failed Kagent API call with its failure kind: 429 responses are rate
+// limited, retryable failures leave the agent unreachable and other failures are rejections
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	if status, ok := httpStatus(err); ok && status == http.StatusTooManyRequests {
+		return khookerrors.New(khookerrors.KindRateLimited, err)
+	}
+	if isRetryable(err) {
+		return khookerrors.New(khookerrors.KindAgentUnreachable, err)
+	}
+	return khookerrors.New(khookerrors.KindAgentRejected, err)
+}
+
+// withRetry runs fn, retrying errors for which retryable returns true with exponential
+// backoff according to the client's retry policy. The final error wraps the last error
+// returned by fn.
+func (c *Client) withRetry(ctx context.Context, operation string, retryable func(error) bool, fn func() error) error {
+	policy := c.config.Retry
+	attempts := max(policy.MaxAttempts, 1)
+
+	var err error
+	var attempt int
+	for attempt = 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if !retryable(err) || attempt == attempts || ctx.Err() != nil {
+			break
+		}
+
+		delay := policy.delay(attempt)
+		c.logger.Info("Retrying agent call after transient failure",
+			"operation", operation,
+			"attempt", attempt,
+			"maxAttempts", attempts,
+			"delay", delay,
+			"error", err.Error())
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s aborted after %d attempt(s): %w", operation, attempt, err)
+		case <-timer.C:
+		}
+	}
+
+	if attempt > 1 {
+		return fmt.Errorf("%s failed after %d attempts: %w", operation, attempt, err)
+	}
+	return err
+}
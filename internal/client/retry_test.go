@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	kagentclient "github.com/kagent-dev/kagent/go/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	khookerrors "github.com/kagent-dev/khook/internal/errors"
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "kagent 503", err: &kagentclient.ClientError{StatusCode: http.StatusServiceUnavailable}, expected: true},
+		{name: "kagent 400", err: &kagentclient.ClientError{StatusCode: http.StatusBadRequest}, expected: false},
+		{name: "a2a 502", err: errors.New("a2aClient.doRequest: unexpected http status 502: bad gateway"), expected: true},
+		{name: "a2a 404", err: errors.New("a2aClient.doRequest: unexpected http status 404: not found"), expected: false},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), expected: true},
+		{name: "other", err: errors.New("invalid request"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isRetryable(tt.err))
+		})
+	}
+}
+
+func TestIsSendRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "a2a 503", err: errors.New("a2aClient.doRequest: unexpected http status 503: unavailable"), expected: true},
+		{name: "a2a 429", err: errors.New("a2aClient.doRequest: unexpected http status 429: Too Many Requests"), expected: true},
+		{name: "a2a 400", err: errors.New("a2aClient.doRequest: unexpected http status 400: bad request"), expected: false},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), expected: true},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), expected: false},
+		{name: "deadline exceeded", err: fmt.Errorf("send: %w", context.DeadlineExceeded), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isSendRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.delay(1))
+	assert.Equal(t, 2*time.Second, policy.delay(2))
+	assert.Equal(t, 4*time.Second, policy.delay(3))
+	assert.Equal(t, 5*time.Second, policy.delay(4))
+}
+
+func newRetryTestClient(t *testing.T, status int, calls *int32) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(&Config{
+		BaseURL: server.URL,
+		UserID:  "test-user",
+		Timeout: 5 * time.Second,
+		Retry:   RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+	}, log.Log.WithName("test"))
+}
+
+func TestClient_CallAgent_Retry(t *testing.T) {
+	request := interfaces.AgentRequest{
+		AgentRef:  types.NamespacedName{Name: "test-agent", Namespace: "default"},
+		Prompt:    "Test prompt",
+		EventName: "pod-restart",
+	}
+
+	t.Run("5xx is retried until attempts are exhausted", func(t *testing.T) {
+		var calls int32
+		client := newRetryTestClient(t, http.StatusServiceUnavailable, &calls)
+
+		_, err := client.CallAgent(context.Background(), request)
+		require.Error(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Contains(t, err.Error(), "failed after 3 attempts")
+
+		var clientErr *kagentclient.ClientError
+		require.True(t, errors.As(err, &clientErr))
+		assert.Equal(t, http.StatusServiceUnavailable, clientErr.StatusCode)
+	})
+
+	t.Run("4xx fails immediately", func(t *testing.T) {
+		var calls int32
+		client := newRetryTestClient(t, http.StatusBadRequest, &calls)
+
+		_, err := client.CallAgent(context.Background(), request)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	// Requests continuing a task skip session creation and go straight to the send path
+	taskRequest := request
+	taskRequest.TaskID = "session-1"
+
+	t.Run("429 on send is retried", func(t *testing.T) {
+		var calls int32
+		client := newRetryTestClient(t, http.StatusTooManyRequests, &calls)
+
+		_, err := client.CallAgent(context.Background(), taskRequest)
+		require.Error(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("send timeout is not retried", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			// The agent is already working when the client gives up
+			time.Sleep(200 * time.Millisecond)
+		}))
+		t.Cleanup(server.Close)
+		client := NewClient(&Config{
+			BaseURL: server.URL,
+			UserID:  "test-user",
+			Timeout: 20 * time.Millisecond,
+			Retry:   RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+		}, log.Log.WithName("test"))
+
+		_, err := client.CallAgent(context.Background(), taskRequest)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind khookerrors.Kind
+	}{
+		{"server error", &kagentclient.ClientError{StatusCode: http.StatusBadGateway}, khookerrors.KindAgentUnreachable},
+		{"connection refused", syscall.ECONNREFUSED, khookerrors.KindAgentUnreachable},
+		{"rate limited", errors.New("unexpected http status 429: Too Many Requests"), khookerrors.KindRateLimited},
+		{"bad request", &kagentclient.ClientError{StatusCode: http.StatusBadRequest}, khookerrors.KindAgentRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.kind, khookerrors.KindOf(classify(tt.err)))
+		})
+	}
+	assert.NoError(t, classify(nil))
+}
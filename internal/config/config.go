@@ -4,10 +4,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
+
+	"github.com/kagent-dev/khook/internal/deduplication"
+	"github.com/kagent-dev/khook/internal/notify"
+	"github.com/kagent-dev/khook/internal/severity"
 )
 
 // Config holds the configuration for the hook controller
@@ -42,19 +48,153 @@ type ControllerConfig struct {
 	// EventDeduplicationTimeout is the timeout for event deduplication
 	EventDeduplicationTimeout time.Duration `yaml:"eventDeduplicationTimeout"`
 
+	// EventDeduplicationWindows overrides EventDeduplicationTimeout for specific event types
+	EventDeduplicationWindows map[string]time.Duration `yaml:"eventDeduplicationWindows"`
+
+	// DeduplicationKey lists the event fields (namespace, resourceName, reason) that, with
+	// the event type, identify duplicate events. Empty uses namespace and resourceName.
+	// Adding reason separates events of one resource with different reasons; dropping
+	// resourceName deduplicates an event type across resources, so one agent call covers them.
+	DeduplicationKey []string `yaml:"deduplicationKey"`
+
+	// NotifyCooldown is the minimum time between agent calls for an event that keeps
+	// re-firing. The event stays firing in the hook status during the cooldown.
+	NotifyCooldown time.Duration `yaml:"notifyCooldown"`
+
+	// NotifyCooldowns overrides NotifyCooldown for specific event types
+	NotifyCooldowns map[string]time.Duration `yaml:"notifyCooldowns"`
+
+	// EventBufferSize is how many mapped events each namespace watcher buffers while they
+	// wait for processing. Larger buffers absorb event bursts on busy clusters at the cost of
+	// memory (roughly 1KB per buffered event, per watched namespace); when the buffer is full
+	// the watcher stops reading from the API server until there is space.
+	EventBufferSize int `yaml:"eventBufferSize"`
+
+	// EventStalenessWindow ignores events whose last occurrence is older than this window
+	EventStalenessWindow time.Duration `yaml:"eventStalenessWindow"`
+
 	// EventCleanupInterval is the interval for cleaning up expired events
 	EventCleanupInterval time.Duration `yaml:"eventCleanupInterval"`
 
+	// StatusUpdateInterval is the interval for updating hook statuses
+	StatusUpdateInterval time.Duration `yaml:"statusUpdateInterval"`
+
 	// MaxConcurrentReconciles is the maximum number of concurrent reconciles
 	MaxConcurrentReconciles int `yaml:"maxConcurrentReconciles"`
+
+	// EventProcessingTimeout bounds the processing of each event match, including the
+	// agent call. Timed-out calls are recorded as failures.
+	EventProcessingTimeout time.Duration `yaml:"eventProcessingTimeout"`
+
+	// ShutdownDrainTimeout is how long events being processed at shutdown may take to
+	// finish before their agent calls are cancelled. Zero cancels them immediately. Pods
+	// need a termination grace period longer than this for the drain to complete.
+	ShutdownDrainTimeout time.Duration `yaml:"shutdownDrainTimeout"`
+
+	// MaxConcurrentNamespaceWorkflows limits how many namespaces are watched and processed
+	// at once. Namespaces over the limit wait until another namespace's hooks are removed.
+	// Zero is unlimited.
+	MaxConcurrentNamespaceWorkflows int `yaml:"maxConcurrentNamespaceWorkflows"`
+
+	// MatchConcurrency is how many hooks matching the same event are processed in
+	// parallel. Values below 2 process them one at a time.
+	MatchConcurrency int `yaml:"matchConcurrency"`
+
+	// MaxAgentCallsPerMinute limits how often each hook calls its agent. Events over the
+	// limit are recorded without calling the agent. Zero disables the limit.
+	MaxAgentCallsPerMinute int `yaml:"maxAgentCallsPerMinute"`
+
+	// AgentDeduplicationWindow suppresses agent calls for an event and resource when
+	// another hook called the same agent for them within the window. Zero disables it.
+	AgentDeduplicationWindow time.Duration `yaml:"agentDeduplicationWindow"`
+
+	// MinAgentSeverity is the lowest event severity (low, medium, high, critical) that
+	// triggers an agent call. Events below it are only recorded. Empty calls agents for all events.
+	MinAgentSeverity string `yaml:"minAgentSeverity"`
+
+	// EventSeverities overrides the built-in severity (low, medium, high, critical) of
+	// event types, e.g. pod-pending: low. Unknown event types default to medium.
+	EventSeverities map[string]string `yaml:"eventSeverities"`
+
+	// AgentTaskReuseWindow sends follow-up events for a resource as a continuation of its open
+	// agent task while events keep arriving within this window. Zero always starts a new task.
+	AgentTaskReuseWindow time.Duration `yaml:"agentTaskReuseWindow"`
+
+	// WatchAllNamespaces watches events in every namespace with a single watcher. Hooks then
+	// match events from any namespace rather than only their own.
+	WatchAllNamespaces bool `yaml:"watchAllNamespaces"`
+
+	// ResolveDeletedPods also watches pods and resolves the active events of a pod as soon
+	// as it is deleted, instead of when its deduplication window expires
+	ResolveDeletedPods bool `yaml:"resolveDeletedPods"`
+
+	// IgnoreNormalEvents drops or maps events of type Normal for every resource kind. Unset
+	// drops them except for Deployments and Nodes, whose scaling, cordon and drain events are
+	// Normal. Opted-in container start events always map.
+	IgnoreNormalEvents *bool `yaml:"ignoreNormalEvents"`
+
+	// IgnoreNormalEventsByKind overrides IgnoreNormalEvents for resource kinds, e.g. Node: true
+	IgnoreNormalEventsByKind map[string]bool `yaml:"ignoreNormalEventsByKind"`
+
+	// ExcludeNamespaces lists namespaces whose events are never processed
+	ExcludeNamespaces []string `yaml:"excludeNamespaces"`
+
+	// ExcludeReportingControllers lists reporting controllers (the event's reportingController,
+	// e.g. "example.com/noisy-operator") whose events are never processed
+	ExcludeReportingControllers []string `yaml:"excludeReportingControllers"`
+
+	// EventTypeKinds lists additional resource kinds, per event type, whose events are
+	// mapped with the pod rules (e.g. oom-kill: [MyWorkload]). Pods always map.
+	EventTypeKinds map[string][]string `yaml:"eventTypeKinds"`
+
+	// StatusServerSideApply writes hook status with server-side apply instead of updates
+	StatusServerSideApply bool `yaml:"statusServerSideApply"`
+
+	// FallbackAgentRef ("namespace/name") receives high and critical severity events that
+	// match no hook in a watched namespace. Empty disables fallback routing.
+	FallbackAgentRef string `yaml:"fallbackAgentRef"`
+
+	// DetectHookConflicts emits a warning event on hooks in the same namespace that send
+	// overlapping (eventType, resourceNamePattern) pairs to the same agent. With
+	// --enable-webhooks the overlap is also returned as an admission warning.
+	DetectHookConflicts bool `yaml:"detectHookConflicts"`
+
+	// VerifyAgentRefs looks up the agents referenced by hooks in the Kagent API whenever
+	// hooks change and emits a warning event on hooks whose agents do not exist. With
+	// --enable-webhooks they are also returned as admission warnings. The hook is still
+	// accepted.
+	VerifyAgentRefs bool `yaml:"verifyAgentRefs"`
+
+	// EnableDiagnosticsEndpoints serves debug endpoints, such as the in-memory deduplication
+	// state of a hook at /api/v1/diagnostics/dedup, the event preview at
+	// /api/v1/diagnostics/preview and hook latencies at
+	// /api/v1/stats/hooks/{namespace}/{name}/latency, on the metrics server
+	EnableDiagnosticsEndpoints bool `yaml:"enableDiagnosticsEndpoints"`
+
+	// ResolveWebhookURL receives a JSON POST whenever an active event transitions from
+	// firing to resolved. Empty disables resolve notifications.
+	ResolveWebhookURL string `yaml:"resolveWebhookURL"`
+
+	// Sinks receive every event that starts firing for a hook
+	Sinks []SinkConfig `yaml:"sinks"`
+}
+
+// SinkConfig configures a notification sink
+type SinkConfig struct {
+	// Type is the sink type: webhook, slack or teams
+	Type string `yaml:"type"`
+
+	// URL is the endpoint the sink posts to
+	URL string `yaml:"url"`
 }
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
-	// Level is the logging level
+	// Level is the logging level. Empty logs at debug with the text format and at info
+	// with json.
 	Level string `yaml:"level"`
 
-	// Format is the logging format (json or text)
+	// Format is the logging format (json or text). Empty uses text.
 	Format string `yaml:"format"`
 }
 
@@ -68,12 +208,14 @@ func DefaultConfig() *Config {
 		},
 		Controller: ControllerConfig{
 			EventDeduplicationTimeout: 10 * time.Minute,
+			NotifyCooldown:            10 * time.Minute,
+			EventStalenessWindow:      15 * time.Minute,
+			EventBufferSize:           100,
 			EventCleanupInterval:      5 * time.Minute,
+			StatusUpdateInterval:      1 * time.Minute,
 			MaxConcurrentReconciles:   1,
-		},
-		Logging: LoggingConfig{
-			Level:  "info",
-			Format: "json",
+			EventProcessingTimeout:    30 * time.Second,
+			ShutdownDrainTimeout:      20 * time.Second,
 		},
 	}
 }
@@ -146,6 +288,11 @@ func Load(configFile string) (*Config, error) {
 		}
 	}
 
+	// The Kagent credentials are checked by the client, which may take them from elsewhere
+	if err := config.ValidateController(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	return config, nil
 }
 
@@ -159,13 +306,166 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("kagent.apiKey is required")
 	}
 
+	return c.ValidateController()
+}
+
+// ValidateController validates the controller section of the configuration
+func (c *Config) ValidateController() error {
 	if c.Controller.EventDeduplicationTimeout <= 0 {
 		return fmt.Errorf("controller.eventDeduplicationTimeout must be positive")
 	}
 
+	for eventType, window := range c.Controller.EventDeduplicationWindows {
+		if window <= 0 {
+			return fmt.Errorf("controller.eventDeduplicationWindows[%s] must be positive", eventType)
+		}
+	}
+
+	if _, err := deduplication.ParseKeyComponents(c.Controller.DeduplicationKey); err != nil {
+		return fmt.Errorf("controller.deduplicationKey: %w", err)
+	}
+
+	if c.Controller.NotifyCooldown < 0 {
+		return fmt.Errorf("controller.notifyCooldown cannot be negative")
+	}
+
+	for eventType, cooldown := range c.Controller.NotifyCooldowns {
+		if cooldown <= 0 {
+			return fmt.Errorf("controller.notifyCooldowns[%s] must be positive", eventType)
+		}
+	}
+
+	if c.Controller.EventBufferSize <= 0 {
+		return fmt.Errorf("controller.eventBufferSize must be positive")
+	}
+
+	if c.Controller.EventStalenessWindow < 0 {
+		return fmt.Errorf("controller.eventStalenessWindow cannot be negative")
+	}
+
 	if c.Controller.EventCleanupInterval <= 0 {
 		return fmt.Errorf("controller.eventCleanupInterval must be positive")
 	}
 
+	if c.Controller.StatusUpdateInterval <= 0 {
+		return fmt.Errorf("controller.statusUpdateInterval must be positive")
+	}
+
+	if c.Controller.EventProcessingTimeout < 0 {
+		return fmt.Errorf("controller.eventProcessingTimeout cannot be negative")
+	}
+
+	if c.Controller.ShutdownDrainTimeout < 0 {
+		return fmt.Errorf("controller.shutdownDrainTimeout cannot be negative")
+	}
+
+	if c.Controller.MaxConcurrentNamespaceWorkflows < 0 {
+		return fmt.Errorf("controller.maxConcurrentNamespaceWorkflows cannot be negative")
+	}
+
+	if c.Controller.MatchConcurrency < 0 {
+		return fmt.Errorf("controller.matchConcurrency cannot be negative")
+	}
+
+	if c.Controller.MaxAgentCallsPerMinute < 0 {
+		return fmt.Errorf("controller.maxAgentCallsPerMinute cannot be negative")
+	}
+
+	if c.Controller.AgentDeduplicationWindow < 0 {
+		return fmt.Errorf("controller.agentDeduplicationWindow cannot be negative")
+	}
+
+	if c.Controller.AgentTaskReuseWindow < 0 {
+		return fmt.Errorf("controller.agentTaskReuseWindow cannot be negative")
+	}
+
+	for eventType, kinds := range c.Controller.EventTypeKinds {
+		for _, kind := range kinds {
+			if kind == "" {
+				return fmt.Errorf("controller.eventTypeKinds[%s] cannot contain an empty kind", eventType)
+			}
+		}
+	}
+
+	for kind := range c.Controller.IgnoreNormalEventsByKind {
+		if kind == "" {
+			return fmt.Errorf("controller.ignoreNormalEventsByKind cannot contain an empty kind")
+		}
+	}
+
+	if c.Controller.FallbackAgentRef != "" {
+		namespace, name, ok := strings.Cut(c.Controller.FallbackAgentRef, "/")
+		if !ok || namespace == "" || name == "" {
+			return fmt.Errorf("controller.fallbackAgentRef must be in namespace/name form")
+		}
+	}
+
+	if c.Controller.ResolveWebhookURL != "" &&
+		!strings.HasPrefix(c.Controller.ResolveWebhookURL, "http://") &&
+		!strings.HasPrefix(c.Controller.ResolveWebhookURL, "https://") {
+		return fmt.Errorf("controller.resolveWebhookURL must start with http:// or https://")
+	}
+
+	for i, sink := range c.Controller.Sinks {
+		if _, err := notify.NewSink(sink.Type, sink.URL); err != nil {
+			return fmt.Errorf("controller.sinks[%d]: %w", i, err)
+		}
+		if !strings.HasPrefix(sink.URL, "http://") && !strings.HasPrefix(sink.URL, "https://") {
+			return fmt.Errorf("controller.sinks[%d].url must start with http:// or https://", i)
+		}
+	}
+
+	if _, err := severity.NewMapping(c.Controller.EventSeverities); err != nil {
+		return fmt.Errorf("controller.eventSeverities: %w", err)
+	}
+
+	if c.Controller.MinAgentSeverity != "" {
+		if _, err := severity.Parse(c.Controller.MinAgentSeverity); err != nil {
+			return fmt.Errorf("controller.minAgentSeverity: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// Reload returns a copy of c with the options that can change without a restart (logging
+// level, sinks, deduplication windows and notification cooldowns) taken from updated.
+// It also returns the changed options that were applied and those that were ignored
+// because they only take effect on restart.
+func (c *Config) Reload(updated *Config) (reloaded *Config, applied, ignored []string) {
+	reloaded = new(Config)
+	*reloaded = *c
+	reloaded.Logging.Level = updated.Logging.Level
+	reloaded.Controller.Sinks = updated.Controller.Sinks
+	reloaded.Controller.EventDeduplicationTimeout = updated.Controller.EventDeduplicationTimeout
+	reloaded.Controller.EventDeduplicationWindows = updated.Controller.EventDeduplicationWindows
+	reloaded.Controller.NotifyCooldown = updated.Controller.NotifyCooldown
+	reloaded.Controller.NotifyCooldowns = updated.Controller.NotifyCooldowns
+
+	// Options still differing from updated after the reload were not applied
+	ignored = changedOptions(reflect.ValueOf(*reloaded), reflect.ValueOf(*updated), "")
+	for _, option := range changedOptions(reflect.ValueOf(*c), reflect.ValueOf(*updated), "") {
+		if !slices.Contains(ignored, option) {
+			applied = append(applied, option)
+		}
+	}
+	return reloaded, applied, ignored
+}
+
+// changedOptions returns the yaml paths (e.g. "logging.level") of the options that differ
+// between the config structs old and updated
+func changedOptions(old, updated reflect.Value, prefix string) []string {
+	var changed []string
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		name := prefix + strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if field.Type.Kind() == reflect.Struct {
+			changed = append(changed, changedOptions(old.Field(i), updated.Field(i), name+".")...)
+			continue
+		}
+		if !reflect.DeepEqual(old.Field(i).Interface(), updated.Field(i).Interface()) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
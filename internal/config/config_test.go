@@ -0,0 +1,280 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	tests := []struct {
+		name        string
+		update      func(*Config)
+		wantApplied []string
+		wantIgnored []string
+		check       func(t *testing.T, reloaded *Config)
+	}{
+		{
+			name:   "nothing changed",
+			update: func(c *Config) {},
+		},
+		{
+			name:        "log level is reloadable",
+			update:      func(c *Config) { c.Logging.Level = "debug" },
+			wantApplied: []string{"logging.level"},
+			check: func(t *testing.T, reloaded *Config) {
+				assert.Equal(t, "debug", reloaded.Logging.Level)
+			},
+		},
+		{
+			name:        "log format needs a restart",
+			update:      func(c *Config) { c.Logging.Format = "console" },
+			wantIgnored: []string{"logging.format"},
+			check: func(t *testing.T, reloaded *Config) {
+				assert.Empty(t, reloaded.Logging.Format)
+			},
+		},
+		{
+			name: "deduplication windows and cooldowns are reloadable",
+			update: func(c *Config) {
+				c.Controller.EventDeduplicationTimeout = 5 * time.Minute
+				c.Controller.EventDeduplicationWindows = map[string]time.Duration{"oom-kill": 2 * time.Minute}
+				c.Controller.NotifyCooldown = time.Minute
+				c.Controller.NotifyCooldowns = map[string]time.Duration{"pod-restart": 30 * time.Minute}
+			},
+			wantApplied: []string{
+				"controller.eventDeduplicationTimeout",
+				"controller.eventDeduplicationWindows",
+				"controller.notifyCooldown",
+				"controller.notifyCooldowns",
+			},
+			check: func(t *testing.T, reloaded *Config) {
+				assert.Equal(t, 5*time.Minute, reloaded.Controller.EventDeduplicationTimeout)
+				assert.Equal(t, 2*time.Minute, reloaded.Controller.EventDeduplicationWindows["oom-kill"])
+				assert.Equal(t, time.Minute, reloaded.Controller.NotifyCooldown)
+				assert.Equal(t, 30*time.Minute, reloaded.Controller.NotifyCooldowns["pod-restart"])
+			},
+		},
+		{
+			name: "sinks are reloadable",
+			update: func(c *Config) {
+				c.Controller.Sinks = []SinkConfig{{Type: "webhook", URL: "https://example.com/hook"}}
+			},
+			wantApplied: []string{"controller.sinks"},
+			check: func(t *testing.T, reloaded *Config) {
+				assert.Equal(t, []SinkConfig{{Type: "webhook", URL: "https://example.com/hook"}}, reloaded.Controller.Sinks)
+			},
+		},
+		{
+			name: "options in every section need a restart",
+			update: func(c *Config) {
+				c.Kagent.BaseURL = "https://other.kagent.dev"
+				c.Controller.WatchAllNamespaces = true
+				c.Controller.EventBufferSize = 500
+			},
+			wantIgnored: []string{"kagent.baseUrl", "controller.eventBufferSize", "controller.watchAllNamespaces"},
+			check: func(t *testing.T, reloaded *Config) {
+				assert.Equal(t, "https://api.kagent.dev", reloaded.Kagent.BaseURL)
+				assert.False(t, reloaded.Controller.WatchAllNamespaces)
+				assert.Equal(t, 100, reloaded.Controller.EventBufferSize)
+			},
+		},
+		{
+			name: "reloadable and restart-only changes together",
+			update: func(c *Config) {
+				c.Logging.Level = "warn"
+				c.Controller.ShutdownDrainTimeout = time.Minute
+			},
+			wantApplied: []string{"logging.level"},
+			wantIgnored: []string{"controller.shutdownDrainTimeout"},
+			check: func(t *testing.T, reloaded *Config) {
+				assert.Equal(t, "warn", reloaded.Logging.Level)
+				assert.Equal(t, 20*time.Second, reloaded.Controller.ShutdownDrainTimeout)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := DefaultConfig()
+			updated := DefaultConfig()
+			tt.update(updated)
+
+			reloaded, applied, ignored := current.Reload(updated)
+			assert.ElementsMatch(t, tt.wantApplied, applied)
+			assert.ElementsMatch(t, tt.wantIgnored, ignored)
+			if tt.check != nil {
+				tt.check(t, reloaded)
+			}
+
+			// The running configuration is left as it was
+			assert.Equal(t, DefaultConfig(), current)
+		})
+	}
+}
+
+func TestValidateController(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*Config)
+		wantErr string
+	}{
+		{
+			name:   "defaults are valid",
+			update: func(c *Config) {},
+		},
+		{
+			name:    "zero deduplication timeout",
+			update:  func(c *Config) { c.Controller.EventDeduplicationTimeout = 0 },
+			wantErr: "controller.eventDeduplicationTimeout must be positive",
+		},
+		{
+			name: "zero deduplication window for an event type",
+			update: func(c *Config) {
+				c.Controller.EventDeduplicationWindows = map[string]time.Duration{"oom-kill": 0}
+			},
+			wantErr: "controller.eventDeduplicationWindows[oom-kill] must be positive",
+		},
+		{
+			name:    "unknown deduplication key component",
+			update:  func(c *Config) { c.Controller.DeduplicationKey = []string{"uid"} },
+			wantErr: "controller.deduplicationKey",
+		},
+		{
+			name:    "negative notification cooldown",
+			update:  func(c *Config) { c.Controller.NotifyCooldown = -time.Minute },
+			wantErr: "controller.notifyCooldown cannot be negative",
+		},
+		{
+			name:    "zero event buffer size",
+			update:  func(c *Config) { c.Controller.EventBufferSize = 0 },
+			wantErr: "controller.eventBufferSize must be positive",
+		},
+		{
+			name:    "negative shutdown drain timeout",
+			update:  func(c *Config) { c.Controller.ShutdownDrainTimeout = -time.Second },
+			wantErr: "controller.shutdownDrainTimeout cannot be negative",
+		},
+		{
+			name:    "fallback agent without namespace",
+			update:  func(c *Config) { c.Controller.FallbackAgentRef = "agent" },
+			wantErr: "controller.fallbackAgentRef must be in namespace/name form",
+		},
+		{
+			name: "unknown sink type",
+			update: func(c *Config) {
+				c.Controller.Sinks = []SinkConfig{{Type: "email", URL: "https://example.com"}}
+			},
+			wantErr: "controller.sinks[0]",
+		},
+		{
+			name: "sink without http URL",
+			update: func(c *Config) {
+				c.Controller.Sinks = []SinkConfig{{Type: "webhook", URL: "example.com/hook"}}
+			},
+			wantErr: "controller.sinks[0].url must start with http:// or https://",
+		},
+		{
+			name:    "unknown minimum agent severity",
+			update:  func(c *Config) { c.Controller.MinAgentSeverity = "urgent" },
+			wantErr: "controller.minAgentSeverity",
+		},
+		{
+			name: "missing API key is left to the client",
+			update: func(c *Config) {
+				c.Kagent.APIKey = ""
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.update(config)
+
+			err := config.ValidateController()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+		check   func(t *testing.T, config *Config)
+	}{
+		{
+			name: "valid file",
+			content: `
+logging:
+  level: debug
+controller:
+  notifyCooldown: 15m
+  eventDeduplicationWindows:
+    oom-kill: 2m
+`,
+			check: func(t *testing.T, config *Config) {
+				assert.Equal(t, "debug", config.Logging.Level)
+				assert.Equal(t, 15*time.Minute, config.Controller.NotifyCooldown)
+				assert.Equal(t, 2*time.Minute, config.Controller.EventDeduplicationWindows["oom-kill"])
+				assert.Equal(t, 100, config.Controller.EventBufferSize, "unset options keep their defaults")
+			},
+		},
+		{
+			name: "invalid value",
+			content: `
+controller:
+  eventBufferSize: -1
+`,
+			wantErr: "invalid configuration: controller.eventBufferSize must be positive",
+		},
+		{
+			name:    "malformed file",
+			content: "controller: [",
+			wantErr: "failed to parse config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			config, err := Load(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, config)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, config)
+		})
+	}
+}
+
+func TestLoad_InvalidReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("controller:\n  notifyCooldown: 15m\n"), 0o600))
+	current, err := Load(path)
+	require.NoError(t, err)
+
+	// An edit with an invalid reloadable value is rejected rather than reloaded
+	require.NoError(t, os.WriteFile(path, []byte("controller:\n  notifyCooldown: -5m\n"), 0o600))
+	updated, err := Load(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "controller.notifyCooldown cannot be negative")
+	assert.Nil(t, updated)
+	assert.Equal(t, 15*time.Minute, current.Controller.NotifyCooldown)
+}
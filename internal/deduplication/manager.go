@@ -2,9 +2,12 @@ package deduplication
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/kagent-dev/khook/api/v1alpha2"
 	"github.com/kagent-dev/khook/internal/interfaces"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -13,13 +16,13 @@ import (
 const (
 	// EventTimeoutDuration is the duration after which events are considered resolved
 	EventTimeoutDuration = 10 * time.Minute
-	// NotificationSuppressionDuration is the window to suppress re-sending after success
+	// NotificationSuppressionDuration is the default cooldown that suppresses re-sending after success
 	NotificationSuppressionDuration = 10 * time.Minute
 
 	// StatusFiring indicates an event is currently active
 	StatusFiring = "firing"
 
-	// StatusResolved indicates an event has been resolved (timed out)
+	// StatusResolved indicates an event has been resolved (timed out or its resource deleted)
 	StatusResolved = "resolved"
 )
 
@@ -29,18 +32,199 @@ type Manager struct {
 	// hookName -> eventKey -> ActiveEvent
 	hookEvents map[string]map[string]*interfaces.ActiveEvent
 	mutex      sync.RWMutex
+
+	// eventTypeWindows overrides the deduplication window for specific event types
+	eventTypeWindows map[string]time.Duration
+	// defaultWindow is the deduplication window for event types without an override
+	defaultWindow time.Duration
+
+	// notifyCooldowns overrides the notification cooldown for specific event types
+	notifyCooldowns map[string]time.Duration
+	// defaultCooldown is the notification cooldown for event types without an override
+	defaultCooldown time.Duration
+
+	// keyComponents selects the event fields that identify duplicate events
+	keyComponents KeyComponents
+
+	// pendingEvents holds events awaiting confirmation that they stay active
+	// hookName -> eventKey -> pendingEvent
+	pendingEvents map[string]map[string]*pendingEvent
+}
+
+// pendingEvent is an event held until it is observed again after its minimum active duration
+type pendingEvent struct {
+	eventType    string
+	namespace    string
+	resourceName string
+	firstSeen    time.Time
+	confirmAt    time.Time
+}
+
+// KeyComponents selects which event fields, besides the event type, make up the
+// deduplication key. Events with the same key are duplicates of each other.
+type KeyComponents struct {
+	// Namespace keeps events from different namespaces apart
+	Namespace bool
+	// ResourceName keeps events about different resources apart. Without it, one agent
+	// call covers every resource with the event within the window.
+	ResourceName bool
+	// Reason keeps events with different Kubernetes reasons apart, e.g. an OOMKilling and a
+	// Killing event of the same pod
+	Reason bool
+}
+
+// DefaultKeyComponents identifies events by type, namespace and resource name
+var DefaultKeyComponents = KeyComponents{Namespace: true, ResourceName: true}
+
+// Option configures optional Manager behavior
+type Option func(*Manager)
+
+// WithEventTypeWindows sets per-event-type deduplication windows. Event types that are
+// not present in windows use defaultWindow; a non-positive defaultWindow keeps EventTimeoutDuration.
+func WithEventTypeWindows(windows map[string]time.Duration, defaultWindow time.Duration) Option {
+	return func(m *Manager) {
+		m.eventTypeWindows = make(map[string]time.Duration, len(windows))
+		for eventType, window := range windows {
+			if window > 0 {
+				m.eventTypeWindows[eventType] = window
+			}
+		}
+		if defaultWindow > 0 {
+			m.defaultWindow = defaultWindow
+		}
+	}
+}
+
+// WithNotifyCooldowns sets per-event-type notification cooldowns. After an agent is notified,
+// further occurrences of the event are suppressed for the cooldown, and the event stays
+// firing even once its deduplication window has passed. Event types that are not present in
+// cooldowns use defaultCooldown; a non-positive defaultCooldown keeps NotificationSuppressionDuration.
+func WithNotifyCooldowns(cooldowns map[string]time.Duration, defaultCooldown time.Duration) Option {
+	return func(m *Manager) {
+		m.notifyCooldowns = make(map[string]time.Duration, len(cooldowns))
+		for eventType, cooldown := range cooldowns {
+			if cooldown > 0 {
+				m.notifyCooldowns[eventType] = cooldown
+			}
+		}
+		if defaultCooldown > 0 {
+			m.defaultCooldown = defaultCooldown
+		}
+	}
+}
+
+// ParseKeyComponents converts component names (namespace, resourceName, reason) into
+// KeyComponents. No names selects DefaultKeyComponents.
+func ParseKeyComponents(names []string) (KeyComponents, error) {
+	if len(names) == 0 {
+		return DefaultKeyComponents, nil
+	}
+	var components KeyComponents
+	for _, name := range names {
+		switch name {
+		case "namespace":
+			components.Namespace = true
+		case "resourceName":
+			components.ResourceName = true
+		case "reason":
+			components.Reason = true
+		default:
+			return KeyComponents{}, fmt.Errorf("invalid key component '%s', must be one of: namespace, resourceName, reason", name)
+		}
+	}
+	return components, nil
+}
+
+// WithKeyComponents sets the event fields that make up the deduplication key instead of
+// DefaultKeyComponents
+func WithKeyComponents(components KeyComponents) Option {
+	return func(m *Manager) {
+		m.keyComponents = components
+	}
 }
 
 // NewManager creates a new DeduplicationManager instance
-func NewManager() *Manager {
-	return &Manager{
-		hookEvents: make(map[string]map[string]*interfaces.ActiveEvent),
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		hookEvents:       make(map[string]map[string]*interfaces.ActiveEvent),
+		eventTypeWindows: map[string]time.Duration{},
+		defaultWindow:    EventTimeoutDuration,
+		notifyCooldowns:  map[string]time.Duration{},
+		defaultCooldown:  NotificationSuppressionDuration,
+		keyComponents:    DefaultKeyComponents,
+		pendingEvents:    make(map[string]map[string]*pendingEvent),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Reconfigure applies opts to a running manager, e.g. to change deduplication windows or
+// notification cooldowns after the configuration is reloaded. Tracked events keep their
+// state and are evaluated with the new settings from then on.
+func (m *Manager) Reconfigure(opts ...Option) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, opt := range opts {
+		opt(m)
 	}
 }
 
-// eventKey generates a unique key for an event based on type and resource
+// windowFor returns the deduplication window for the given event type
+func (m *Manager) windowFor(eventType string) time.Duration {
+	if window, ok := m.eventTypeWindows[eventType]; ok {
+		return window
+	}
+	return m.defaultWindow
+}
+
+// cooldownFor returns the notification cooldown for the given event type
+func (m *Manager) cooldownFor(eventType string) time.Duration {
+	if cooldown, ok := m.notifyCooldowns[eventType]; ok {
+		return cooldown
+	}
+	return m.defaultCooldown
+}
+
+// inCooldown reports whether the agent was notified about the event within its cooldown
+func (m *Manager) inCooldown(activeEvent *interfaces.ActiveEvent, now time.Time) bool {
+	return activeEvent.LastNotifiedAt != nil && now.Sub(*activeEvent.LastNotifiedAt) < m.cooldownFor(activeEvent.EventType)
+}
+
+// isExpired reports whether the event's deduplication window has passed and it is not
+// held active by a notification cooldown
+func (m *Manager) isExpired(activeEvent *interfaces.ActiveEvent, now time.Time) bool {
+	if activeEvent.Status == StatusResolved {
+		return true
+	}
+	return now.Sub(activeEvent.FirstSeen) > m.windowFor(activeEvent.EventType) && !m.inCooldown(activeEvent, now)
+}
+
+// eventKey generates the key identifying duplicates of an event from its type and the
+// configured key components
 func (m *Manager) eventKey(event interfaces.Event) string {
-	return fmt.Sprintf("%s:%s:%s", event.Type, event.Namespace, event.ResourceName)
+	key := event.Type
+	if m.keyComponents.Namespace {
+		key += ":" + event.Namespace
+	}
+	if m.keyComponents.ResourceName {
+		key += ":" + event.ResourceName
+	}
+	if m.keyComponents.Reason {
+		key += ":" + event.Reason
+	}
+	return key
+}
+
+// seriesCount returns the occurrence count reported by Kubernetes for the event, or 0 if unknown
+func (m *Manager) seriesCount(event interfaces.Event) int32 {
+	count, err := strconv.ParseInt(event.Metadata["count"], 10, 32)
+	if err != nil || count < 0 {
+		return 0
+	}
+	return int32(count)
 }
 
 // ShouldProcessEvent determines if an event should be processed based on deduplication logic
@@ -64,15 +248,22 @@ func (m *Manager) ShouldProcessEvent(hookRef types.NamespacedName, event interfa
 		return true
 	}
 
-	// Suppress if we recently notified and within suppression window
-	if activeEvent.LastNotifiedAt != nil && time.Since(*activeEvent.LastNotifiedAt) < NotificationSuppressionDuration {
-		logger.V(1).Info("Within notification suppression window; will ignore",
-			"lastNotifiedAt", *activeEvent.LastNotifiedAt)
+	// A resolved event that fires again, e.g. for a recreated pod, is a new occurrence
+	if activeEvent.Status == StatusResolved {
+		logger.V(1).Info("Event was resolved; will process as new")
+		return true
+	}
+
+	// Suppress if we recently notified and are within the event type's cooldown
+	if m.inCooldown(activeEvent, time.Now()) {
+		logger.V(1).Info("Within notification cooldown; will ignore",
+			"lastNotifiedAt", *activeEvent.LastNotifiedAt,
+			"cooldown", m.cooldownFor(activeEvent.EventType))
 		return false
 	}
 
-	// Check if event has expired (more than 10 minutes old)
-	if time.Since(activeEvent.FirstSeen) > EventTimeoutDuration {
+	// Check if event has expired (older than the event type's deduplication window)
+	if time.Since(activeEvent.FirstSeen) > m.windowFor(activeEvent.EventType) {
 		// Event has expired, should process as new event
 		logger.V(1).Info("Event expired; will process as new", "firstSeen", activeEvent.FirstSeen)
 		return true
@@ -97,20 +288,29 @@ func (m *Manager) RecordEvent(hookRef types.NamespacedName, event interfaces.Eve
 	key := m.eventKey(event)
 	now := time.Now()
 
-	// Check if event already exists
-	if existingEvent, exists := m.hookEvents[hookRef.String()][key]; exists {
+	// Check if event already exists; a resolved event starts over as a new record
+	if existingEvent, exists := m.hookEvents[hookRef.String()][key]; exists && existingEvent.Status != StatusResolved {
 		// Update existing event
 		existingEvent.LastSeen = now
 		existingEvent.Status = StatusFiring
-		logger.V(1).Info("Updated existing active event", "lastSeen", existingEvent.LastSeen)
+		existingEvent.OccurrenceCount++
+		if seriesCount := m.seriesCount(event); seriesCount > existingEvent.OccurrenceCount {
+			existingEvent.OccurrenceCount = seriesCount
+		}
+		logger.V(1).Info("Updated existing active event",
+			"lastSeen", existingEvent.LastSeen,
+			"occurrenceCount", existingEvent.OccurrenceCount)
 	} else {
 		// Create new event record
 		m.hookEvents[hookRef.String()][key] = &interfaces.ActiveEvent{
-			EventType:    event.Type,
-			ResourceName: event.ResourceName,
-			FirstSeen:    now,
-			LastSeen:     now,
-			Status:       StatusFiring,
+			EventType:       event.Type,
+			ResourceName:    event.ResourceName,
+			Namespace:       event.Namespace,
+			Reason:          event.Reason,
+			FirstSeen:       now,
+			LastSeen:        now,
+			Status:          StatusFiring,
+			OccurrenceCount: max(1, m.seriesCount(event)),
 		}
 		logger.Info("Recorded new active event", "firstSeen", now)
 	}
@@ -118,8 +318,68 @@ func (m *Manager) RecordEvent(hookRef types.NamespacedName, event interfaces.Eve
 	return nil
 }
 
-// MarkNotified marks that we successfully notified the agent for this event now
-func (m *Manager) MarkNotified(hookRef types.NamespacedName, event interfaces.Event) {
+// RestoreFromStatus rehydrates active events from each hook's persisted status so that events
+// still firing when the controller restarted are not re-sent to agents. Events are keyed by
+// the namespace and reason saved in the status, falling back to the hook's namespace for
+// events saved without one. Resolved events or events already tracked are skipped. It
+// returns the number of events restored.
+func (m *Manager) RestoreFromStatus(hooks []*v1alpha2.Hook) int {
+	logger := log.Log.WithName("dedup")
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	restored := 0
+	for _, hook := range hooks {
+		hookRef := types.NamespacedName{Namespace: hook.Namespace, Name: hook.Name}
+		for _, status := range hook.Status.ActiveEvents {
+			if status.Status != StatusFiring {
+				continue
+			}
+
+			if m.hookEvents[hookRef.String()] == nil {
+				m.hookEvents[hookRef.String()] = make(map[string]*interfaces.ActiveEvent)
+			}
+			namespace := status.Namespace
+			if namespace == "" {
+				namespace = hook.Namespace
+			}
+			key := m.eventKey(interfaces.Event{
+				Type:         status.EventType,
+				Namespace:    namespace,
+				ResourceName: status.ResourceName,
+				Reason:       status.Reason,
+			})
+			if _, exists := m.hookEvents[hookRef.String()][key]; exists {
+				continue
+			}
+
+			m.hookEvents[hookRef.String()][key] = &interfaces.ActiveEvent{
+				EventType:          status.EventType,
+				ResourceName:       status.ResourceName,
+				Namespace:          namespace,
+				Reason:             status.Reason,
+				FirstSeen:          status.FirstSeen.Time,
+				LastSeen:           status.LastSeen.Time,
+				Status:             StatusFiring,
+				OccurrenceCount:    max(1, status.OccurrenceCount),
+				SessionID:          status.SessionID,
+				RemediationSummary: status.RemediationSummary,
+			}
+			restored++
+			logger.V(1).Info("Restored active event from hook status",
+				"hook", hookRef.String(),
+				"eventType", status.EventType,
+				"resource", status.ResourceName,
+				"firstSeen", status.FirstSeen.Time)
+		}
+	}
+
+	return restored
+}
+
+// MarkNotified marks that we successfully notified the agent for this event now, keeping
+// the session and summary of the agent's response, if any
+func (m *Manager) MarkNotified(hookRef types.NamespacedName, event interfaces.Event, response *interfaces.AgentResponse) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 	if m.hookEvents[hookRef.String()] == nil {
@@ -127,41 +387,127 @@ func (m *Manager) MarkNotified(hookRef types.NamespacedName, event interfaces.Ev
 	}
 	key := m.eventKey(event)
 	now := time.Now()
-	if ae, ok := m.hookEvents[hookRef.String()][key]; ok {
+	ae, ok := m.hookEvents[hookRef.String()][key]
+	if ok {
 		ae.LastNotifiedAt = &now
 		if ae.NotifiedAt == nil {
 			ae.NotifiedAt = &now
 		}
 	} else {
-		m.hookEvents[hookRef.String()][key] = &interfaces.ActiveEvent{
-			EventType:      event.Type,
-			ResourceName:   event.ResourceName,
-			FirstSeen:      now,
-			LastSeen:       now,
-			Status:         StatusFiring,
-			NotifiedAt:     &now,
-			LastNotifiedAt: &now,
+		ae = &interfaces.ActiveEvent{
+			EventType:       event.Type,
+			ResourceName:    event.ResourceName,
+			Namespace:       event.Namespace,
+			Reason:          event.Reason,
+			FirstSeen:       now,
+			LastSeen:        now,
+			Status:          StatusFiring,
+			OccurrenceCount: max(1, m.seriesCount(event)),
+			NotifiedAt:      &now,
+			LastNotifiedAt:  &now,
+		}
+		m.hookEvents[hookRef.String()][key] = ae
+	}
+	if response != nil {
+		ae.SessionID = response.SessionID
+		ae.RemediationSummary = response.Summary
+	}
+}
+
+// ResolveEvent marks the hook's firing events of eventType for resourceName in namespace
+// resolved, e.g. because the resource was deleted. Resolved events are removed by the next
+// cleanup. It reports whether any event was resolved.
+func (m *Manager) ResolveEvent(hookRef types.NamespacedName, eventType, namespace, resourceName string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Events that resolve while pending never fire
+	for key, pending := range m.pendingEvents[hookRef.String()] {
+		if pending.eventType == eventType && pending.namespace == namespace && pending.resourceName == resourceName {
+			delete(m.pendingEvents[hookRef.String()], key)
+		}
+	}
+
+	resolved := false
+	for _, activeEvent := range m.hookEvents[hookRef.String()] {
+		if activeEvent.EventType == eventType && activeEvent.Namespace == namespace &&
+			activeEvent.ResourceName == resourceName && activeEvent.Status == StatusFiring {
+			activeEvent.Status = StatusResolved
+			resolved = true
 		}
 	}
+	if resolved {
+		log.Log.WithName("dedup").Info("Resolved active event",
+			"hook", hookRef.String(),
+			"eventType", eventType,
+			"namespace", namespace,
+			"resource", resourceName)
+	}
+	return resolved
 }
 
-// CleanupExpiredEvents removes events that have exceeded the timeout duration
+// ConfirmActive holds an event until it stays active for minActive. The first occurrence
+// is recorded as pending and later occurrences report true once at least minActive has
+// passed since it, dropping the pending record. Pending events not observed again within
+// the event type's deduplication window after that are dropped by cleanup.
+func (m *Manager) ConfirmActive(hookRef types.NamespacedName, event interfaces.Event, minActive time.Duration) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	hookName := hookRef.String()
+	if m.pendingEvents[hookName] == nil {
+		m.pendingEvents[hookName] = make(map[string]*pendingEvent)
+	}
+
+	key := m.eventKey(event)
+	now := time.Now()
+	pending, exists := m.pendingEvents[hookName][key]
+	if !exists || now.After(pending.confirmAt.Add(m.windowFor(pending.eventType))) {
+		m.pendingEvents[hookName][key] = &pendingEvent{
+			eventType:    event.Type,
+			namespace:    event.Namespace,
+			resourceName: event.ResourceName,
+			firstSeen:    now,
+			confirmAt:    now.Add(minActive),
+		}
+		return false
+	}
+	if now.Before(pending.confirmAt) {
+		return false
+	}
+
+	delete(m.pendingEvents[hookName], key)
+	if len(m.pendingEvents[hookName]) == 0 {
+		delete(m.pendingEvents, hookName)
+	}
+	log.Log.WithName("dedup").V(1).Info("Confirmed pending event",
+		"hook", hookName,
+		"eventType", event.Type,
+		"resource", event.ResourceName,
+		"firstSeen", pending.firstSeen)
+	return true
+}
+
+// CleanupExpiredEvents removes events that have exceeded their deduplication window and
+// notification cooldown
 func (m *Manager) CleanupExpiredEvents(hookRef types.NamespacedName) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	now := time.Now()
+	m.cleanupPendingEvents(hookRef.String(), now)
+
 	hookEventMap, exists := m.hookEvents[hookRef.String()]
 	if !exists {
 		// No events for this hook
 		return nil
 	}
 
-	now := time.Now()
 	expiredKeys := make([]string, 0)
 
 	// Find expired events
 	for key, activeEvent := range hookEventMap {
-		if now.Sub(activeEvent.FirstSeen) > EventTimeoutDuration {
+		if m.isExpired(activeEvent, now) {
 			// Mark as resolved before removal
 			activeEvent.Status = StatusResolved
 			expiredKeys = append(expiredKeys, key)
@@ -181,11 +527,41 @@ func (m *Manager) CleanupExpiredEvents(hookRef types.NamespacedName) error {
 	return nil
 }
 
+// cleanupPendingEvents drops the hook's pending events that were not confirmed within
+// their deduplication window after they became confirmable. The caller holds the lock.
+func (m *Manager) cleanupPendingEvents(hookName string, now time.Time) {
+	for key, pending := range m.pendingEvents[hookName] {
+		if now.After(pending.confirmAt.Add(m.windowFor(pending.eventType))) {
+			delete(m.pendingEvents[hookName], key)
+		}
+	}
+	if len(m.pendingEvents[hookName]) == 0 {
+		delete(m.pendingEvents, hookName)
+	}
+}
+
 // GetActiveEvents returns all active events for a specific hook
 func (m *Manager) GetActiveEvents(hookRef types.NamespacedName) []interfaces.ActiveEvent {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
+	return m.activeEvents(hookRef)
+}
+
+// GetActiveEvent returns the hook's active event the event is a duplicate of, if any
+func (m *Manager) GetActiveEvent(hookRef types.NamespacedName, event interfaces.Event) (interfaces.ActiveEvent, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	activeEvent, exists := m.hookEvents[hookRef.String()][m.eventKey(event)]
+	if !exists {
+		return interfaces.ActiveEvent{}, false
+	}
+	return *activeEvent, true
+}
+
+// activeEvents returns copies of the hook's active events; the caller must hold the mutex
+func (m *Manager) activeEvents(hookRef types.NamespacedName) []interfaces.ActiveEvent {
 	hookEventMap, exists := m.hookEvents[hookRef.String()]
 	if !exists {
 		return []interfaces.ActiveEvent{}
@@ -206,12 +582,17 @@ func (m *Manager) GetActiveEvents(hookRef types.NamespacedName) []interfaces.Act
 // GetActiveEventsWithStatus returns all active events with their current status
 // This method handles status calculation without race conditions
 func (m *Manager) GetActiveEventsWithStatus(hookRef types.NamespacedName) []interfaces.ActiveEvent {
-	activeEvents := m.GetActiveEvents(hookRef)
+	// Hold the lock while computing statuses, as Reconfigure replaces the windows and
+	// cooldowns they depend on
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	activeEvents := m.activeEvents(hookRef)
 
 	now := time.Now()
 	for i := range activeEvents {
 		// Check if event should be marked as resolved
-		if now.Sub(activeEvents[i].FirstSeen) > EventTimeoutDuration {
+		if m.isExpired(&activeEvents[i], now) {
 			activeEvents[i].Status = StatusResolved
 		}
 	}
@@ -244,3 +625,101 @@ func (m *Manager) GetEventCount() int {
 
 	return count
 }
+
+// TrackedEvent is a tracked event in a HookSnapshot
+type TrackedEvent struct {
+	interfaces.ActiveEvent
+
+	// InCooldown reports whether re-notification of the event is currently suppressed
+	InCooldown bool `json:"inCooldown"`
+}
+
+// PendingEvent is an event in a HookSnapshot awaiting confirmation that it stays active
+type PendingEvent struct {
+	EventType    string    `json:"eventType"`
+	ResourceName string    `json:"resourceName"`
+	FirstSeen    time.Time `json:"firstSeen"`
+	ConfirmAt    time.Time `json:"confirmAt"`
+}
+
+// HookSnapshot is a point-in-time copy of the deduplication state of one hook
+type HookSnapshot struct {
+	// Events are the tracked events with their current status
+	Events []TrackedEvent `json:"events"`
+
+	// Pending are the events held until they stay active for their minimum active duration
+	Pending []PendingEvent `json:"pending"`
+}
+
+// Snapshot returns a copy of the events tracked and pending for a hook, sorted by event
+// type and resource name. It is meant for diagnostics and does not change any state.
+func (m *Manager) Snapshot(hookRef types.NamespacedName) HookSnapshot {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now()
+	snapshot := HookSnapshot{Events: []TrackedEvent{}, Pending: []PendingEvent{}}
+	for _, activeEvent := range m.hookEvents[hookRef.String()] {
+		tracked := TrackedEvent{ActiveEvent: *activeEvent, InCooldown: m.inCooldown(activeEvent, now)}
+		if m.isExpired(activeEvent, now) {
+			tracked.Status = StatusResolved
+		}
+		snapshot.Events = append(snapshot.Events, tracked)
+	}
+	for _, pending := range m.pendingEvents[hookRef.String()] {
+		snapshot.Pending = append(snapshot.Pending, PendingEvent{
+			EventType:    pending.eventType,
+			ResourceName: pending.resourceName,
+			FirstSeen:    pending.firstSeen,
+			ConfirmAt:    pending.confirmAt,
+		})
+	}
+
+	sort.Slice(snapshot.Events, func(i, j int) bool {
+		a, b := snapshot.Events[i], snapshot.Events[j]
+		if a.EventType != b.EventType {
+			return a.EventType < b.EventType
+		}
+		return a.ResourceName < b.ResourceName
+	})
+	sort.Slice(snapshot.Pending, func(i, j int) bool {
+		a, b := snapshot.Pending[i], snapshot.Pending[j]
+		if a.EventType != b.EventType {
+			return a.EventType < b.EventType
+		}
+		return a.ResourceName < b.ResourceName
+	})
+	return snapshot
+}
+
+// DedupStats summarizes the deduplication state
+type DedupStats struct {
+	// TrackedEvents is the number of active events across all hooks
+	TrackedEvents int `json:"trackedEvents"`
+
+	// EventsInCooldown is the number of tracked events whose re-notification is suppressed
+	EventsInCooldown int `json:"eventsInCooldown"`
+
+	// EventsPerHook is the number of active events per hook ("namespace/name")
+	EventsPerHook map[string]int `json:"eventsPerHook"`
+}
+
+// Stats returns a snapshot of the tracked events, per hook and in notification cooldown
+func (m *Manager) Stats() DedupStats {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now()
+	stats := DedupStats{EventsPerHook: make(map[string]int, len(m.hookEvents))}
+	for hookName, hookEventMap := range m.hookEvents {
+		stats.EventsPerHook[hookName] = len(hookEventMap)
+		stats.TrackedEvents += len(hookEventMap)
+		for _, activeEvent := range hookEventMap {
+			if m.inCooldown(activeEvent, now) {
+				stats.EventsInCooldown++
+			}
+		}
+	}
+
+	return stats
+}
@@ -5,9 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kagent-dev/khook/api/v1alpha2"
 	"github.com/kagent-dev/khook/internal/interfaces"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -33,6 +35,46 @@ func TestEventKey(t *testing.T) {
 	assert.Equal(t, expected, key)
 }
 
+func TestEventKey_KeyComponents(t *testing.T) {
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	oomKill := interfaces.Event{Type: "oom-kill", ResourceName: "test-pod", Namespace: "default", Reason: "OOMKilling"}
+	killed := oomKill
+	killed.Reason = "Killing"
+	otherPod := oomKill
+	otherPod.ResourceName = "other-pod"
+
+	// The default key dedupes different reasons together
+	manager := NewManager()
+	require.NoError(t, manager.RecordEvent(hookRef, oomKill))
+	assert.False(t, manager.ShouldProcessEvent(hookRef, killed))
+	assert.True(t, manager.ShouldProcessEvent(hookRef, otherPod))
+
+	// Including the reason keeps them apart
+	manager = NewManager(WithKeyComponents(KeyComponents{Namespace: true, ResourceName: true, Reason: true}))
+	require.NoError(t, manager.RecordEvent(hookRef, oomKill))
+	assert.True(t, manager.ShouldProcessEvent(hookRef, killed))
+	assert.Equal(t, "oom-kill:default:test-pod:OOMKilling", manager.eventKey(oomKill))
+
+	// Excluding the resource name dedupes across resources
+	manager = NewManager(WithKeyComponents(KeyComponents{Namespace: true}))
+	require.NoError(t, manager.RecordEvent(hookRef, oomKill))
+	assert.False(t, manager.ShouldProcessEvent(hookRef, otherPod))
+	assert.Equal(t, "oom-kill:default", manager.eventKey(oomKill))
+}
+
+func TestParseKeyComponents(t *testing.T) {
+	components, err := ParseKeyComponents(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultKeyComponents, components)
+
+	components, err = ParseKeyComponents([]string{"namespace", "reason"})
+	require.NoError(t, err)
+	assert.Equal(t, KeyComponents{Namespace: true, Reason: true}, components)
+
+	_, err = ParseKeyComponents([]string{"uid"})
+	assert.Error(t, err)
+}
+
 func TestShouldProcessEvent_NewEvent(t *testing.T) {
 	manager := NewManager()
 
@@ -92,6 +134,168 @@ func TestShouldProcessEvent_ExpiredEvent(t *testing.T) {
 	assert.True(t, shouldProcess)
 }
 
+func TestWindowFor(t *testing.T) {
+	manager := NewManager()
+	assert.Equal(t, EventTimeoutDuration, manager.windowFor("oom-kill"))
+
+	manager = NewManager(WithEventTypeWindows(map[string]time.Duration{
+		"oom-kill":    2 * time.Minute,
+		"pod-pending": 0,
+	}, 15*time.Minute))
+	assert.Equal(t, 2*time.Minute, manager.windowFor("oom-kill"))
+	// Non-positive overrides fall back to the default window
+	assert.Equal(t, 15*time.Minute, manager.windowFor("pod-pending"))
+	assert.Equal(t, 15*time.Minute, manager.windowFor("pod-restart"))
+}
+
+func TestReconfigure(t *testing.T) {
+	manager := NewManager(WithEventTypeWindows(map[string]time.Duration{"oom-kill": 2 * time.Minute}, 0))
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	event := interfaces.Event{
+		Type:         "oom-kill",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+	manager.hookEvents[hookRef.String()][manager.eventKey(event)].FirstSeen = time.Now().Add(-5 * time.Minute)
+	assert.True(t, manager.ShouldProcessEvent(hookRef, event))
+
+	// Tracked events are evaluated with the new windows
+	manager.Reconfigure(
+		WithEventTypeWindows(map[string]time.Duration{"oom-kill": 10 * time.Minute}, 0),
+		WithNotifyCooldowns(nil, 30*time.Minute),
+	)
+	assert.False(t, manager.ShouldProcessEvent(hookRef, event))
+	assert.Equal(t, 30*time.Minute, manager.cooldownFor("oom-kill"))
+	assert.Equal(t, EventTimeoutDuration, manager.windowFor("pod-restart"))
+}
+
+// TestReconfigureDuringStatusUpdates reloads the windows while statuses are computed, as a
+// SIGHUP reload does during the status loop; run with -race to detect unsynchronized reads
+func TestReconfigureDuringStatusUpdates(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	require.NoError(t, manager.RecordEvent(hookRef, interfaces.Event{
+		Type:         "oom-kill",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			manager.Reconfigure(
+				WithEventTypeWindows(map[string]time.Duration{"oom-kill": time.Duration(i+1) * time.Minute}, 0),
+				WithNotifyCooldowns(map[string]time.Duration{"oom-kill": time.Minute}, 0),
+			)
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		assert.Len(t, manager.GetActiveEventsWithStatus(hookRef), 1)
+	}
+	<-done
+}
+
+func TestShouldProcessEvent_PerEventTypeWindow(t *testing.T) {
+	manager := NewManager(WithEventTypeWindows(map[string]time.Duration{
+		"oom-kill":    2 * time.Minute,
+		"pod-pending": 30 * time.Minute,
+	}, 0))
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	oomEvent := interfaces.Event{
+		Type:         "oom-kill",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}
+	pendingEvent := interfaces.Event{
+		Type:         "pod-pending",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}
+	require.NoError(t, manager.RecordEvent(hookRef, oomEvent))
+	require.NoError(t, manager.RecordEvent(hookRef, pendingEvent))
+
+	// Age both events past the oom-kill window but within the pod-pending window
+	hookEventMap := manager.hookEvents[hookRef.String()]
+	hookEventMap[manager.eventKey(oomEvent)].FirstSeen = time.Now().Add(-5 * time.Minute)
+	hookEventMap[manager.eventKey(pendingEvent)].FirstSeen = time.Now().Add(-5 * time.Minute)
+
+	assert.True(t, manager.ShouldProcessEvent(hookRef, oomEvent))
+	assert.False(t, manager.ShouldProcessEvent(hookRef, pendingEvent))
+
+	activeEvents := manager.GetActiveEventsWithStatus(hookRef)
+	require.Len(t, activeEvents, 2)
+	for _, event := range activeEvents {
+		switch event.EventType {
+		case "oom-kill":
+			assert.Equal(t, StatusResolved, event.Status)
+		case "pod-pending":
+			assert.Equal(t, StatusFiring, event.Status)
+		}
+	}
+
+	require.NoError(t, manager.CleanupExpiredEvents(hookRef))
+	assert.Equal(t, 1, manager.GetEventCount())
+}
+
+func TestCooldownFor(t *testing.T) {
+	manager := NewManager()
+	assert.Equal(t, NotificationSuppressionDuration, manager.cooldownFor("oom-kill"))
+
+	manager = NewManager(WithNotifyCooldowns(map[string]time.Duration{
+		"oom-kill":    30 * time.Minute,
+		"pod-pending": 0,
+	}, 5*time.Minute))
+	assert.Equal(t, 30*time.Minute, manager.cooldownFor("oom-kill"))
+	// Non-positive overrides fall back to the default cooldown
+	assert.Equal(t, 5*time.Minute, manager.cooldownFor("pod-pending"))
+	assert.Equal(t, 5*time.Minute, manager.cooldownFor("pod-restart"))
+}
+
+func TestShouldProcessEvent_NotifyCooldown(t *testing.T) {
+	manager := NewManager(
+		WithEventTypeWindows(nil, 2*time.Minute),
+		WithNotifyCooldowns(map[string]time.Duration{"oom-kill": 30 * time.Minute}, 0),
+	)
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	event := interfaces.Event{
+		Type:         "oom-kill",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}
+
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+	manager.MarkNotified(hookRef, event, nil)
+
+	// Age the event past its deduplication window but within the cooldown
+	activeEvent := manager.hookEvents[hookRef.String()][manager.eventKey(event)]
+	activeEvent.FirstSeen = time.Now().Add(-5 * time.Minute)
+	notifiedAt := time.Now().Add(-5 * time.Minute)
+	activeEvent.LastNotifiedAt = &notifiedAt
+
+	// Re-firing is suppressed and the event stays firing
+	assert.False(t, manager.ShouldProcessEvent(hookRef, event))
+	require.NoError(t, manager.CleanupExpiredEvents(hookRef))
+	activeEvents := manager.GetActiveEventsWithStatus(hookRef)
+	require.Len(t, activeEvents, 1)
+	assert.Equal(t, StatusFiring, activeEvents[0].Status)
+
+	// Once the cooldown passes the event is processed again and expires
+	notifiedAt = time.Now().Add(-31 * time.Minute)
+	activeEvent.LastNotifiedAt = &notifiedAt
+	assert.True(t, manager.ShouldProcessEvent(hookRef, event))
+	assert.Equal(t, StatusResolved, manager.GetActiveEventsWithStatus(hookRef)[0].Status)
+	require.NoError(t, manager.CleanupExpiredEvents(hookRef))
+	assert.Equal(t, 0, manager.GetEventCount())
+}
+
 func TestRecordEvent_NewEvent(t *testing.T) {
 	manager := NewManager()
 
@@ -142,6 +346,52 @@ func TestRecordEvent_UpdateExistingEvent(t *testing.T) {
 	assert.True(t, activeEvents[0].LastSeen.After(firstSeen)) // LastSeen should be updated
 }
 
+func TestRecordEvent_OccurrenceCount(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	event := interfaces.Event{
+		Type:         "pod-restart",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}
+
+	// Each repeat of the same event increments the occurrence count
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, manager.RecordEvent(hookRef, event))
+
+		activeEvents := manager.GetActiveEvents(hookRef)
+		require.Len(t, activeEvents, 1)
+		assert.Equal(t, int32(i), activeEvents[0].OccurrenceCount)
+	}
+
+	// A larger Kubernetes series count takes precedence over the local count
+	event.Metadata = map[string]string{"count": "12"}
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+	assert.Equal(t, int32(12), manager.GetActiveEvents(hookRef)[0].OccurrenceCount)
+
+	// Subsequent repeats keep counting from there
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+	assert.Equal(t, int32(13), manager.GetActiveEvents(hookRef)[0].OccurrenceCount)
+}
+
+func TestRecordEvent_OccurrenceCountFromSeries(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	event := interfaces.Event{
+		Type:         "pod-restart",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+		Metadata:     map[string]string{"count": "5"},
+	}
+
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+	assert.Equal(t, int32(5), manager.GetActiveEvents(hookRef)[0].OccurrenceCount)
+}
+
 func TestRecordEvent_MultipleHooks(t *testing.T) {
 	manager := NewManager()
 
@@ -168,6 +418,146 @@ func TestRecordEvent_MultipleHooks(t *testing.T) {
 	assert.Equal(t, 2, manager.GetEventCount())
 }
 
+func TestMarkNotified_AgentResponse(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	event := interfaces.Event{Type: "oom-kill", ResourceName: "test-pod", Namespace: "default", Timestamp: time.Now()}
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+
+	manager.MarkNotified(hookRef, event, &interfaces.AgentResponse{SessionID: "session-1", Summary: "Raised the memory limit"})
+	activeEvents := manager.GetActiveEvents(hookRef)
+	require.Len(t, activeEvents, 1)
+	assert.Equal(t, "session-1", activeEvents[0].SessionID)
+	assert.Equal(t, "Raised the memory limit", activeEvents[0].RemediationSummary)
+
+	// A later notification replaces the session
+	manager.MarkNotified(hookRef, event, &interfaces.AgentResponse{SessionID: "session-2"})
+	activeEvents = manager.GetActiveEvents(hookRef)
+	assert.Equal(t, "session-2", activeEvents[0].SessionID)
+	assert.Empty(t, activeEvents[0].RemediationSummary)
+}
+
+func TestRestoreFromStatus(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	firstSeen := time.Now().Add(-2 * time.Minute)
+
+	hook := &v1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+		Status: v1alpha2.HookStatus{
+			ActiveEvents: []v1alpha2.ActiveEventStatus{
+				{
+					EventType:       "pod-restart",
+					ResourceName:    "firing-pod",
+					FirstSeen:       metav1.NewTime(firstSeen),
+					LastSeen:        metav1.NewTime(firstSeen.Add(time.Minute)),
+					Status:          StatusFiring,
+					OccurrenceCount: 3,
+					SessionID:       "session-1",
+				},
+				{
+					EventType:    "oom-kill",
+					ResourceName: "resolved-pod",
+					FirstSeen:    metav1.NewTime(firstSeen),
+					LastSeen:     metav1.NewTime(firstSeen),
+					Status:       StatusResolved,
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, 1, manager.RestoreFromStatus([]*v1alpha2.Hook{hook}))
+
+	activeEvents := manager.GetActiveEvents(hookRef)
+	require.Len(t, activeEvents, 1)
+	assert.Equal(t, "firing-pod", activeEvents[0].ResourceName)
+	assert.True(t, firstSeen.Equal(activeEvents[0].FirstSeen))
+	assert.True(t, firstSeen.Add(time.Minute).Equal(activeEvents[0].LastSeen))
+	assert.Equal(t, int32(3), activeEvents[0].OccurrenceCount)
+	assert.Equal(t, "session-1", activeEvents[0].SessionID)
+
+	// The restored event is still within its window, so a repeat is deduplicated
+	restartEvent := interfaces.Event{Type: "pod-restart", ResourceName: "firing-pod", Namespace: "default"}
+	assert.False(t, manager.ShouldProcessEvent(hookRef, restartEvent))
+
+	// Resolved events are not restored
+	oomEvent := interfaces.Event{Type: "oom-kill", ResourceName: "resolved-pod", Namespace: "default"}
+	assert.True(t, manager.ShouldProcessEvent(hookRef, oomEvent))
+
+	// Events already tracked in memory are kept as they are
+	require.NoError(t, manager.RecordEvent(hookRef, restartEvent))
+	assert.Equal(t, 0, manager.RestoreFromStatus([]*v1alpha2.Hook{hook}))
+	assert.Equal(t, int32(4), manager.GetActiveEvents(hookRef)[0].OccurrenceCount)
+}
+
+func TestRestoreFromStatus_EventNamespaceAndReason(t *testing.T) {
+	components := KeyComponents{Namespace: true, ResourceName: true, Reason: true}
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "ops"}
+	event := interfaces.Event{
+		Type:         "oom-kill",
+		ResourceName: "web-0",
+		Namespace:    "a",
+		Reason:       "OOMKilling",
+		Timestamp:    time.Now(),
+	}
+
+	// A hook watching all namespaces saves the event's own namespace and reason
+	before := NewManager(WithKeyComponents(components))
+	require.NoError(t, before.RecordEvent(hookRef, event))
+	activeEvents := before.GetActiveEvents(hookRef)
+	require.Len(t, activeEvents, 1)
+	hook := &v1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "ops"},
+		Status: v1alpha2.HookStatus{
+			ActiveEvents: []v1alpha2.ActiveEventStatus{{
+				EventType:    activeEvents[0].EventType,
+				ResourceName: activeEvents[0].ResourceName,
+				Namespace:    activeEvents[0].Namespace,
+				Reason:       activeEvents[0].Reason,
+				FirstSeen:    metav1.NewTime(activeEvents[0].FirstSeen),
+				LastSeen:     metav1.NewTime(activeEvents[0].LastSeen),
+				Status:       StatusFiring,
+			}},
+		},
+	}
+
+	// After a restart the live event matches the restored one
+	after := NewManager(WithKeyComponents(components))
+	assert.Equal(t, 1, after.RestoreFromStatus([]*v1alpha2.Hook{hook}))
+	assert.False(t, after.ShouldProcessEvent(hookRef, event))
+
+	// Events in other namespaces or with other reasons are still new
+	otherNamespace := event
+	otherNamespace.Namespace = "b"
+	assert.True(t, after.ShouldProcessEvent(hookRef, otherNamespace))
+	otherReason := event
+	otherReason.Reason = "Killing"
+	assert.True(t, after.ShouldProcessEvent(hookRef, otherReason))
+}
+
+func TestRestoreFromStatus_ExpiredEvent(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	firstSeen := time.Now().Add(-EventTimeoutDuration - time.Minute)
+
+	manager.RestoreFromStatus([]*v1alpha2.Hook{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-hook", Namespace: "default"},
+		Status: v1alpha2.HookStatus{
+			ActiveEvents: []v1alpha2.ActiveEventStatus{{
+				EventType:    "pod-restart",
+				ResourceName: "test-pod",
+				FirstSeen:    metav1.NewTime(firstSeen),
+				LastSeen:     metav1.NewTime(firstSeen),
+				Status:       StatusFiring,
+			}},
+		},
+	}})
+
+	// An event whose window elapsed while the controller was down fires again
+	event := interfaces.Event{Type: "pod-restart", ResourceName: "test-pod", Namespace: "default"}
+	assert.True(t, manager.ShouldProcessEvent(hookRef, event))
+}
+
 func TestCleanupExpiredEvents(t *testing.T) {
 	manager := NewManager()
 
@@ -209,6 +599,126 @@ func TestCleanupExpiredEvents(t *testing.T) {
 	assert.Equal(t, "recent-pod", activeEvents[0].ResourceName)
 }
 
+func TestResolveEvent(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	event := interfaces.Event{
+		Type:         "pod-restart",
+		ResourceName: "deleted-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+	manager.MarkNotified(hookRef, event, nil)
+
+	assert.False(t, manager.ResolveEvent(hookRef, "oom-kill", "default", "deleted-pod"))
+	assert.True(t, manager.ResolveEvent(hookRef, "pod-restart", "default", "deleted-pod"))
+	assert.False(t, manager.ResolveEvent(hookRef, "pod-restart", "default", "deleted-pod"), "already resolved")
+
+	activeEvents := manager.GetActiveEventsWithStatus(hookRef)
+	require.Len(t, activeEvents, 1)
+	assert.Equal(t, StatusResolved, activeEvents[0].Status)
+
+	// A recreated pod with the same name fires again despite the notification cooldown
+	assert.True(t, manager.ShouldProcessEvent(hookRef, event))
+	require.NoError(t, manager.RecordEvent(hookRef, event))
+	activeEvents = manager.GetActiveEvents(hookRef)
+	require.Len(t, activeEvents, 1)
+	assert.Equal(t, StatusFiring, activeEvents[0].Status)
+	assert.Equal(t, int32(1), activeEvents[0].OccurrenceCount)
+
+	// Resolved events are removed by the next cleanup
+	manager.ResolveEvent(hookRef, "pod-restart", "default", "deleted-pod")
+	require.NoError(t, manager.CleanupExpiredEvents(hookRef))
+	assert.Empty(t, manager.GetActiveEvents(hookRef))
+}
+
+func TestResolveEvent_OtherNamespace(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "ops"}
+	for _, namespace := range []string{"a", "b"} {
+		event := interfaces.Event{
+			Type:         "pod-restart",
+			ResourceName: "web-0",
+			Namespace:    namespace,
+			Timestamp:    time.Now(),
+		}
+		require.NoError(t, manager.RecordEvent(hookRef, event))
+	}
+
+	assert.True(t, manager.ResolveEvent(hookRef, "pod-restart", "a", "web-0"))
+
+	statuses := make(map[string]string)
+	for _, activeEvent := range manager.GetActiveEventsWithStatus(hookRef) {
+		statuses[activeEvent.Namespace] = activeEvent.Status
+	}
+	assert.Equal(t, map[string]string{"a": StatusResolved, "b": StatusFiring}, statuses)
+}
+
+func TestConfirmActive(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	event := interfaces.Event{
+		Type:         "pod-pending",
+		ResourceName: "pending-pod",
+		Namespace:    "default",
+		Timestamp:    time.Now(),
+	}
+	key := manager.eventKey(event)
+
+	// Occurrences before the minimum active duration stay pending
+	assert.False(t, manager.ConfirmActive(hookRef, event, time.Minute))
+	assert.False(t, manager.ConfirmActive(hookRef, event, time.Minute))
+	assert.Empty(t, manager.GetActiveEvents(hookRef), "pending events are not active")
+
+	// An occurrence after the duration confirms the event once
+	manager.pendingEvents[hookRef.String()][key].confirmAt = time.Now().Add(-time.Second)
+	assert.True(t, manager.ConfirmActive(hookRef, event, time.Minute))
+	assert.False(t, manager.ConfirmActive(hookRef, event, time.Minute), "confirming starts over")
+
+	// Events that resolve while pending never fire
+	manager.ResolveEvent(hookRef, "pod-pending", "default", "pending-pod")
+	assert.NotContains(t, manager.pendingEvents[hookRef.String()], key)
+
+	// Pending events not observed again within the window are dropped by cleanup
+	assert.False(t, manager.ConfirmActive(hookRef, event, time.Minute))
+	manager.pendingEvents[hookRef.String()][key].confirmAt = time.Now().Add(-EventTimeoutDuration - time.Minute)
+	require.NoError(t, manager.CleanupExpiredEvents(hookRef))
+	assert.NotContains(t, manager.pendingEvents, hookRef.String())
+}
+
+func TestSnapshot(t *testing.T) {
+	manager := NewManager()
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	assert.Equal(t, HookSnapshot{Events: []TrackedEvent{}, Pending: []PendingEvent{}}, manager.Snapshot(hookRef))
+
+	notified := interfaces.Event{Type: "pod-restart", ResourceName: "b-pod", Namespace: "default"}
+	firing := interfaces.Event{Type: "pod-restart", ResourceName: "a-pod", Namespace: "default"}
+	pending := interfaces.Event{Type: "pod-pending", ResourceName: "c-pod", Namespace: "default"}
+	require.NoError(t, manager.RecordEvent(hookRef, notified))
+	manager.MarkNotified(hookRef, notified, nil)
+	require.NoError(t, manager.RecordEvent(hookRef, firing))
+	manager.ConfirmActive(hookRef, pending, time.Minute)
+
+	snapshot := manager.Snapshot(hookRef)
+	require.Len(t, snapshot.Events, 2)
+	assert.Equal(t, "a-pod", snapshot.Events[0].ResourceName)
+	assert.False(t, snapshot.Events[0].InCooldown)
+	assert.Nil(t, snapshot.Events[0].NotifiedAt)
+	assert.Equal(t, "b-pod", snapshot.Events[1].ResourceName)
+	assert.True(t, snapshot.Events[1].InCooldown)
+	assert.NotNil(t, snapshot.Events[1].NotifiedAt)
+	assert.Equal(t, StatusFiring, snapshot.Events[1].Status)
+
+	require.Len(t, snapshot.Pending, 1)
+	assert.Equal(t, "c-pod", snapshot.Pending[0].ResourceName)
+	assert.Equal(t, time.Minute, snapshot.Pending[0].ConfirmAt.Sub(snapshot.Pending[0].FirstSeen))
+
+	// The snapshot is a copy
+	snapshot.Events[0].Status = StatusResolved
+	assert.Equal(t, StatusFiring, manager.Snapshot(hookRef).Events[0].Status)
+}
+
 func TestCleanupExpiredEvents_EmptyHook(t *testing.T) {
 	manager := NewManager()
 
@@ -370,6 +880,33 @@ func TestGetEventCount(t *testing.T) {
 	assert.Equal(t, 3, manager.GetEventCount())
 }
 
+func TestStats(t *testing.T) {
+	manager := NewManager()
+	assert.Equal(t, DedupStats{EventsPerHook: map[string]int{}}, manager.Stats())
+
+	hookA := types.NamespacedName{Name: "hook-a", Namespace: "default"}
+	hookB := types.NamespacedName{Name: "hook-b", Namespace: "ops"}
+	restart := interfaces.Event{Type: "pod-restart", ResourceName: "pod-1", Namespace: "default"}
+	oom := interfaces.Event{Type: "oom-kill", ResourceName: "pod-2", Namespace: "default"}
+	pending := interfaces.Event{Type: "pod-pending", ResourceName: "pod-3", Namespace: "ops"}
+
+	require.NoError(t, manager.RecordEvent(hookA, restart))
+	require.NoError(t, manager.RecordEvent(hookA, oom))
+	require.NoError(t, manager.RecordEvent(hookB, pending))
+	manager.MarkNotified(hookA, restart, nil)
+
+	// A notification older than the cooldown no longer counts
+	manager.MarkNotified(hookB, pending, nil)
+	expired := time.Now().Add(-NotificationSuppressionDuration - time.Minute)
+	manager.hookEvents[hookB.String()][manager.eventKey(pending)].LastNotifiedAt = &expired
+
+	assert.Equal(t, DedupStats{
+		TrackedEvents:    3,
+		EventsInCooldown: 1,
+		EventsPerHook:    map[string]int{"default/hook-a": 2, "ops/hook-b": 1},
+	}, manager.Stats())
+}
+
 func TestConcurrentAccess(t *testing.T) {
 	manager := NewManager()
 
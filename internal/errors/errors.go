@@ -0,0 +1,86 @@
+// Package errors classifies event pipeline failures so callers and metrics can tell
+// transient failures, which may succeed when retried, from permanent ones.
+package errors
+
+import (
+	"errors"
+)
+
+// Kind is the class of a pipeline failure
+type Kind string
+
+const (
+	// KindAgentUnreachable indicates the Kagent API could not be reached or failed with a
+	// server error. The failure is transient.
+	KindAgentUnreachable Kind = "agent_unreachable"
+
+	// KindAgentRejected indicates the Kagent API rejected the request as invalid
+	KindAgentRejected Kind = "agent_rejected"
+
+	// KindInvalidConfig indicates a hook configuration that cannot be acted on
+	KindInvalidConfig Kind = "invalid_config"
+
+	// KindRateLimited indicates the request was refused by a rate limit. The failure is transient.
+	KindRateLimited Kind = "rate_limited"
+)
+
+// Error is a pipeline failure of a known kind
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+// Error returns the message of the underlying error
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Kind)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an Error of the same kind, so errors.Is(err, ErrRateLimited)
+// matches any rate limit failure
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Err == nil && t.Kind == e.Kind
+}
+
+// Sentinels for use with errors.Is
+var (
+	ErrAgentUnreachable = &Error{Kind: KindAgentUnreachable}
+	ErrAgentRejected    = &Error{Kind: KindAgentRejected}
+	ErrInvalidConfig    = &Error{Kind: KindInvalidConfig}
+	ErrRateLimited      = &Error{Kind: KindRateLimited}
+)
+
+// New wraps err as a failure of the given kind. It returns nil if err is nil.
+func New(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// KindOf returns the kind of the outermost classified error in err's chain, or an empty
+// kind if err is not classified
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return ""
+}
+
+// IsTransient reports whether err is a failure that may succeed when retried
+func IsTransient(err error) bool {
+	switch KindOf(err) {
+	case KindAgentUnreachable, KindRateLimited:
+		return true
+	}
+	return false
+}
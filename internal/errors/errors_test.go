@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	err := fmt.Errorf("failed to call agent test-agent: %w", New(KindAgentUnreachable, errors.New("connection refused")))
+
+	assert.Equal(t, KindAgentUnreachable, KindOf(err))
+	assert.Equal(t, "failed to call agent test-agent: connection refused", err.Error())
+	assert.True(t, errors.Is(err, ErrAgentUnreachable))
+	assert.False(t, errors.Is(err, ErrRateLimited))
+	assert.True(t, IsTransient(err))
+
+	assert.Equal(t, Kind(""), KindOf(errors.New("plain")))
+	assert.False(t, IsTransient(errors.New("plain")))
+	assert.False(t, IsTransient(New(KindInvalidConfig, errors.New("bad agent name"))))
+	assert.Nil(t, New(KindInvalidConfig, nil))
+}
@@ -0,0 +1,138 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/metrics"
+)
+
+// watchPodDeletions queues a resource-deleted event for every deleted pod until the watcher
+// stops. Closed watches are re-established with backoff; deletions while disconnected are
+// missed and their events resolve when the deduplication window expires.
+func (w *Watcher) watchPodDeletions(ctx context.Context) {
+	backoff := w.reconnectBackoff
+	resourceVersion := ""
+	for {
+		watcher, rv, err := w.watchPods(ctx, resourceVersion)
+		if err == nil {
+			w.logger.V(1).Info("Pod deletion watcher established", "resourceVersion", rv)
+			backoff = w.reconnectBackoff
+			var stopped bool
+			resourceVersion, stopped = w.consumePodDeletions(ctx, watcher, rv)
+			watcher.Stop()
+			if stopped {
+				return
+			}
+		} else if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			resourceVersion = ""
+		} else {
+			w.logger.Error(err, "Failed to watch pod deletions", "retryIn", backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
+
+// watchPods opens a pod watch starting after resourceVersion. Without a resourceVersion it
+// starts from the current state, listing a single pod for the resourceVersion so existing
+// pods are not replayed. It returns the resourceVersion the watch started from.
+func (w *Watcher) watchPods(ctx context.Context, resourceVersion string) (watch.Interface, string, error) {
+	pods := w.client.CoreV1().Pods(w.namespace)
+	if resourceVersion == "" {
+		list, err := pods.List(ctx, metav1.ListOptions{Limit: 1})
+		if err != nil {
+			return nil, "", err
+		}
+		resourceVersion = list.ResourceVersion
+	}
+
+	watcher, err := pods.Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion})
+	return watcher, resourceVersion, err
+}
+
+// consumePodDeletions queues deleted pods from watcher until it closes or the watcher stops.
+// It returns the last resourceVersion seen and whether the watcher stopped.
+func (w *Watcher) consumePodDeletions(ctx context.Context, watcher watch.Interface, resourceVersion string) (string, bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, true
+		case <-w.stopCh:
+			return resourceVersion, true
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, false
+			}
+
+			if event.Type == watch.Error {
+				err := apierrors.FromObject(event.Object)
+				w.logger.V(1).Info("Pod deletion watcher returned an error, reconnecting", "error", err.Error())
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					resourceVersion = ""
+				}
+				return resourceVersion, false
+			}
+
+			pod, ok := event.Object.(*corev1.Pod)
+			if !ok {
+				continue
+			}
+			if pod.ResourceVersion != "" {
+				resourceVersion = pod.ResourceVersion
+			}
+			if event.Type == watch.Deleted && !w.handlePodDeletion(ctx, pod) {
+				return resourceVersion, true
+			}
+		}
+	}
+}
+
+// handlePodDeletion queues a resource-deleted event for pod. It returns false when the
+// watcher stopped while queueing.
+func (w *Watcher) handlePodDeletion(ctx context.Context, pod *corev1.Pod) bool {
+	if w.isExcluded(pod.Namespace) {
+		return true
+	}
+
+	deleted := interfaces.Event{
+		Type:         interfaces.ResourceDeletedEventType,
+		ResourceName: pod.Name,
+		Timestamp:    time.Now(),
+		Namespace:    pod.Namespace,
+		Reason:       "Deleted",
+		Message:      "Pod was deleted",
+		UID:          string(pod.UID),
+		ReceivedAt:   time.Now(),
+		Metadata: map[string]string{
+			"kind":       "Pod",
+			"apiVersion": "v1",
+		},
+	}
+
+	metrics.WatcherEventsMappedTotal.WithLabelValues(deleted.Type).Inc()
+	w.logger.V(1).Info("Discovered deleted pod", "resource", pod.Name, "namespace", pod.Namespace)
+	select {
+	case w.eventCh <- deleted:
+		return true
+	case <-ctx.Done():
+		metrics.WatcherEventsDroppedTotal.WithLabelValues(deleted.Type).Inc()
+		return false
+	case <-w.stopCh:
+		metrics.WatcherEventsDroppedTotal.WithLabelValues(deleted.Type).Inc()
+		return false
+	}
+}
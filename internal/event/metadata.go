@@ -0,0 +1,134 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+const (
+	// metadataCacheTTL is how long an object's labels and annotations are reused, so a burst
+	// of events about one workload fetches it once
+	metadataCacheTTL = 30 * time.Second
+
+	// metadataLookupTimeout bounds an object lookup so matching cannot stall on the API server
+	metadataLookupTimeout = 2 * time.Second
+)
+
+// metadataCacheEntry is a cached object lookup. Nil maps record an object that no longer
+// exists or whose kind is not supported.
+type metadataCacheEntry struct {
+	labels      map[string]string
+	annotations map[string]string
+	expires     time.Time
+}
+
+// MetadataCache looks up the labels and annotations of the object an event is about and
+// caches them briefly. Pods, Nodes, Deployments, StatefulSets, DaemonSets and ReplicaSets
+// are supported; events about other kinds resolve to no labels or annotations.
+type MetadataCache struct {
+	client  kubernetes.Interface
+	mu      sync.Mutex
+	entries map[string]metadataCacheEntry
+}
+
+// NewMetadataCache creates a metadata cache fetching objects with client
+func NewMetadataCache(client kubernetes.Interface) *MetadataCache {
+	return &MetadataCache{
+		client:  client,
+		entries: map[string]metadataCacheEntry{},
+	}
+}
+
+// Lookup returns the labels and annotations of event's involved object. Objects that no
+// longer exist and unsupported kinds return nil maps. Failed lookups return an error and
+// are not cached.
+func (c *MetadataCache) Lookup(ctx context.Context, event interfaces.Event) (map[string]string, map[string]string, error) {
+	kind := event.Metadata["kind"]
+	namespace := event.Namespace
+	if kind == "Node" {
+		namespace = ""
+	}
+	key := kind + "/" + namespace + "/" + event.ResourceName
+
+	if entry, ok := c.get(key, time.Now()); ok {
+		return entry.labels, entry.annotations, nil
+	}
+
+	object, err := c.fetch(ctx, kind, namespace, event.ResourceName)
+	if apierrors.IsNotFound(err) {
+		object, err = nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to look up %s %s: %w", kind, event.ResourceName, err)
+	}
+
+	entry := metadataCacheEntry{}
+	if object != nil {
+		entry.labels = object.GetLabels()
+		entry.annotations = object.GetAnnotations()
+	}
+	c.put(key, entry, time.Now())
+	return entry.labels, entry.annotations, nil
+}
+
+// fetch gets the object of kind, returning nil for kinds that are not supported
+func (c *MetadataCache) fetch(ctx context.Context, kind, namespace, name string) (metav1.Object, error) {
+	ctx, cancel := context.WithTimeout(ctx, metadataLookupTimeout)
+	defer cancel()
+
+	opts := metav1.GetOptions{}
+	switch kind {
+	case "Pod":
+		return c.client.CoreV1().Pods(namespace).Get(ctx, name, opts)
+	case "Node":
+		return c.client.CoreV1().Nodes().Get(ctx, name, opts)
+	case "Deployment":
+		return c.client.AppsV1().Deployments(namespace).Get(ctx, name, opts)
+	case "StatefulSet":
+		return c.client.AppsV1().StatefulSets(namespace).Get(ctx, name, opts)
+	case "DaemonSet":
+		return c.client.AppsV1().DaemonSets(namespace).Get(ctx, name, opts)
+	case "ReplicaSet":
+		return c.client.AppsV1().ReplicaSets(namespace).Get(ctx, name, opts)
+	default:
+		return nil, nil
+	}
+}
+
+// get returns the cached entry for key and whether a live entry exists
+func (c *MetadataCache) get(key string, now time.Time) (metadataCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return metadataCacheEntry{}, false
+	}
+	if now.After(entry.expires) {
+		delete(c.entries, key)
+		return metadataCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// put caches entry for key until metadataCacheTTL after now, dropping expired entries
+func (c *MetadataCache) put(key string, entry metadataCacheEntry, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, existing := range c.entries {
+		if now.After(existing.expires) {
+			delete(c.entries, k)
+		}
+	}
+	entry.expires = now.Add(metadataCacheTTL)
+	c.entries[key] = entry
+}
@@ -0,0 +1,143 @@
+package event
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+const (
+	// ownerCacheTTL is how long a pod's owner lookup is reused, so a crash-looping pod's
+	// events do not each fetch the pod
+	ownerCacheTTL = 30 * time.Second
+
+	// ownerLookupTimeout bounds a pod lookup so enrichment cannot stall the watch
+	ownerLookupTimeout = 2 * time.Second
+)
+
+// workloadOwner is the workload that controls a pod
+type workloadOwner struct {
+	Kind string
+	Name string
+}
+
+// ownerCacheEntry is a cached owner lookup. A nil owner records a pod without a
+// controller, or one that was already deleted.
+type ownerCacheEntry struct {
+	owner   *workloadOwner
+	expires time.Time
+}
+
+// ownerCache caches pod owner lookups by namespace/name
+type ownerCache struct {
+	mu      sync.Mutex
+	entries map[string]ownerCacheEntry
+}
+
+// get returns the cached owner for key and whether a live entry exists
+func (c *ownerCache) get(key string, now time.Time) (*workloadOwner, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if now.After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.owner, true
+}
+
+// put caches owner for key until ownerCacheTTL after now, dropping expired entries
+func (c *ownerCache) put(key string, owner *workloadOwner, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = map[string]ownerCacheEntry{}
+	}
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = ownerCacheEntry{owner: owner, expires: now.Add(ownerCacheTTL)}
+}
+
+// enrichOwner adds ownerKind and ownerName to the metadata of pod events from the pod's
+// controller owner. Pods owned by a Deployment's ReplicaSet report the Deployment. Pods
+// that no longer exist or have no controller are left unenriched.
+func (w *Watcher) enrichOwner(ctx context.Context, event *interfaces.Event) {
+	if event.Metadata["kind"] != "Pod" {
+		return
+	}
+
+	key := event.Namespace + "/" + event.ResourceName
+	owner, cached := w.owners.get(key, time.Now())
+	if !cached {
+		var ok bool
+		owner, ok = w.lookupOwner(ctx, event.Namespace, event.ResourceName)
+		if !ok {
+			return
+		}
+		w.owners.put(key, owner, time.Now())
+	}
+
+	if owner != nil {
+		event.Metadata["ownerKind"] = owner.Kind
+		event.Metadata["ownerName"] = owner.Name
+	}
+}
+
+// lookupOwner fetches the pod and returns its workload owner. It reports false when the
+// lookup failed and should not be cached.
+func (w *Watcher) lookupOwner(ctx context.Context, namespace, name string) (*workloadOwner, bool) {
+	ctx, cancel := context.WithTimeout(ctx, ownerLookupTimeout)
+	defer cancel()
+
+	pod, err := w.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		w.logger.V(1).Info("Pod already deleted, skipping owner enrichment",
+			"namespace", namespace,
+			"pod", name)
+		return nil, true
+	}
+	if err != nil {
+		w.logger.V(1).Info("Failed to look up pod owner",
+			"namespace", namespace,
+			"pod", name,
+			"error", err.Error())
+		return nil, false
+	}
+	return podOwner(pod), true
+}
+
+// podOwner returns the workload controlling pod, or nil if it has no controller.
+// A ReplicaSet created by a Deployment is named after the Deployment with the pod template
+// hash appended, which identifies the Deployment without fetching the ReplicaSet.
+func podOwner(pod *corev1.Pod) *workloadOwner {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return nil
+	}
+
+	owner := &workloadOwner{Kind: ref.Kind, Name: ref.Name}
+	if ref.Kind == "ReplicaSet" {
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" {
+			if deployment, ok := strings.CutSuffix(ref.Name, "-"+hash); ok && deployment != "" {
+				owner = &workloadOwner{Kind: "Deployment", Name: deployment}
+			}
+		}
+	}
+	return owner
+}
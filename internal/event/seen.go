@@ -0,0 +1,87 @@
+package event
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// seenEventTTL is how long an emitted event version is remembered, covering watch
+	// re-lists that deliver recent events again
+	seenEventTTL = 10 * time.Minute
+
+	// maxSeenEvents bounds the remembered event versions; the least recently emitted
+	// are forgotten first
+	maxSeenEvents = 4096
+)
+
+// seenEvent is the last emitted version of an event object
+type seenEvent struct {
+	uid             string
+	resourceVersion string
+	expires         time.Time
+}
+
+// seenEvents is a bounded LRU of the versions of emitted events, keyed by event UID
+type seenEvents struct {
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// seen reports whether the version of the event with uid was already emitted
+func (s *seenEvents) seen(uid, resourceVersion string, now time.Time) bool {
+	if uid == "" || resourceVersion == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[uid]
+	if !ok {
+		return false
+	}
+	entry := elem.Value.(*seenEvent)
+	if now.After(entry.expires) {
+		s.order.Remove(elem)
+		delete(s.entries, uid)
+		return false
+	}
+	return entry.resourceVersion == resourceVersion
+}
+
+// add records the version of the event with uid as emitted, evicting the least recently
+// emitted event once maxSeenEvents are remembered
+func (s *seenEvents) add(uid, resourceVersion string, now time.Time) {
+	if uid == "" || resourceVersion == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.entries == nil {
+		s.order = list.New()
+		s.entries = map[string]*list.Element{}
+	}
+	if elem, ok := s.entries[uid]; ok {
+		entry := elem.Value.(*seenEvent)
+		entry.resourceVersion = resourceVersion
+		entry.expires = now.Add(seenEventTTL)
+		s.order.MoveToFront(elem)
+		return
+	}
+
+	s.entries[uid] = s.order.PushFront(&seenEvent{
+		uid:             uid,
+		resourceVersion: resourceVersion,
+		expires:         now.Add(seenEventTTL),
+	})
+	if s.order.Len() > maxSeenEvents {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*seenEvent).uid)
+	}
+}
@@ -3,11 +3,15 @@ package event
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/watch"
@@ -16,8 +20,24 @@ import (
 
 	"github.com/kagent-dev/khook/api/v1alpha2"
 	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/metrics"
 )
 
+const (
+	// initialReconnectBackoff is the delay before the first attempt to re-establish a closed watch
+	initialReconnectBackoff = time.Second
+
+	// maxReconnectBackoff caps the delay between attempts to re-establish a closed watch
+	maxReconnectBackoff = time.Minute
+)
+
+// DefaultStalenessWindow is how old an event's last occurrence may be before it is ignored
+const DefaultStalenessWindow = 15 * time.Minute
+
+// DefaultEventBufferSize is how many mapped events may wait for processing before the
+// watcher stops reading from the watch
+const DefaultEventBufferSize = 100
+
 // Watcher implements the EventWatcher interface
 type Watcher struct {
 	client    kubernetes.Interface
@@ -25,10 +45,163 @@ type Watcher struct {
 	logger    logr.Logger
 	stopCh    chan struct{}
 	eventCh   chan interfaces.Event
+
+	// excludedNamespaces lists namespaces whose events are dropped
+	excludedNamespaces map[string]struct{}
+
+	// excludedControllers lists reporting controllers whose events are dropped
+	excludedControllers map[string]struct{}
+
+	// containerStarted maps Normal container start events to container-started
+	containerStarted bool
+
+	// kindEventTypes lists, per additional resource kind, the pod event types its
+	// events may map to
+	kindEventTypes map[string]map[string]struct{}
+
+	// stalenessWindow drops events whose last occurrence is older than this
+	stalenessWindow time.Duration
+
+	// reconnectBackoff is the initial delay before re-establishing a closed watch
+	reconnectBackoff time.Duration
+
+	// owners caches the workload owners of pods that events are about
+	owners ownerCache
+
+	// emitted remembers the versions of queued events so re-delivered objects are skipped
+	emitted seenEvents
+
+	// podDeletions also watches pods and queues a resource-deleted event for each deleted pod
+	podDeletions bool
+
+	// ignoreNormal drops Normal events of every kind without an override in
+	// ignoreNormalByKind; nil applies defaultIgnoreNormalEventsByKind instead
+	ignoreNormal *bool
+
+	// ignoreNormalByKind decides per resource kind whether Normal events are dropped
+	ignoreNormalByKind map[string]bool
+}
+
+// defaultIgnoreNormalEventsByKind lists the kinds whose Normal events are mapped unless
+// configured otherwise: Deployment scaling and Node cordon and drain events are Normal.
+// Normal events of all other kinds are dropped.
+var defaultIgnoreNormalEventsByKind = map[string]bool{
+	"Deployment": false,
+	"Node":       false,
+}
+
+// WatcherOption configures optional Watcher behavior
+type WatcherOption func(*Watcher)
+
+// WatchAllNamespaces makes the watcher watch events in every namespace
+// instead of the namespace passed to NewWatcher
+func WatchAllNamespaces() WatcherOption {
+	return func(w *Watcher) {
+		w.namespace = metav1.NamespaceAll
+	}
+}
+
+// WithExcludedNamespaces drops events from the given namespaces before they are mapped
+func WithExcludedNamespaces(namespaces ...string) WatcherOption {
+	return func(w *Watcher) {
+		for _, ns := range namespaces {
+			w.excludedNamespaces[ns] = struct{}{}
+		}
+	}
+}
+
+// WithExcludedReportingControllers drops events reported by the given controllers
+// (e.g. "example.com/noisy-operator") before they are mapped
+func WithExcludedReportingControllers(controllers ...string) WatcherOption {
+	return func(w *Watcher) {
+		for _, controller := range controllers {
+			if w.excludedControllers == nil {
+				w.excludedControllers = map[string]struct{}{}
+			}
+			w.excludedControllers[controller] = struct{}{}
+		}
+	}
+}
+
+// WithContainerStartedEvents maps container start events to the container-started
+// event type. They are Normal events and are otherwise ignored.
+func WithContainerStartedEvents() WatcherOption {
+	return func(w *Watcher) {
+		w.containerStarted = true
+	}
+}
+
+// WithIgnoreNormalEvents drops (ignore=true) or maps (ignore=false) Normal events of every
+// resource kind, replacing the per-kind defaults. WithIgnoreNormalEventsByKind overrides it
+// for specific kinds.
+func WithIgnoreNormalEvents(ignore bool) WatcherOption {
+	return func(w *Watcher) {
+		w.ignoreNormal = &ignore
+	}
+}
+
+// WithIgnoreNormalEventsByKind decides per resource kind (e.g. "Node") whether its Normal
+// events are dropped, overriding WithIgnoreNormalEvents and the defaults
+func WithIgnoreNormalEventsByKind(kinds map[string]bool) WatcherOption {
+	return func(w *Watcher) {
+		for kind, ignore := range kinds {
+			if w.ignoreNormalByKind == nil {
+				w.ignoreNormalByKind = map[string]bool{}
+			}
+			w.ignoreNormalByKind[kind] = ignore
+		}
+	}
+}
+
+// WithPodDeletions also watches pods and queues a resource-deleted event whenever a pod is
+// deleted, so the events of deleted pods resolve without waiting for their window to expire
+func WithPodDeletions() WatcherOption {
+	return func(w *Watcher) {
+		w.podDeletions = true
+	}
+}
+
+// WithEventTypeKinds lets events about additional resource kinds map to pod event types.
+// kinds maps an event type (e.g. "oom-kill") to the extra kinds that can produce it;
+// events from those kinds are mapped with the pod rules and kept only for listed types.
+func WithEventTypeKinds(kinds map[string][]string) WatcherOption {
+	return func(w *Watcher) {
+		for eventType, kindList := range kinds {
+			for _, kind := range kindList {
+				if w.kindEventTypes == nil {
+					w.kindEventTypes = map[string]map[string]struct{}{}
+				}
+				if w.kindEventTypes[kind] == nil {
+					w.kindEventTypes[kind] = map[string]struct{}{}
+				}
+				w.kindEventTypes[kind][eventType] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithEventBufferSize sets how many mapped events may wait for processing instead of
+// DefaultEventBufferSize. Non-positive values keep the default.
+func WithEventBufferSize(size int) WatcherOption {
+	return func(w *Watcher) {
+		if size > 0 {
+			w.eventCh = make(chan interfaces.Event, size)
+		}
+	}
+}
+
+// WithStalenessWindow ignores events whose last occurrence is older than window instead of
+// DefaultStalenessWindow. Non-positive values keep the default.
+func WithStalenessWindow(window time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if window > 0 {
+			w.stalenessWindow = window
+		}
+	}
 }
 
 // NewWatcher creates a new EventWatcher instance
-func NewWatcher(client kubernetes.Interface, namespace string) interfaces.EventWatcher {
+func NewWatcher(client kubernetes.Interface, namespace string, opts ...WatcherOption) interfaces.EventWatcher {
 	// Validate inputs
 	if client == nil {
 		panic("kubernetes client cannot be nil")
@@ -53,118 +226,271 @@ func NewWatcher(client kubernetes.Interface, namespace string) interfaces.EventW
 		panic("namespace name cannot start or end with a hyphen")
 	}
 
-	return &Watcher{
-		client:    client,
-		namespace: namespace,
-		logger:    log.Log.WithName("event-watcher").WithValues("namespace", namespace),
-		stopCh:    make(chan struct{}),
-		eventCh:   make(chan interfaces.Event, 100),
+	w := &Watcher{
+		client:             client,
+		namespace:          namespace,
+		stopCh:             make(chan struct{}),
+		eventCh:            make(chan interfaces.Event, DefaultEventBufferSize),
+		excludedNamespaces: map[string]struct{}{},
+		stalenessWindow:    DefaultStalenessWindow,
+		reconnectBackoff:   initialReconnectBackoff,
+	}
+	for _, opt := range opts {
+		opt(w)
 	}
+	w.logger = log.Log.WithName("event-watcher").WithValues("namespace", w.namespace)
+
+	return w
+}
+
+// isExcluded reports whether events from namespace should be dropped
+func (w *Watcher) isExcluded(namespace string) bool {
+	_, excluded := w.excludedNamespaces[namespace]
+	return excluded
 }
 
-// Start begins the event watching process
+// Start begins the event watching process. When the watch closes, for example after an
+// API server restart or watch timeout, it is re-established with backoff and resumes from
+// the last resourceVersion seen.
 func (w *Watcher) Start(ctx context.Context) error {
 	w.logger.Info("Starting event watcher", "namespace", w.namespace)
 
-	// Create a field selector to watch for events
-	fieldSelector := fields.Everything()
-
-	// Create a watch for events using the events.k8s.io/v1 API
-	watchlist := metav1.ListOptions{
-		FieldSelector: fieldSelector.String(),
-	}
-
-	w.logger.V(1).Info("Creating EventsV1 watcher", "fieldSelector", fieldSelector.String(), "namespace", w.namespace)
-	watcher, err := w.client.EventsV1().Events(w.namespace).Watch(ctx, watchlist)
+	watcher, err := w.watch(ctx, "")
 	if err != nil {
 		return fmt.Errorf("failed to create event watcher: %w", err)
 	}
 	w.logger.Info("EventsV1 watcher established", "namespace", w.namespace)
 
+	// The event channel is closed only once both watches stopped sending to it
+	var deletions sync.WaitGroup
+	if w.podDeletions {
+		deletions.Add(1)
+		go func() {
+			defer deletions.Done()
+			w.watchPodDeletions(ctx)
+		}()
+	}
+
 	go func() {
-		defer watcher.Stop()
 		defer close(w.eventCh)
+		defer deletions.Wait()
 
+		resourceVersion := ""
 		for {
-			select {
-			case <-ctx.Done():
-				w.logger.Info("Context cancelled, stopping event watcher")
+			var stopped bool
+			resourceVersion, stopped = w.consume(ctx, watcher, resourceVersion)
+			watcher.Stop()
+			if stopped {
 				return
-			case <-w.stopCh:
-				w.logger.Info("Stop signal received, stopping event watcher")
+			}
+
+			watcher, resourceVersion = w.rewatch(ctx, resourceVersion)
+			if watcher == nil {
 				return
-			case event, ok := <-watcher.ResultChan():
-				if !ok {
-					w.logger.Info("Event watcher channel closed")
-					return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// watch opens an EventsV1 watch starting after resourceVersion, or from the current
+// state when it is empty
+func (w *Watcher) watch(ctx context.Context, resourceVersion string) (watch.Interface, error) {
+	// Create a field selector to watch for events
+	fieldSelector := fields.Everything()
+
+	// Create a watch for events using the events.k8s.io/v1 API
+	watchlist := metav1.ListOptions{
+		FieldSelector:   fieldSelector.String(),
+		ResourceVersion: resourceVersion,
+	}
+
+	w.logger.V(1).Info("Creating EventsV1 watcher",
+		"fieldSelector", fieldSelector.String(),
+		"namespace", w.namespace,
+		"resourceVersion", resourceVersion)
+	return w.client.EventsV1().Events(w.namespace).Watch(ctx, watchlist)
+}
+
+// rewatch re-establishes a closed watch, retrying with exponential backoff until it succeeds
+// or the watcher stops. A resourceVersion the API server no longer has is dropped so the
+// watch resumes from the current state. It returns nil when the watcher stopped.
+func (w *Watcher) rewatch(ctx context.Context, resourceVersion string) (watch.Interface, string) {
+	backoff := w.reconnectBackoff
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, resourceVersion
+		case <-w.stopCh:
+			return nil, resourceVersion
+		case <-time.After(backoff):
+		}
+
+		watcher, err := w.watch(ctx, resourceVersion)
+		if err == nil {
+			metrics.WatcherReconnectsTotal.Inc()
+			w.logger.Info("EventsV1 watcher re-established",
+				"attempt", attempt,
+				"resourceVersion", resourceVersion)
+			return watcher, resourceVersion
+		}
+
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			w.logger.Info("Resource version expired, resuming watch from current state",
+				"resourceVersion", resourceVersion)
+			resourceVersion = ""
+		} else {
+			w.logger.Error(err, "Failed to re-establish event watcher", "attempt", attempt, "retryIn", backoff)
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
+
+// consume forwards events from watcher until it closes or the watcher stops. It returns the
+// last resourceVersion seen and whether the watcher stopped, as opposed to the watch closing.
+func (w *Watcher) consume(ctx context.Context, watcher watch.Interface, resourceVersion string) (string, bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Context cancelled, stopping event watcher")
+			return resourceVersion, true
+		case <-w.stopCh:
+			w.logger.Info("Stop signal received, stopping event watcher")
+			return resourceVersion, true
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				w.logger.Info("Event watcher channel closed, reconnecting", "resourceVersion", resourceVersion)
+				return resourceVersion, false
+			}
+
+			if event.Type == watch.Error {
+				err := apierrors.FromObject(event.Object)
+				w.logger.Info("Event watcher returned an error, reconnecting", "error", err.Error())
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					resourceVersion = ""
 				}
+				return resourceVersion, false
+			}
 
-				if event.Type == watch.Added || event.Type == watch.Modified {
-					if k8sEvent, ok := event.Object.(*eventsv1.Event); ok {
-						w.logger.V(2).Info("Received Kubernetes event",
-							"watchType", event.Type,
-							"namespace", k8sEvent.Namespace,
-							"regarding.kind", k8sEvent.Regarding.Kind,
-							"regarding.name", k8sEvent.Regarding.Name,
-							"reason", k8sEvent.Reason,
-							"type", k8sEvent.Type,
-							"note", k8sEvent.Note,
-							"series.count", func() int32 {
-								if k8sEvent.Series != nil {
-									return k8sEvent.Series.Count
-								}
-								return 0
-							}())
-
-						// Staleness filter: ignore events older than 15 minutes without recent occurrence
-						cutoff := time.Now().Add(-15 * time.Minute)
-						lastTime := k8sEvent.CreationTimestamp.Time
-						if !k8sEvent.EventTime.IsZero() {
-							lastTime = k8sEvent.EventTime.Time
-						}
-						if k8sEvent.Series != nil && !k8sEvent.Series.LastObservedTime.IsZero() {
-							lastTime = k8sEvent.Series.LastObservedTime.Time
-						}
-						if lastTime.Before(cutoff) {
-							w.logger.V(1).Info("Ignoring stale event (>15m)",
-								"namespace", k8sEvent.Namespace,
-								"regarding.name", k8sEvent.Regarding.Name,
-								"reason", k8sEvent.Reason,
-								"lastTime", lastTime)
-							continue
-						}
-
-						if mappedEvent := w.mapKubernetesEvent(k8sEvent); mappedEvent != nil {
-							w.logger.Info("Discovered interesting event",
-								"eventType", mappedEvent.Type,
-								"resource", mappedEvent.ResourceName,
-								"reason", mappedEvent.Reason,
-								"namespace", mappedEvent.Namespace)
-							select {
-							case w.eventCh <- *mappedEvent:
-								w.logger.V(2).Info("Queued event for processing",
-									"eventType", mappedEvent.Type,
-									"resource", mappedEvent.ResourceName)
-							case <-ctx.Done():
-								return
-							case <-w.stopCh:
-								return
-							}
-						} else {
-							w.logger.V(3).Info("Ignoring event (no mapping)",
-								"namespace", k8sEvent.Namespace,
-								"regarding.kind", k8sEvent.Regarding.Kind,
-								"regarding.name", k8sEvent.Regarding.Name,
-								"reason", k8sEvent.Reason,
-								"type", k8sEvent.Type)
-						}
+			if event.Type == watch.Added || event.Type == watch.Modified {
+				if k8sEvent, ok := event.Object.(*eventsv1.Event); ok {
+					if k8sEvent.ResourceVersion != "" {
+						resourceVersion = k8sEvent.ResourceVersion
+					}
+					if !w.handleEvent(ctx, event.Type, k8sEvent) {
+						return resourceVersion, true
 					}
 				}
 			}
 		}
-	}()
+	}
+}
 
-	return nil
+// handleEvent maps a Kubernetes event and queues it for processing. It returns false when
+// the watcher stopped while queueing.
+func (w *Watcher) handleEvent(ctx context.Context, watchType watch.EventType, k8sEvent *eventsv1.Event) bool {
+	if w.isExcluded(k8sEvent.Namespace) {
+		w.logger.V(3).Info("Ignoring event from excluded namespace",
+			"namespace", k8sEvent.Namespace,
+			"regarding.name", k8sEvent.Regarding.Name,
+			"reason", k8sEvent.Reason)
+		return true
+	}
+
+	metrics.WatcherEventsReceivedTotal.WithLabelValues(k8sEvent.Namespace).Inc()
+	w.logger.V(2).Info("Received Kubernetes event",
+		"watchType", watchType,
+		"namespace", k8sEvent.Namespace,
+		"regarding.kind", k8sEvent.Regarding.Kind,
+		"regarding.name", k8sEvent.Regarding.Name,
+		"reason", k8sEvent.Reason,
+		"type", k8sEvent.Type,
+		"note", k8sEvent.Note,
+		"series.count", eventCount(k8sEvent))
+
+	// Staleness filter: ignore events without a recent occurrence
+	if lastTime, stale := w.isStale(k8sEvent, time.Now()); stale {
+		metrics.WatcherEventsStaleTotal.WithLabelValues(k8sEvent.Namespace).Inc()
+		w.logger.V(1).Info("Ignoring stale event",
+			"namespace", k8sEvent.Namespace,
+			"regarding.name", k8sEvent.Regarding.Name,
+			"reason", k8sEvent.Reason,
+			"lastTime", lastTime,
+			"stalenessWindow", w.stalenessWindow)
+		return true
+	}
+
+	// Skip event versions already queued, which a watch re-list delivers again as Added
+	uid, resourceVersion := string(k8sEvent.UID), k8sEvent.ResourceVersion
+	if w.emitted.seen(uid, resourceVersion, time.Now()) {
+		metrics.WatcherEventsRedeliveredTotal.WithLabelValues(k8sEvent.Namespace).Inc()
+		w.logger.V(1).Info("Ignoring already emitted event",
+			"namespace", k8sEvent.Namespace,
+			"regarding.name", k8sEvent.Regarding.Name,
+			"reason", k8sEvent.Reason,
+			"resourceVersion", resourceVersion)
+		return true
+	}
+
+	mappedEvent := w.mapKubernetesEvent(k8sEvent)
+	if mappedEvent == nil {
+		w.logger.V(3).Info("Ignoring event (no mapping)",
+			"namespace", k8sEvent.Namespace,
+			"regarding.kind", k8sEvent.Regarding.Kind,
+			"regarding.name", k8sEvent.Regarding.Name,
+			"reason", k8sEvent.Reason,
+			"type", k8sEvent.Type)
+		return true
+	}
+
+	w.enrichOwner(ctx, mappedEvent)
+
+	metrics.WatcherEventsMappedTotal.WithLabelValues(mappedEvent.Type).Inc()
+	w.logger.Info("Discovered interesting event",
+		"eventType", mappedEvent.Type,
+		"resource", mappedEvent.ResourceName,
+		"reason", mappedEvent.Reason,
+		"namespace", mappedEvent.Namespace)
+	select {
+	case w.eventCh <- *mappedEvent:
+		w.emitted.add(uid, resourceVersion, time.Now())
+		w.logger.V(2).Info("Queued event for processing",
+			"eventType", mappedEvent.Type,
+			"resource", mappedEvent.ResourceName)
+		return true
+	case <-ctx.Done():
+		metrics.WatcherEventsDroppedTotal.WithLabelValues(mappedEvent.Type).Inc()
+		return false
+	case <-w.stopCh:
+		metrics.WatcherEventsDroppedTotal.WithLabelValues(mappedEvent.Type).Inc()
+		return false
+	}
+}
+
+// eventCount returns how often Kubernetes has observed the event: the series count of
+// events.k8s.io events, the deprecated count of events converted from core/v1, or 1
+func eventCount(k8sEvent *eventsv1.Event) int32 {
+	if k8sEvent.Series != nil && k8sEvent.Series.Count > 0 {
+		return k8sEvent.Series.Count
+	}
+	if k8sEvent.DeprecatedCount > 0 {
+		return k8sEvent.DeprecatedCount
+	}
+	return 1
+}
+
+// isStale reports whether the event's last occurrence is older than the staleness window
+// at now. It also returns that last occurrence time. Events exactly at the cutoff are kept.
+func (w *Watcher) isStale(k8sEvent *eventsv1.Event, now time.Time) (time.Time, bool) {
+	lastTime := k8sEvent.CreationTimestamp.Time
+	if !k8sEvent.EventTime.IsZero() {
+		lastTime = k8sEvent.EventTime.Time
+	}
+	if k8sEvent.Series != nil && !k8sEvent.Series.LastObservedTime.IsZero() {
+		lastTime = k8sEvent.Series.LastObservedTime.Time
+	}
+	return lastTime, lastTime.Before(now.Add(-w.stalenessWindow))
 }
 
 // Stop gracefully stops the event watcher
@@ -196,6 +522,15 @@ func (w *Watcher) FilterEvent(event interfaces.Event, hooks []*v1alpha2.Hook) []
 
 // mapKubernetesEvent converts a Kubernetes event to our internal Event type
 func (w *Watcher) mapKubernetesEvent(k8sEvent *eventsv1.Event) *interfaces.Event {
+	if _, excluded := w.excludedControllers[k8sEvent.ReportingController]; excluded {
+		w.logger.V(3).Info("Event from excluded reporting controller ignored",
+			"namespace", k8sEvent.Namespace,
+			"regarding.name", k8sEvent.Regarding.Name,
+			"reason", k8sEvent.Reason,
+			"reportingController", k8sEvent.ReportingController)
+		return nil
+	}
+
 	eventType := w.mapEventType(k8sEvent)
 	if eventType == "" {
 		// This event type is not one we're interested in
@@ -215,12 +550,6 @@ func (w *Watcher) mapKubernetesEvent(k8sEvent *eventsv1.Event) *interfaces.Event
 		timestamp = k8sEvent.EventTime.Time
 	}
 
-	// Handle deprecated fields for backward compatibility
-	count := "1"
-	if k8sEvent.DeprecatedCount != 0 {
-		count = fmt.Sprintf("%d", k8sEvent.DeprecatedCount)
-	}
-
 	event := &interfaces.Event{
 		Type:         eventType,
 		ResourceName: k8sEvent.Regarding.Name,
@@ -229,16 +558,26 @@ func (w *Watcher) mapKubernetesEvent(k8sEvent *eventsv1.Event) *interfaces.Event
 		Reason:       k8sEvent.Reason,
 		Message:      k8sEvent.Note,
 		UID:          string(k8sEvent.UID),
+		ReceivedAt:   time.Now(),
 		Metadata: map[string]string{
 			"kind":                k8sEvent.Regarding.Kind,
 			"apiVersion":          k8sEvent.Regarding.APIVersion,
-			"count":               count,
+			"count":               strconv.Itoa(int(eventCount(k8sEvent))),
 			"type":                k8sEvent.Type,
 			"reportingController": k8sEvent.ReportingController,
 			"reportingInstance":   k8sEvent.ReportingInstance,
 		},
 	}
 
+	if strings.EqualFold(k8sEvent.Reason, "FailedScheduling") {
+		event.Metadata["schedulingReason"] = categorizeSchedulingFailure(k8sEvent.Note)
+	}
+	if eventType == "probe-failed" {
+		if probeType := probeTypeOf(k8sEvent.Note); probeType != "" {
+			event.Metadata["probeType"] = probeType
+		}
+	}
+
 	w.logger.V(1).Info("Mapped Kubernetes event",
 		"eventType", event.Type,
 		"resource", event.ResourceName,
@@ -249,19 +588,57 @@ func (w *Watcher) mapKubernetesEvent(k8sEvent *eventsv1.Event) *interfaces.Event
 	return event
 }
 
+// ignoresNormalEvents reports whether Normal events about kind are dropped
+func (w *Watcher) ignoresNormalEvents(kind string) bool {
+	if ignore, ok := w.ignoreNormalByKind[kind]; ok {
+		return ignore
+	}
+	if w.ignoreNormal != nil {
+		return *w.ignoreNormal
+	}
+	if ignore, ok := defaultIgnoreNormalEventsByKind[kind]; ok {
+		return ignore
+	}
+	return true
+}
+
 // mapEventType maps Kubernetes event reasons to our event types
 func (w *Watcher) mapEventType(k8sEvent *eventsv1.Event) string {
-	// Ignore Normal events entirely; only act on warnings/errors
-	if strings.ToLower(k8sEvent.Type) == "normal" {
-		return ""
+	if strings.EqualFold(k8sEvent.Type, corev1.EventTypeNormal) {
+		// Container starts are Normal events that map whenever they are opted in
+		if k8sEvent.Regarding.Kind == "Pod" && w.containerStarted && isContainerStarted(k8sEvent) {
+			return "container-started"
+		}
+		if w.ignoresNormalEvents(k8sEvent.Regarding.Kind) {
+			return ""
+		}
 	}
+
 	// Map based on the regarding object kind and event reason
 	switch k8sEvent.Regarding.Kind {
 	case "Pod":
 		return w.mapPodEventType(k8sEvent)
+	case "Deployment":
+		return w.mapDeploymentEventType(k8sEvent)
+	case "Node":
+		return w.mapNodeEventType(k8sEvent)
 	default:
+		return w.mapConfiguredKindEventType(k8sEvent)
+	}
+}
+
+// mapConfiguredKindEventType maps events for kinds configured through WithEventTypeKinds,
+// returning only event types enabled for that kind
+func (w *Watcher) mapConfiguredKindEventType(k8sEvent *eventsv1.Event) string {
+	eventTypes, ok := w.kindEventTypes[k8sEvent.Regarding.Kind]
+	if !ok {
+		return ""
+	}
+	eventType := w.mapPodEventType(k8sEvent)
+	if _, ok := eventTypes[eventType]; !ok {
 		return ""
 	}
+	return eventType
 }
 
 // mapPodEventType maps pod-related events to our event types
@@ -317,3 +694,85 @@ func (w *Watcher) mapPodEventType(k8sEvent *eventsv1.Event) string {
 
 	return ""
 }
+
+// probeTypeOf returns the kind of probe (liveness, readiness or startup) that a probe
+// failure note reports, or an empty string when the note does not name one
+func probeTypeOf(note string) string {
+	note = strings.ToLower(note)
+	for _, probeType := range []string{"liveness", "readiness", "startup"} {
+		if strings.Contains(note, probeType) {
+			return probeType
+		}
+	}
+	return ""
+}
+
+// isContainerStarted reports whether the event records a container starting
+func isContainerStarted(k8sEvent *eventsv1.Event) bool {
+	return strings.EqualFold(k8sEvent.Reason, "Started") &&
+		strings.Contains(strings.ToLower(k8sEvent.Note), "container")
+}
+
+// mapDeploymentEventType maps deployment-related events to our event types.
+// Scaling is reported as a Normal event, so Normal deployment events are mapped by default.
+func (w *Watcher) mapDeploymentEventType(k8sEvent *eventsv1.Event) string {
+	reason := strings.ToLower(k8sEvent.Reason)
+	message := strings.ToLower(k8sEvent.Note)
+	eventType := strings.ToLower(k8sEvent.Type)
+
+	switch {
+	case reason == "scalingreplicaset":
+		return "deployment-scaled"
+	case reason == "deploymentrollbackfailed" || reason == "progressdeadlineexceeded" ||
+		reason == "minimumreplicasunavailable":
+		return "deployment-unavailable"
+	case eventType == "warning" && strings.Contains(message, "unavailable"):
+		return "deployment-unavailable"
+	default:
+		return ""
+	}
+}
+
+// mapNodeEventType maps node-related events to our event types.
+// Cordoning and draining are reported as Normal events, so Normal node events are mapped by default.
+func (w *Watcher) mapNodeEventType(k8sEvent *eventsv1.Event) string {
+	switch strings.ToLower(k8sEvent.Reason) {
+	case "nodenotschedulable":
+		// Emitted by the node lifecycle controller when a node is cordoned;
+		// NodeSchedulable reports the node being uncordoned and is ignored
+		return "node-cordoned"
+	case "draining", "nodedraining", "drained", "nodedrained":
+		return "node-drained"
+	default:
+		return ""
+	}
+}
+
+// schedulingFailureCategories maps FailedScheduling message fragments to a category.
+// Entries are checked in order, so the first matching category wins for messages
+// that report several reasons.
+var schedulingFailureCategories = []struct {
+	category  string
+	fragments []string
+}{
+	{category: "insufficient-resources", fragments: []string{"insufficient ", "too many pods"}},
+	{category: "taints", fragments: []string{"taint"}},
+	{category: "volume", fragments: []string{"persistentvolumeclaim", "volume node affinity conflict", "volume"}},
+	{category: "affinity", fragments: []string{"affinity", "node selector", "didn't match pod topology spread constraints"}},
+	{category: "ports", fragments: []string{"free ports"}},
+	{category: "unschedulable", fragments: []string{"unschedulable"}},
+}
+
+// categorizeSchedulingFailure returns the category of a FailedScheduling event message,
+// or "other" when it does not match a known category
+func categorizeSchedulingFailure(message string) string {
+	message = strings.ToLower(message)
+	for _, c := range schedulingFailureCategories {
+		for _, fragment := range c.fragments {
+			if strings.Contains(message, fragment) {
+				return c.category
+			}
+		}
+	}
+	return "other"
+}
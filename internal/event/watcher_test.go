@@ -2,18 +2,25 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"github.com/kagent-dev/khook/api/v1alpha2"
 	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/metrics"
 )
 
 func TestMapEventType(t *testing.T) {
@@ -64,6 +71,56 @@ func TestMapEventType(t *testing.T) {
 			},
 			expected: "probe-failed",
 		},
+		{
+			name: "normal pod event ignored",
+			event: &eventsv1.Event{
+				Regarding: corev1.ObjectReference{Kind: "Pod"},
+				Reason:    "BackOff",
+				Note:      "Back-off restarting failed container",
+				Type:      "Normal",
+			},
+			expected: "",
+		},
+		{
+			name: "deployment scaled",
+			event: &eventsv1.Event{
+				Regarding: corev1.ObjectReference{Kind: "Deployment"},
+				Reason:    "ScalingReplicaSet",
+				Note:      "Scaled up replica set web-7d9f to 3",
+				Type:      "Normal",
+			},
+			expected: "deployment-scaled",
+		},
+		{
+			name: "deployment rollback failed",
+			event: &eventsv1.Event{
+				Regarding: corev1.ObjectReference{Kind: "Deployment"},
+				Reason:    "DeploymentRollbackFailed",
+				Note:      "Rollback to revision 2 failed",
+				Type:      "Warning",
+			},
+			expected: "deployment-unavailable",
+		},
+		{
+			name: "deployment unavailable warning",
+			event: &eventsv1.Event{
+				Regarding: corev1.ObjectReference{Kind: "Deployment"},
+				Reason:    "FailedRollout",
+				Note:      "Deployment does not have minimum availability: replicas unavailable",
+				Type:      "Warning",
+			},
+			expected: "deployment-unavailable",
+		},
+		{
+			name: "unrelated deployment event",
+			event: &eventsv1.Event{
+				Regarding: corev1.ObjectReference{Kind: "Deployment"},
+				Reason:    "DeploymentRollback",
+				Note:      "Rolled back deployment web to revision 2",
+				Type:      "Normal",
+			},
+			expected: "",
+		},
 		{
 			name: "unrelated event",
 			event: &eventsv1.Event{
@@ -124,6 +181,163 @@ func TestMapKubernetesEvent(t *testing.T) {
 	assert.Equal(t, "node1", result.Metadata["reportingInstance"])
 }
 
+func TestMapEventType_ContainerStarted(t *testing.T) {
+	started := &eventsv1.Event{
+		Regarding: corev1.ObjectReference{Kind: "Pod"},
+		Reason:    "Started",
+		Note:      "Started container app",
+		Type:      "Normal",
+	}
+	pulled := &eventsv1.Event{
+		Regarding: corev1.ObjectReference{Kind: "Pod"},
+		Reason:    "Pulled",
+		Note:      "Container image already present on machine",
+		Type:      "Normal",
+	}
+
+	disabled := &Watcher{}
+	assert.Equal(t, "", disabled.mapEventType(started))
+
+	enabled := &Watcher{}
+	WithContainerStartedEvents()(enabled)
+	assert.Equal(t, "container-started", enabled.mapEventType(started))
+	assert.Equal(t, "", enabled.mapEventType(pulled))
+}
+
+func TestMapEventType_ConfiguredKinds(t *testing.T) {
+	watcher := &Watcher{}
+	WithEventTypeKinds(map[string][]string{"oom-kill": {"SandboxWorkload"}})(watcher)
+
+	oomEvent := func(kind string) *eventsv1.Event {
+		return &eventsv1.Event{
+			Regarding: corev1.ObjectReference{Kind: kind},
+			Reason:    "OOMKilling",
+			Note:      "Memory cgroup out of memory: Killed process",
+			Type:      "Warning",
+		}
+	}
+
+	assert.Equal(t, "oom-kill", watcher.mapEventType(oomEvent("SandboxWorkload")))
+	assert.Equal(t, "", watcher.mapEventType(oomEvent("StatefulSet")))
+
+	// Only the configured event types map for the custom kind
+	assert.Equal(t, "", watcher.mapEventType(&eventsv1.Event{
+		Regarding: corev1.ObjectReference{Kind: "SandboxWorkload"},
+		Reason:    "BackOff",
+		Note:      "Back-off restarting failed container",
+		Type:      "Warning",
+	}))
+
+	// Pods keep their default mapping
+	assert.Equal(t, "oom-kill", watcher.mapEventType(oomEvent("Pod")))
+}
+
+func TestMapEventType_Node(t *testing.T) {
+	watcher := &Watcher{}
+
+	tests := []struct {
+		name      string
+		reason    string
+		eventType string
+		expected  string
+	}{
+		{name: "cordoned", reason: "NodeNotSchedulable", eventType: "Normal", expected: "node-cordoned"},
+		{name: "uncordoned", reason: "NodeSchedulable", eventType: "Normal", expected: ""},
+		{name: "draining", reason: "Draining", eventType: "Normal", expected: "node-drained"},
+		{name: "node draining", reason: "NodeDraining", eventType: "Warning", expected: "node-drained"},
+		{name: "drained", reason: "Drained", eventType: "Normal", expected: "node-drained"},
+		{name: "node drained", reason: "NodeDrained", eventType: "Normal", expected: "node-drained"},
+		{name: "unrelated node event", reason: "RegisteredNode", eventType: "Normal", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &eventsv1.Event{
+				Regarding: corev1.ObjectReference{Kind: "Node", Name: "worker-1"},
+				Reason:    tt.reason,
+				Type:      tt.eventType,
+			}
+			assert.Equal(t, tt.expected, watcher.mapEventType(event))
+		})
+	}
+}
+
+func TestMapEventType_NormalEventPolicy(t *testing.T) {
+	cordoned := &eventsv1.Event{
+		Regarding: corev1.ObjectReference{Kind: "Node", Name: "worker-1"},
+		Reason:    "NodeNotSchedulable",
+		Type:      "Normal",
+	}
+	normalOOM := &eventsv1.Event{
+		Regarding: corev1.ObjectReference{Kind: "Pod", Name: "web-0"},
+		Reason:    "OOMKilling",
+		Note:      "Memory cgroup out of memory: Killed process",
+		Type:      "Normal",
+	}
+	started := &eventsv1.Event{
+		Regarding: corev1.ObjectReference{Kind: "Pod", Name: "web-0"},
+		Reason:    "Started",
+		Note:      "Started container app",
+		Type:      "Normal",
+	}
+
+	tests := []struct {
+		name            string
+		opts            []WatcherOption
+		expectedNode    string
+		expectedPod     string
+		expectedStarted string
+	}{
+		{name: "defaults map Normal node events only", expectedNode: "node-cordoned", expectedPod: ""},
+		{
+			name:         "ignoring Normal events excludes node events",
+			opts:         []WatcherOption{WithIgnoreNormalEvents(true)},
+			expectedNode: "",
+			expectedPod:  "",
+		},
+		{
+			name:         "per-kind override excludes node events",
+			opts:         []WatcherOption{WithIgnoreNormalEventsByKind(map[string]bool{"Node": true})},
+			expectedNode: "",
+			expectedPod:  "",
+		},
+		{
+			name:         "mapping Normal events includes pod events",
+			opts:         []WatcherOption{WithIgnoreNormalEvents(false)},
+			expectedNode: "node-cordoned",
+			expectedPod:  "oom-kill",
+		},
+		{
+			name: "per-kind override wins over the global policy",
+			opts: []WatcherOption{
+				WithIgnoreNormalEventsByKind(map[string]bool{"Node": false}),
+				WithIgnoreNormalEvents(true),
+			},
+			expectedNode: "node-cordoned",
+			expectedPod:  "",
+		},
+		{
+			name:            "opted-in container starts map while Normal events are ignored",
+			opts:            []WatcherOption{WithIgnoreNormalEvents(true), WithContainerStartedEvents()},
+			expectedNode:    "",
+			expectedPod:     "",
+			expectedStarted: "container-started",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher := &Watcher{}
+			for _, opt := range tt.opts {
+				opt(watcher)
+			}
+			assert.Equal(t, tt.expectedNode, watcher.mapEventType(cordoned))
+			assert.Equal(t, tt.expectedPod, watcher.mapEventType(normalOOM))
+			assert.Equal(t, tt.expectedStarted, watcher.mapEventType(started))
+		})
+	}
+}
+
 func TestFilterEvent(t *testing.T) {
 	watcher := &Watcher{}
 
@@ -172,3 +386,552 @@ func TestWatcherStartStop(t *testing.T) {
 	err = watcher.Stop()
 	assert.NoError(t, err)
 }
+
+func TestNewWatcherOptions(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	watcher := NewWatcher(client, "test-namespace", WatchAllNamespaces(), WithExcludedNamespaces("kube-system"))
+	w, ok := watcher.(*Watcher)
+	require.True(t, ok)
+	assert.Equal(t, metav1.NamespaceAll, w.namespace)
+	assert.True(t, w.isExcluded("kube-system"))
+	assert.False(t, w.isExcluded("production"))
+	assert.Equal(t, DefaultEventBufferSize, cap(w.eventCh))
+
+	w = NewWatcher(client, "test-namespace", WithEventBufferSize(1000)).(*Watcher)
+	assert.Equal(t, 1000, cap(w.eventCh))
+}
+
+func TestIsStale(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	eventAt := func(ts time.Time) *eventsv1.Event {
+		return &eventsv1.Event{EventTime: metav1.NewMicroTime(ts)}
+	}
+
+	defaultWatcher := NewWatcher(fake.NewSimpleClientset(), "test-namespace").(*Watcher)
+	assert.Equal(t, DefaultStalenessWindow, defaultWatcher.stalenessWindow)
+
+	tests := []struct {
+		name   string
+		window time.Duration
+		event  *eventsv1.Event
+		stale  bool
+	}{
+		{name: "recent event", window: DefaultStalenessWindow, event: eventAt(now.Add(-time.Minute)), stale: false},
+		{name: "exactly at cutoff", window: DefaultStalenessWindow, event: eventAt(now.Add(-DefaultStalenessWindow)), stale: false},
+		{name: "just past cutoff", window: DefaultStalenessWindow, event: eventAt(now.Add(-DefaultStalenessWindow - time.Microsecond)), stale: true},
+		{name: "custom window keeps older event", window: time.Hour, event: eventAt(now.Add(-30 * time.Minute)), stale: false},
+		{name: "custom window exactly at cutoff", window: time.Hour, event: eventAt(now.Add(-time.Hour)), stale: false},
+		{name: "stricter window drops event", window: time.Minute, event: eventAt(now.Add(-2 * time.Minute)), stale: true},
+		{
+			name:   "recent series occurrence",
+			window: DefaultStalenessWindow,
+			event: &eventsv1.Event{
+				EventTime: metav1.NewMicroTime(now.Add(-time.Hour)),
+				Series:    &eventsv1.EventSeries{Count: 5, LastObservedTime: metav1.NewMicroTime(now.Add(-time.Minute))},
+			},
+			stale: false,
+		},
+		{
+			name:   "creation timestamp fallback",
+			window: DefaultStalenessWindow,
+			event:  &eventsv1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))}},
+			stale:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher := NewWatcher(fake.NewSimpleClientset(), "test-namespace", WithStalenessWindow(tt.window)).(*Watcher)
+			_, stale := watcher.isStale(tt.event, now)
+			assert.Equal(t, tt.stale, stale)
+		})
+	}
+}
+
+func TestWatcherReconnectsAfterWatchCloses(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	watches := make(chan *watch.FakeWatcher, 2)
+	resourceVersions := make(chan string, 2)
+	client.PrependWatchReactor("events", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		resourceVersions <- action.(k8stesting.WatchAction).GetWatchRestrictions().ResourceVersion
+		fw := watch.NewFakeWithChanSize(1, false)
+		watches <- fw
+		return true, fw, nil
+	})
+
+	watcher := NewWatcher(client, "test-namespace").(*Watcher)
+	watcher.reconnectBackoff = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	eventCh, err := watcher.WatchEvents(ctx)
+	require.NoError(t, err)
+
+	restartEvent := func(name, resourceVersion string) runtime.Object {
+		return &eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace", ResourceVersion: resourceVersion},
+			Regarding:  corev1.ObjectReference{Kind: "Pod", Name: name},
+			Reason:     "BackOff",
+			Note:       "Back-off restarting failed container",
+			Type:       "Warning",
+			EventTime:  metav1.NewMicroTime(time.Now()),
+		}
+	}
+
+	first := <-watches
+	assert.Equal(t, "", <-resourceVersions)
+	first.Add(restartEvent("before", "41"))
+	select {
+	case event := <-eventCh:
+		assert.Equal(t, "before", event.ResourceName)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for event before reconnect")
+	}
+
+	// Closing the watch, as an API server restart would, must not stop event delivery
+	first.Stop()
+
+	var second *watch.FakeWatcher
+	select {
+	case second = <-watches:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for watch to be re-established")
+	}
+	assert.Equal(t, "41", <-resourceVersions, "watch should resume from the last seen resourceVersion")
+
+	second.Add(restartEvent("after", "42"))
+	select {
+	case event := <-eventCh:
+		assert.Equal(t, "after", event.ResourceName)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for event after reconnect")
+	}
+
+	require.NoError(t, watcher.Stop())
+}
+
+func TestWatcherAllNamespacesHonorsExcludeList(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	watcher := NewWatcher(client, "", WatchAllNamespaces(), WithExcludedNamespaces("kube-system"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	eventCh, err := watcher.WatchEvents(ctx)
+	require.NoError(t, err)
+
+	for _, namespace := range []string{"kube-system", "team-a", "team-b"} {
+		_, err := client.EventsV1().Events(namespace).Create(ctx, &eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "restart-" + namespace, Namespace: namespace},
+			Regarding:  corev1.ObjectReference{Kind: "Pod", Name: "app"},
+			Reason:     "BackOff",
+			Note:       "Back-off restarting failed container",
+			Type:       "Warning",
+			EventTime:  metav1.NewMicroTime(time.Now()),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	var namespaces []string
+	for len(namespaces) < 2 {
+		select {
+		case event := <-eventCh:
+			namespaces = append(namespaces, event.Namespace)
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for events, received %v", namespaces)
+		}
+	}
+	assert.ElementsMatch(t, []string{"team-a", "team-b"}, namespaces)
+
+	// The excluded namespace's event, created first, must never be delivered
+	select {
+	case event := <-eventCh:
+		t.Fatalf("unexpected event from namespace %s", event.Namespace)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, watcher.Stop())
+}
+
+func TestWatcherPodDeletions(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	podWatches := make(chan *watch.FakeWatcher, 1)
+	client.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+		fw := watch.NewFakeWithChanSize(2, false)
+		podWatches <- fw
+		return true, fw, nil
+	})
+	watcher := NewWatcher(client, "test-namespace", WithPodDeletions())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	eventCh, err := watcher.WatchEvents(ctx)
+	require.NoError(t, err)
+
+	var podWatch *watch.FakeWatcher
+	select {
+	case podWatch = <-podWatches:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the pod watch")
+	}
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "crashing", Namespace: "test-namespace", UID: "pod-uid"}}
+	podWatch.Modify(pod)
+	podWatch.Delete(pod)
+
+	select {
+	case event := <-eventCh:
+		assert.Equal(t, interfaces.ResourceDeletedEventType, event.Type)
+		assert.Equal(t, "crashing", event.ResourceName)
+		assert.Equal(t, "test-namespace", event.Namespace)
+		assert.Equal(t, "Pod", event.Metadata["kind"])
+		assert.Equal(t, "pod-uid", event.UID)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for pod deletion")
+	}
+
+	// The event channel closes only after both watches stopped
+	require.NoError(t, watcher.Stop())
+	for range eventCh {
+	}
+}
+
+func TestCategorizeSchedulingFailure(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected string
+	}{
+		{"0/3 nodes are available: 3 Insufficient cpu. preemption: 0/3 nodes are available: 3 No preemption victims found for incoming pod.", "insufficient-resources"},
+		{"0/1 nodes are available: 1 Insufficient memory.", "insufficient-resources"},
+		{"0/3 nodes are available: 3 node(s) had untolerated taint {node-role.kubernetes.io/control-plane: }.", "taints"},
+		{"0/5 nodes are available: 5 node(s) didn't match Pod's node affinity/selector.", "affinity"},
+		{"0/4 nodes are available: 4 node(s) didn't match pod anti-affinity rules.", "affinity"},
+		{"0/2 nodes are available: 2 node(s) had volume node affinity conflict.", "volume"},
+		{"0/1 nodes are available: pod has unbound immediate PersistentVolumeClaims.", "volume"},
+		{"0/2 nodes are available: 2 node(s) didn't have free ports for the requested pod ports.", "ports"},
+		{"0/1 nodes are available: 1 node(s) were unschedulable.", "unschedulable"},
+		{"scheduler is shutting down", "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, categorizeSchedulingFailure(tt.message))
+		})
+	}
+}
+
+func TestMapKubernetesEvent_SchedulingReason(t *testing.T) {
+	watcher := &Watcher{}
+
+	result := watcher.mapKubernetesEvent(&eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+		Regarding:  corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+		Reason:     "FailedScheduling",
+		Note:       "0/3 nodes are available: 3 node(s) had untolerated taint {dedicated: gpu}.",
+		Type:       "Warning",
+	})
+	require.NotNil(t, result)
+	assert.Equal(t, "pod-pending", result.Type)
+	assert.Equal(t, "taints", result.Metadata["schedulingReason"])
+
+	result = watcher.mapKubernetesEvent(&eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+		Regarding:  corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+		Reason:     "BackOff",
+		Note:       "Back-off restarting failed container",
+		Type:       "Warning",
+	})
+	require.NotNil(t, result)
+	assert.NotContains(t, result.Metadata, "schedulingReason")
+}
+
+func TestMapKubernetesEvent_ProbeType(t *testing.T) {
+	watcher := &Watcher{}
+
+	tests := []struct {
+		reason   string
+		note     string
+		expected string
+	}{
+		{"Unhealthy", "Liveness probe failed: HTTP probe failed with statuscode: 500", "liveness"},
+		{"Unhealthy", "Readiness probe failed: Get \"http://10.0.0.1:8080/ready\": context deadline exceeded", "readiness"},
+		{"Unhealthy", "Startup probe failed: dial tcp 10.0.0.1:8080: connect: connection refused", "startup"},
+		{"ProbeWarning", "Probe terminated redirects", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.note, func(t *testing.T) {
+			result := watcher.mapKubernetesEvent(&eventsv1.Event{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace"},
+				Regarding:  corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+				Reason:     tt.reason,
+				Note:       tt.note,
+				Type:       "Warning",
+			})
+			require.NotNil(t, result)
+			assert.Equal(t, "probe-failed", result.Type)
+			if tt.expected == "" {
+				assert.NotContains(t, result.Metadata, "probeType")
+			} else {
+				assert.Equal(t, tt.expected, result.Metadata["probeType"])
+			}
+		})
+	}
+}
+
+func TestMapKubernetesEvent_ExcludedReportingController(t *testing.T) {
+	watcher := NewWatcher(fake.NewSimpleClientset(), "test-namespace",
+		WithExcludedReportingControllers("example.com/noisy-operator")).(*Watcher)
+
+	newEvent := func(controller string) *eventsv1.Event {
+		return &eventsv1.Event{
+			ObjectMeta:          metav1.ObjectMeta{Namespace: "test-namespace"},
+			Regarding:           corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+			Reason:              "BackOff",
+			Note:                "Back-off restarting failed container",
+			Type:                "Warning",
+			ReportingController: controller,
+		}
+	}
+
+	assert.Nil(t, watcher.mapKubernetesEvent(newEvent("example.com/noisy-operator")))
+
+	result := watcher.mapKubernetesEvent(newEvent("kubelet"))
+	require.NotNil(t, result)
+	assert.Equal(t, "pod-restart", result.Type)
+	assert.Equal(t, "kubelet", result.Metadata["reportingController"])
+}
+
+func TestMapKubernetesEvent_Count(t *testing.T) {
+	watcher := &Watcher{}
+
+	tests := []struct {
+		name            string
+		series          *eventsv1.EventSeries
+		deprecatedCount int32
+		expected        string
+	}{
+		{name: "single occurrence", expected: "1"},
+		{name: "series", series: &eventsv1.EventSeries{Count: 50}, expected: "50"},
+		{name: "deprecated count", deprecatedCount: 3, expected: "3"},
+		{name: "series preferred", series: &eventsv1.EventSeries{Count: 7}, deprecatedCount: 2, expected: "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := watcher.mapKubernetesEvent(&eventsv1.Event{
+				ObjectMeta:      metav1.ObjectMeta{Namespace: "test-namespace"},
+				Regarding:       corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+				Reason:          "BackOff",
+				Type:            "Warning",
+				Series:          tt.series,
+				DeprecatedCount: tt.deprecatedCount,
+			})
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.Metadata["count"])
+		})
+	}
+}
+
+func TestHandleEventMetrics(t *testing.T) {
+	const namespace = "watcher-metrics"
+	watcher := NewWatcher(fake.NewSimpleClientset(), namespace).(*Watcher)
+	watcher.eventCh = make(chan interfaces.Event, 1)
+
+	newEvent := func(kind, reason, eventType string, at time.Time) *eventsv1.Event {
+		return &eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "event", Namespace: namespace},
+			Regarding:  corev1.ObjectReference{Kind: kind, Name: "test-resource"},
+			Reason:     reason,
+			Type:       eventType,
+			EventTime:  metav1.NewMicroTime(at),
+		}
+	}
+	mappedBefore := testutil.ToFloat64(metrics.WatcherEventsMappedTotal.WithLabelValues("node-cordoned"))
+	droppedBefore := testutil.ToFloat64(metrics.WatcherEventsDroppedTotal.WithLabelValues("node-cordoned"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.True(t, watcher.handleEvent(ctx, watch.Added, newEvent("Node", "NodeNotSchedulable", "Normal", time.Now())))
+	assert.True(t, watcher.handleEvent(ctx, watch.Added, newEvent("Node", "NodeNotSchedulable", "Normal", time.Now().Add(-time.Hour))))
+	assert.True(t, watcher.handleEvent(ctx, watch.Added, newEvent("Node", "RegisteredNode", "Normal", time.Now())))
+
+	// The buffer is full, so the next event waits until the watcher stops and is dropped
+	cancel()
+	assert.False(t, watcher.handleEvent(ctx, watch.Added, newEvent("Node", "NodeNotSchedulable", "Normal", time.Now())))
+
+	assert.Equal(t, float64(4), testutil.ToFloat64(metrics.WatcherEventsReceivedTotal.WithLabelValues(namespace)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.WatcherEventsStaleTotal.WithLabelValues(namespace)))
+	assert.Equal(t, mappedBefore+2, testutil.ToFloat64(metrics.WatcherEventsMappedTotal.WithLabelValues("node-cordoned")))
+	assert.Equal(t, droppedBefore+1, testutil.ToFloat64(metrics.WatcherEventsDroppedTotal.WithLabelValues("node-cordoned")))
+}
+
+func TestHandleEventSkipsRedeliveredEvents(t *testing.T) {
+	const namespace = "watcher-redelivery"
+	watcher := NewWatcher(fake.NewSimpleClientset(), namespace).(*Watcher)
+	watcher.eventCh = make(chan interfaces.Event, 3)
+
+	newEvent := func(resourceVersion string) *eventsv1.Event {
+		return &eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: "event", Namespace: namespace, UID: "event-uid", ResourceVersion: resourceVersion},
+			Regarding:  corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+			Reason:     "BackOff",
+			Note:       "Back-off restarting failed container",
+			Type:       "Warning",
+			EventTime:  metav1.NewMicroTime(time.Now()),
+		}
+	}
+
+	ctx := context.Background()
+	assert.True(t, watcher.handleEvent(ctx, watch.Added, newEvent("100")))
+	// A re-list delivers the same version again
+	assert.True(t, watcher.handleEvent(ctx, watch.Added, newEvent("100")))
+	// A new occurrence updates the event object
+	assert.True(t, watcher.handleEvent(ctx, watch.Modified, newEvent("101")))
+
+	assert.Len(t, watcher.eventCh, 2)
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.WatcherEventsRedeliveredTotal.WithLabelValues(namespace)))
+}
+
+func TestSeenEvents(t *testing.T) {
+	var seen seenEvents
+	now := time.Now()
+
+	assert.False(t, seen.seen("uid-1", "1", now))
+	seen.add("uid-1", "1", now)
+	assert.True(t, seen.seen("uid-1", "1", now))
+	assert.False(t, seen.seen("uid-1", "2", now))
+	assert.False(t, seen.seen("uid-1", "1", now.Add(seenEventTTL+time.Second)))
+
+	// Objects without a UID or resource version are never considered seen
+	seen.add("", "1", now)
+	assert.False(t, seen.seen("", "1", now))
+
+	// The least recently emitted events are evicted once the cache is full
+	for i := range maxSeenEvents + 1 {
+		seen.add(fmt.Sprintf("uid-%d", i), "1", now)
+	}
+	assert.False(t, seen.seen("uid-0", "1", now))
+	assert.True(t, seen.seen(fmt.Sprintf("uid-%d", maxSeenEvents), "1", now))
+	assert.Len(t, seen.entries, maxSeenEvents)
+}
+
+func TestEnrichOwner(t *testing.T) {
+	controller := true
+	newPod := func(name string, labels map[string]string, owner *metav1.OwnerReference) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace", Labels: labels}}
+		if owner != nil {
+			pod.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return pod
+	}
+	client := fake.NewSimpleClientset(
+		newPod("web-7d9f8b6c5-abcde", map[string]string{"pod-template-hash": "7d9f8b6c5"},
+			&metav1.OwnerReference{Kind: "ReplicaSet", Name: "web-7d9f8b6c5", Controller: &controller}),
+		newPod("db-0", nil, &metav1.OwnerReference{Kind: "StatefulSet", Name: "db", Controller: &controller}),
+		newPod("migrate-x7k2p", nil, &metav1.OwnerReference{Kind: "Job", Name: "migrate", Controller: &controller}),
+		newPod("standalone", nil, nil),
+	)
+	watcher := NewWatcher(client, "test-namespace").(*Watcher)
+
+	tests := []struct {
+		name      string
+		kind      string
+		resource  string
+		ownerKind string
+		ownerName string
+	}{
+		{name: "deployment pod", kind: "Pod", resource: "web-7d9f8b6c5-abcde", ownerKind: "Deployment", ownerName: "web"},
+		{name: "statefulset pod", kind: "Pod", resource: "db-0", ownerKind: "StatefulSet", ownerName: "db"},
+		{name: "job pod", kind: "Pod", resource: "migrate-x7k2p", ownerKind: "Job", ownerName: "migrate"},
+		{name: "pod without controller", kind: "Pod", resource: "standalone"},
+		{name: "deleted pod", kind: "Pod", resource: "gone"},
+		{name: "not a pod", kind: "Deployment", resource: "web"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &interfaces.Event{
+				ResourceName: tt.resource,
+				Namespace:    "test-namespace",
+				Metadata:     map[string]string{"kind": tt.kind},
+			}
+			watcher.enrichOwner(context.Background(), event)
+			assert.Equal(t, tt.ownerKind, event.Metadata["ownerKind"])
+			assert.Equal(t, tt.ownerName, event.Metadata["ownerName"])
+		})
+	}
+
+	// Repeated events for a pod reuse the cached lookup
+	gets := 0
+	client.PrependReactor("get", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+	event := &interfaces.Event{ResourceName: "db-0", Namespace: "test-namespace", Metadata: map[string]string{"kind": "Pod"}}
+	watcher.enrichOwner(context.Background(), event)
+	assert.Equal(t, 0, gets)
+	assert.Equal(t, "StatefulSet", event.Metadata["ownerKind"])
+}
+
+func TestMetadataCacheLookup(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "test-namespace",
+			Labels: map[string]string{"khook.io/watch": "true"}, Annotations: map[string]string{"team": "payments"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace",
+			Labels: map[string]string{"app": "web"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "gpu"}}},
+	)
+	cache := NewMetadataCache(client)
+
+	tests := []struct {
+		name        string
+		kind        string
+		resource    string
+		labels      map[string]string
+		annotations map[string]string
+	}{
+		{name: "pod", kind: "Pod", resource: "web-0",
+			labels: map[string]string{"khook.io/watch": "true"}, annotations: map[string]string{"team": "payments"}},
+		{name: "deployment", kind: "Deployment", resource: "web", labels: map[string]string{"app": "web"}},
+		{name: "node", kind: "Node", resource: "node-1", labels: map[string]string{"pool": "gpu"}},
+		{name: "deleted pod", kind: "Pod", resource: "gone"},
+		{name: "unsupported kind", kind: "Service", resource: "web"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, annotations, err := cache.Lookup(context.Background(), interfaces.Event{
+				ResourceName: tt.resource,
+				Namespace:    "test-namespace",
+				Metadata:     map[string]string{"kind": tt.kind},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.labels, labels)
+			assert.Equal(t, tt.annotations, annotations)
+		})
+	}
+
+	// Repeated events for an object reuse the cached lookup
+	gets := 0
+	client.PrependReactor("get", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+	event := interfaces.Event{ResourceName: "web-0", Namespace: "test-namespace", Metadata: map[string]string{"kind": "Pod"}}
+	labels, _, err := cache.Lookup(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, 0, gets)
+	assert.Equal(t, "true", labels["khook.io/watch"])
+
+	// Failed lookups are reported and not cached
+	client.PrependReactor("get", "deployments", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("forbidden")
+	})
+	event = interfaces.Event{ResourceName: "api", Namespace: "test-namespace", Metadata: map[string]string{"kind": "Deployment"}}
+	_, _, err = cache.Lookup(context.Background(), event)
+	assert.Error(t, err)
+	_, _, err = cache.Lookup(context.Background(), event)
+	assert.Error(t, err)
+}
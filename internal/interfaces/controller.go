@@ -17,6 +17,11 @@ type ControllerManager interface {
 	RemoveHookWatch(hookRef types.NamespacedName) error
 }
 
+// ResourceDeletedEventType is the type of events reporting that the resource named by
+// ResourceName was deleted. They resolve the resource's active events instead of
+// matching hook configurations; Metadata["kind"] holds the resource kind.
+const ResourceDeletedEventType = "resource-deleted"
+
 // Event represents a Kubernetes event with relevant metadata
 type Event struct {
 	Type         string            `json:"type"`
@@ -27,6 +32,9 @@ type Event struct {
 	Message      string            `json:"message"`
 	UID          string            `json:"uid"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
+
+	// ReceivedAt is when the watcher handed the event off for processing
+	ReceivedAt time.Time `json:"-"`
 }
 
 // EventMatch represents a matched event with its corresponding hook configuration
@@ -43,14 +51,24 @@ type EventWatcher interface {
 	Stop() error
 }
 
-// AgentRequest represents a request to the Kagent API
+// AgentRequest represents a request to the Kagent API. A non-empty TaskID continues
+// the agent task identified by a prior response's RequestId instead of starting a new one.
+// Requests sharing a non-empty IdempotencyKey are the same logical request. A positive
+// Timeout replaces the client's default timeout for each attempt to send the request.
 type AgentRequest struct {
-	AgentRef     types.NamespacedName   `json:"agentId"`
-	Prompt       string                 `json:"prompt"`
-	EventName    string                 `json:"eventName"`
-	EventTime    time.Time              `json:"eventTime"`
-	ResourceName string                 `json:"resourceName"`
-	Context      map[string]interface{} `json:"context"`
+	AgentRef       types.NamespacedName   `json:"agentId"`
+	Prompt         string                 `json:"prompt"`
+	EventName      string                 `json:"eventName"`
+	EventTime      time.Time              `json:"eventTime"`
+	ResourceName   string                 `json:"resourceName"`
+	Context        map[string]interface{} `json:"context"`
+	TaskID         string                 `json:"taskId,omitempty"`
+	IdempotencyKey string                 `json:"idempotencyKey,omitempty"`
+	Timeout        time.Duration          `json:"timeout,omitempty"`
+
+	// CoalesceKey identifies calls to the same agent about the same occurrence of an event,
+	// whichever hook makes them
+	CoalesceKey string `json:"coalesceKey,omitempty"`
 }
 
 // AgentResponse represents a response from the Kagent API
@@ -58,6 +76,12 @@ type AgentResponse struct {
 	Success   bool   `json:"success"`
 	Message   string `json:"message"`
 	RequestId string `json:"requestId"`
+
+	// SessionID is the Kagent session handling the request
+	SessionID string `json:"sessionId,omitempty"`
+
+	// Summary is the text of the agent's reply, when it replied before the call returned
+	Summary string `json:"summary,omitempty"`
 }
 
 // KagentClient handles communication with the Kagent platform
@@ -68,13 +92,20 @@ type KagentClient interface {
 
 // ActiveEvent represents an event that is currently being tracked
 type ActiveEvent struct {
-	EventType      string     `json:"eventType"`
-	ResourceName   string     `json:"resourceName"`
-	FirstSeen      time.Time  `json:"firstSeen"`
-	LastSeen       time.Time  `json:"lastSeen"`
-	Status         string     `json:"status"`
-	NotifiedAt     *time.Time `json:"notifiedAt,omitempty"`
-	LastNotifiedAt *time.Time `json:"lastNotifiedAt,omitempty"`
+	EventType       string     `json:"eventType"`
+	ResourceName    string     `json:"resourceName"`
+	Namespace       string     `json:"namespace,omitempty"`
+	Reason          string     `json:"reason,omitempty"`
+	FirstSeen       time.Time  `json:"firstSeen"`
+	LastSeen        time.Time  `json:"lastSeen"`
+	Status          string     `json:"status"`
+	OccurrenceCount int32      `json:"occurrenceCount"`
+	NotifiedAt      *time.Time `json:"notifiedAt,omitempty"`
+	LastNotifiedAt  *time.Time `json:"lastNotifiedAt,omitempty"`
+
+	// SessionID and RemediationSummary describe the agent's handling of the last notification
+	SessionID          string `json:"sessionId,omitempty"`
+	RemediationSummary string `json:"remediationSummary,omitempty"`
 }
 
 // DeduplicationManager implements event deduplication logic with timeout
@@ -83,8 +114,11 @@ type DeduplicationManager interface {
 	RecordEvent(hookRef types.NamespacedName, event Event) error
 	CleanupExpiredEvents(hookRef types.NamespacedName) error
 	GetActiveEvents(hookRef types.NamespacedName) []ActiveEvent
+	GetActiveEvent(hookRef types.NamespacedName, event Event) (ActiveEvent, bool)
 	GetActiveEventsWithStatus(hookRef types.NamespacedName) []ActiveEvent
-	MarkNotified(hookRef types.NamespacedName, event Event)
+	MarkNotified(hookRef types.NamespacedName, event Event, response *AgentResponse)
+	ResolveEvent(hookRef types.NamespacedName, eventType, namespace, resourceName string) bool
+	ConfirmActive(hookRef types.NamespacedName, event Event, minActive time.Duration) bool
 }
 
 // EventRecorder handles Kubernetes event recording
@@ -103,6 +137,10 @@ type StatusManager interface {
 	RecordAgentCallSuccess(ctx context.Context, hook *v1alpha2.Hook, event Event, agentRef types.NamespacedName, requestId string) error
 	RecordAgentCallFailure(ctx context.Context, hook *v1alpha2.Hook, event Event, agentRef types.NamespacedName, err error) error
 	RecordDuplicateEvent(ctx context.Context, hook *v1alpha2.Hook, event Event) error
+	RecordCrossHookDuplicate(ctx context.Context, hook *v1alpha2.Hook, event Event, agentRef, callingHook types.NamespacedName) error
+	RecordRateLimited(ctx context.Context, hook *v1alpha2.Hook, event Event, tripped bool) error
+	RecordHookConflict(ctx context.Context, hook *v1alpha2.Hook, message string) error
+	RecordMissingAgent(ctx context.Context, hook *v1alpha2.Hook, message string) error
 	GetHookStatus(ctx context.Context, hookRef types.NamespacedName) (*v1alpha2.HookStatus, error)
 	LogControllerStartup(ctx context.Context, version string, config map[string]interface{})
 	LogControllerShutdown(ctx context.Context, reason string)
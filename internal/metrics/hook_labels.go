@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	// HookLabelAnnotationPrefix prefixes hook annotations whose values become metric labels,
+	// e.g. metrics.khook.kagent.dev/team
+	HookLabelAnnotationPrefix = "metrics.khook.kagent.dev/"
+
+	// maxHookLabelValueLength truncates long annotation values
+	maxHookLabelValueLength = 63
+
+	// maxHookLabelValues bounds the distinct values per label; later values are reported as overflowLabelValue
+	maxHookLabelValues = 100
+
+	// overflowLabelValue replaces label values beyond maxHookLabelValues
+	overflowLabelValue = "other"
+)
+
+// HookLabels are the per-hook metric labels taken from hook annotations
+type HookLabels struct {
+	Team    string
+	Service string
+}
+
+// hookLabelValues tracks the distinct values seen per label to bound cardinality
+var hookLabelValues = struct {
+	sync.Mutex
+	seen map[string]map[string]struct{}
+}{seen: map[string]map[string]struct{}{}}
+
+// HookLabelsFor returns the metric labels for a hook with the given annotations.
+// Missing annotations yield empty labels.
+func HookLabelsFor(annotations map[string]string) HookLabels {
+	return HookLabels{
+		Team:    boundedLabelValue("team", annotations[HookLabelAnnotationPrefix+"team"]),
+		Service: boundedLabelValue("service", annotations[HookLabelAnnotationPrefix+"service"]),
+	}
+}
+
+// boundedLabelValue sanitizes value and caps the number of distinct values for label
+func boundedLabelValue(label, value string) string {
+	value = sanitizeLabelValue(value)
+	if value == "" {
+		return ""
+	}
+
+	hookLabelValues.Lock()
+	defer hookLabelValues.Unlock()
+
+	seen := hookLabelValues.seen[label]
+	if seen == nil {
+		seen = map[string]struct{}{}
+		hookLabelValues.seen[label] = seen
+	}
+	if _, ok := seen[value]; ok {
+		return value
+	}
+	if len(seen) >= maxHookLabelValues {
+		return overflowLabelValue
+	}
+	seen[value] = struct{}{}
+	return value
+}
+
+// sanitizeLabelValue keeps letters, digits, '-', '_' and '.', replacing anything else
+// with '_', and truncates the result
+func sanitizeLabelValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > maxHookLabelValueLength {
+		value = value[:maxHookLabelValueLength]
+	}
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, value)
+}
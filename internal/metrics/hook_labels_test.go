@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHookLabelsFor(t *testing.T) {
+	labels := HookLabelsFor(map[string]string{
+		HookLabelAnnotationPrefix + "team":    "payments",
+		HookLabelAnnotationPrefix + "service": "checkout api/v2",
+		"unrelated":                           "ignored",
+	})
+	assert.Equal(t, HookLabels{Team: "payments", Service: "checkout_api_v2"}, labels)
+
+	assert.Equal(t, HookLabels{}, HookLabelsFor(nil))
+}
+
+func TestSanitizeLabelValueTruncates(t *testing.T) {
+	long := ""
+	for i := 0; i < 100; i++ {
+		long += "a"
+	}
+	assert.Len(t, sanitizeLabelValue(long), maxHookLabelValueLength)
+}
+
+func TestBoundedLabelValueCapsCardinality(t *testing.T) {
+	for i := 0; i < maxHookLabelValues; i++ {
+		assert.Equal(t, fmt.Sprintf("v%d", i), boundedLabelValue("cardinality-test", fmt.Sprintf("v%d", i)))
+	}
+	assert.Equal(t, overflowLabelValue, boundedLabelValue("cardinality-test", "one-too-many"))
+
+	// Values seen before the cap keep reporting as themselves
+	assert.Equal(t, "v0", boundedLabelValue("cardinality-test", "v0"))
+}
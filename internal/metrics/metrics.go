@@ -0,0 +1,256 @@
+package metrics
+
+import (
+	"time"
+
+	khookerrors "github.com/kagent-dev/khook/internal/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ErrorCategory classifies where in the pipeline a processing error occurred
+type ErrorCategory string
+
+const (
+	// ErrorCategoryAgent indicates a failure calling the Kagent agent
+	ErrorCategoryAgent ErrorCategory = "agent"
+
+	// ErrorCategoryStatus indicates a failure recording hook status or events
+	ErrorCategoryStatus ErrorCategory = "status"
+
+	// ErrorCategoryDedup indicates a failure in the deduplication manager
+	ErrorCategoryDedup ErrorCategory = "dedup"
+
+	// ErrorCategoryNotify indicates a failure delivering an event notification
+	ErrorCategoryNotify ErrorCategory = "notify"
+)
+
+// EventResult is the outcome of processing an event for a hook
+type EventResult string
+
+const (
+	// EventResultSuccess indicates the agent was called successfully
+	EventResultSuccess EventResult = "success"
+
+	// EventResultFailure indicates the event could not be delivered to the agent
+	EventResultFailure EventResult = "failure"
+
+	// EventResultDuplicate indicates the event was suppressed by deduplication
+	EventResultDuplicate EventResult = "duplicate"
+
+	// EventResultSkipped indicates the event was recorded without calling the agent
+	EventResultSkipped EventResult = "skipped"
+
+	// EventResultPending indicates the event is held until it stays active for the
+	// configuration's minimum active duration
+	EventResultPending EventResult = "pending"
+
+	// EventResultRateLimited indicates the hook exceeded its agent call rate limit
+	EventResultRateLimited EventResult = "rate_limited"
+
+	// EventResultAgentUnreachable indicates the agent call failed transiently, e.g. the
+	// Kagent API could not be reached or returned a server error
+	EventResultAgentUnreachable EventResult = "agent_unreachable"
+
+	// EventResultAgentRejected indicates the Kagent API rejected the agent call
+	EventResultAgentRejected EventResult = "agent_rejected"
+
+	// EventResultInvalidConfig indicates the hook configuration could not be acted on
+	EventResultInvalidConfig EventResult = "invalid_config"
+)
+
+var (
+	// EventsProcessedTotal counts processed event matches by event type, hook namespace,
+	// result and the hook's team and service labels
+	EventsProcessedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_events_processed_total",
+			Help: "Total number of processed events by event type, namespace, result, team and service",
+		},
+		[]string{"event_type", "namespace", "result", "team", "service"},
+	)
+
+	// AgentCallDurationSeconds observes the latency of Kagent agent calls
+	AgentCallDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "khook_agent_call_duration_seconds",
+			Help:    "Duration of Kagent agent calls in seconds by event type, result, team and service",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{"event_type", "result", "team", "service"},
+	)
+
+	// EventProcessingDurationSeconds observes, per hook, the time from an event match
+	// entering the pipeline until the agent call completes
+	EventProcessingDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "khook_event_processing_duration_seconds",
+			Help:    "Duration from event receipt to agent response in seconds by hook and result",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{"namespace", "hook", "result"},
+	)
+
+	// DeduplicatedEventsTotal counts events suppressed by deduplication
+	DeduplicatedEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_deduplicated_events_total",
+			Help: "Total number of events suppressed by deduplication by event type and namespace",
+		},
+		[]string{"event_type", "namespace"},
+	)
+
+	// DedupTrackedEvents reports the number of active events tracked for deduplication per hook
+	DedupTrackedEvents = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "khook_dedup_tracked_events",
+			Help: "Number of active events tracked for deduplication by hook",
+		},
+		[]string{"hook"},
+	)
+
+	// DedupEventsInCooldown reports the number of tracked events whose re-notification is suppressed
+	DedupEventsInCooldown = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "khook_dedup_events_in_cooldown",
+			Help: "Number of tracked events within their notification cooldown",
+		},
+	)
+
+	// WatcherEventsReceivedTotal counts Kubernetes events received from the watch
+	WatcherEventsReceivedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_watcher_events_received_total",
+			Help: "Total number of Kubernetes events received from the watch by namespace",
+		},
+		[]string{"namespace"},
+	)
+
+	// WatcherEventsStaleTotal counts received events ignored as stale
+	WatcherEventsStaleTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_watcher_events_stale_total",
+			Help: "Total number of received events ignored because they were older than the staleness window by namespace",
+		},
+		[]string{"namespace"},
+	)
+
+	// WatcherEventsRedeliveredTotal counts received events ignored because the same object
+	// version was already emitted, e.g. when a watch re-list delivers it again
+	WatcherEventsRedeliveredTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_watcher_events_redelivered_total",
+			Help: "Total number of received events ignored because the same event version was already emitted by namespace",
+		},
+		[]string{"namespace"},
+	)
+
+	// WatcherEventsMappedTotal counts received events mapped to a khook event type
+	WatcherEventsMappedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_watcher_events_mapped_total",
+			Help: "Total number of received events mapped to a khook event type by event type",
+		},
+		[]string{"event_type"},
+	)
+
+	// WatcherEventsDroppedTotal counts mapped events discarded because the watcher stopped
+	// while they waited for space in the processing buffer
+	WatcherEventsDroppedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_watcher_events_dropped_total",
+			Help: "Total number of mapped events discarded before processing by event type",
+		},
+		[]string{"event_type"},
+	)
+
+	// WatcherReconnectsTotal counts re-established event watches
+	WatcherReconnectsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "khook_watcher_reconnects_total",
+			Help: "Total number of times the Kubernetes event watch was re-established",
+		},
+	)
+
+	// ProcessingErrorsTotal counts event processing errors by category and hook namespace
+	ProcessingErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "khook_processing_errors_total",
+			Help: "Total number of event processing errors by category and namespace",
+		},
+		[]string{"category", "namespace"},
+	)
+)
+
+func init() {
+	// Register with the controller-runtime registry so metrics are served
+	// on the manager's metrics endpoint
+	ctrlmetrics.Registry.MustRegister(
+		EventsProcessedTotal,
+		AgentCallDurationSeconds,
+		EventProcessingDurationSeconds,
+		DeduplicatedEventsTotal,
+		DedupTrackedEvents,
+		DedupEventsInCooldown,
+		WatcherEventsReceivedTotal,
+		WatcherEventsStaleTotal,
+		WatcherEventsRedeliveredTotal,
+		WatcherEventsMappedTotal,
+		WatcherEventsDroppedTotal,
+		WatcherReconnectsTotal,
+		ProcessingErrorsTotal,
+	)
+}
+
+// ResultForError returns the result for a failed event by the kind of err. Errors of
+// unknown kind are reported as EventResultFailure.
+func ResultForError(err error) EventResult {
+	switch khookerrors.KindOf(err) {
+	case khookerrors.KindAgentUnreachable:
+		return EventResultAgentUnreachable
+	case khookerrors.KindAgentRejected:
+		return EventResultAgentRejected
+	case khookerrors.KindInvalidConfig:
+		return EventResultInvalidConfig
+	case khookerrors.KindRateLimited:
+		return EventResultRateLimited
+	}
+	return EventResultFailure
+}
+
+// RecordProcessingError increments the processing error counter for the given category and namespace
+func RecordProcessingError(category ErrorCategory, namespace string) {
+	ProcessingErrorsTotal.WithLabelValues(string(category), namespace).Inc()
+}
+
+// RecordEventProcessed increments the processed events counter for the given result
+func RecordEventProcessed(eventType, namespace string, result EventResult, labels HookLabels) {
+	EventsProcessedTotal.WithLabelValues(eventType, namespace, string(result), labels.Team, labels.Service).Inc()
+}
+
+// ObserveAgentCall records the duration of an agent call
+func ObserveAgentCall(eventType string, result EventResult, labels HookLabels, duration time.Duration) {
+	AgentCallDurationSeconds.WithLabelValues(eventType, string(result), labels.Team, labels.Service).Observe(duration.Seconds())
+}
+
+// ObserveEventProcessing records the end-to-end processing duration of an event for a hook
+func ObserveEventProcessing(namespace, hook string, result EventResult, duration time.Duration) {
+	EventProcessingDurationSeconds.WithLabelValues(namespace, hook, string(result)).Observe(duration.Seconds())
+}
+
+// RecordDeduplicatedEvent increments the deduplicated events counter and the
+// processed events counter with the duplicate result
+func RecordDeduplicatedEvent(eventType, namespace string, labels HookLabels) {
+	DeduplicatedEventsTotal.WithLabelValues(eventType, namespace).Inc()
+	RecordEventProcessed(eventType, namespace, EventResultDuplicate, labels)
+}
+
+// SetDedupStats replaces the deduplication gauges with the given per-hook event counts
+// (keyed by "namespace/name") and the number of events in notification cooldown
+func SetDedupStats(eventsPerHook map[string]int, eventsInCooldown int) {
+	DedupTrackedEvents.Reset()
+	for hook, count := range eventsPerHook {
+		DedupTrackedEvents.WithLabelValues(hook).Set(float64(count))
+	}
+	DedupEventsInCooldown.Set(float64(eventsInCooldown))
+}
@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Sink types accepted by NewSink
+const (
+	SinkTypeWebhook = "webhook"
+	SinkTypeSlack   = "slack"
+	SinkTypeTeams   = "teams"
+)
+
+// Sink receives every event that starts firing for a hook
+type Sink interface {
+	Notify(ctx context.Context, event FiringEvent) error
+}
+
+// NewSink creates a sink of sinkType posting to url. Webhook sinks receive the event as
+// JSON; Slack and Teams sinks receive a formatted message.
+func NewSink(sinkType, url string) (Sink, error) {
+	switch sinkType {
+	case SinkTypeWebhook:
+		webhook := NewWebhook(url)
+		webhook.logger = log.Log.WithName("webhook-sink")
+		return &webhookSink{webhook: webhook}, nil
+	case SinkTypeSlack:
+		return &slackSink{slack: NewSlack(), url: url}, nil
+	case SinkTypeTeams:
+		webhook := NewWebhook(url)
+		webhook.logger = log.Log.WithName("teams-sink")
+		return &teamsSink{webhook: webhook}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type '%s', must be one of: %s, %s, %s",
+			sinkType, SinkTypeWebhook, SinkTypeSlack, SinkTypeTeams)
+	}
+}
+
+// SinkSet holds the configured sinks and lets them be replaced, e.g. when the configuration
+// is reloaded, while events are being delivered
+type SinkSet struct {
+	sinks []Sink
+	mutex sync.RWMutex
+}
+
+// NewSinkSet creates a set holding sinks
+func NewSinkSet(sinks ...Sink) *SinkSet {
+	return &SinkSet{sinks: sinks}
+}
+
+// Sinks returns the sinks currently in the set; nil sets hold no sinks
+func (s *SinkSet) Sinks() []Sink {
+	if s == nil {
+		return nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.sinks
+}
+
+// Replace swaps the sinks in the set. Deliveries already in progress finish with the
+// previous sinks.
+func (s *SinkSet) Replace(sinks ...Sink) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sinks = sinks
+}
+
+// webhookSink posts firing events as JSON
+type webhookSink struct {
+	webhook *Webhook
+}
+
+// Notify posts event to the webhook
+func (s *webhookSink) Notify(ctx context.Context, event FiringEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal firing event: %w", err)
+	}
+	return s.webhook.deliver(ctx, body, "firing event")
+}
+
+// slackSink posts firing events to a fixed Slack incoming webhook
+type slackSink struct {
+	slack *Slack
+	url   string
+}
+
+// Notify posts a formatted message for event to Slack
+func (s *slackSink) Notify(ctx context.Context, event FiringEvent) error {
+	return s.slack.NotifyFiring(ctx, s.url, event)
+}
+
+// teamsMessage is the Microsoft Teams incoming webhook payload
+type teamsMessage struct {
+	Type    string `json:"@type"`
+	Context string `json:"@context"`
+	Summary string `json:"summary"`
+	Title   string `json:"title"`
+	Text    string `json:"text"`
+}
+
+// teamsSink posts firing events to a Microsoft Teams incoming webhook
+type teamsSink struct {
+	webhook *Webhook
+}
+
+// Notify posts a message card for event to Teams
+func (s *teamsSink) Notify(ctx context.Context, event FiringEvent) error {
+	body, err := json.Marshal(formatTeamsMessage(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams message: %w", err)
+	}
+	return s.webhook.deliver(ctx, body, "teams message")
+}
+
+// formatTeamsMessage renders event as a Teams message card
+func formatTeamsMessage(event FiringEvent) teamsMessage {
+	title := fmt.Sprintf("[%s] %s on %s", strings.ToUpper(event.Severity), event.EventType, event.ResourceName)
+	text := fmt.Sprintf("Namespace `%s`, hook `%s`", event.Namespace, event.Hook)
+	if event.Message != "" {
+		text += "\n\n" + event.Message
+	}
+	return teamsMessage{
+		Type:    "MessageCard",
+		Context: "https://schema.org/extensions",
+		Summary: title,
+		Title:   title,
+		Text:    text,
+	}
+}
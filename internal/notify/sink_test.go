@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinks_Notify(t *testing.T) {
+	event := FiringEvent{
+		Hook:         "test-hook",
+		Namespace:    "default",
+		EventType:    "oom-kill",
+		ResourceName: "test-pod",
+		Severity:     "critical",
+		Message:      "Container app exceeded its memory limit",
+	}
+
+	tests := []struct {
+		sinkType string
+		expected map[string]interface{}
+	}{
+		{
+			sinkType: SinkTypeWebhook,
+			expected: map[string]interface{}{
+				"hook":         "test-hook",
+				"namespace":    "default",
+				"eventType":    "oom-kill",
+				"resourceName": "test-pod",
+				"severity":     "critical",
+				"message":      "Container app exceeded its memory limit",
+			},
+		},
+		{
+			sinkType: SinkTypeSlack,
+			expected: map[string]interface{}{
+				"text": "*[CRITICAL] oom-kill* on `test-pod` in namespace `default` (hook `test-hook`)\n> Container app exceeded its memory limit",
+			},
+		},
+		{
+			sinkType: SinkTypeTeams,
+			expected: map[string]interface{}{
+				"@type":    "MessageCard",
+				"@context": "https://schema.org/extensions",
+				"summary":  "[CRITICAL] oom-kill on test-pod",
+				"title":    "[CRITICAL] oom-kill on test-pod",
+				"text":     "Namespace `default`, hook `test-hook`\n\nContainer app exceeded its memory limit",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sinkType, func(t *testing.T) {
+			var received map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			sink, err := NewSink(tt.sinkType, server.URL)
+			require.NoError(t, err)
+			require.NoError(t, sink.Notify(context.Background(), event))
+			assert.Equal(t, tt.expected, received)
+		})
+	}
+}
+
+func TestNewSink_UnknownType(t *testing.T) {
+	_, err := NewSink("pagerduty", "https://example.com")
+	assert.ErrorContains(t, err, "unknown sink type 'pagerduty'")
+}
+
+func TestSinkSet_Replace(t *testing.T) {
+	var nilSet *SinkSet
+	assert.Empty(t, nilSet.Sinks())
+
+	slack, err := NewSink(SinkTypeSlack, "https://hooks.slack.com/services/a")
+	require.NoError(t, err)
+	webhook, err := NewSink(SinkTypeWebhook, "https://example.com/hook")
+	require.NoError(t, err)
+
+	set := NewSinkSet(slack)
+	assert.Equal(t, []Sink{slack}, set.Sinks())
+
+	set.Replace(webhook)
+	assert.Equal(t, []Sink{webhook}, set.Sinks())
+
+	set.Replace()
+	assert.Empty(t, set.Sinks())
+}
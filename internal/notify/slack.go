@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// FiringEvent describes an event that started firing for a hook, as posted to Slack and sinks
+type FiringEvent struct {
+	Hook         string `json:"hook"`
+	Namespace    string `json:"namespace"`
+	EventType    string `json:"eventType"`
+	ResourceName string `json:"resourceName"`
+	Severity     string `json:"severity"`
+	Message      string `json:"message"`
+}
+
+// slackMessage is the Slack incoming webhook payload
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// Slack posts firing-event messages to Slack incoming webhooks
+type Slack struct {
+	// webhook holds the delivery settings; its url is replaced per message
+	webhook Webhook
+}
+
+// NewSlack creates a Slack notifier with the same timeout and retries as NewWebhook
+func NewSlack() *Slack {
+	webhook := NewWebhook("")
+	webhook.logger = log.Log.WithName("slack-notifier")
+	return &Slack{webhook: *webhook}
+}
+
+// NotifyFiring posts a formatted message for event to the Slack incoming webhook at webhookURL
+func (s *Slack) NotifyFiring(ctx context.Context, webhookURL string, event FiringEvent) error {
+	body, err := json.Marshal(slackMessage{Text: FormatSlackMessage(event)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack message: %w", err)
+	}
+
+	webhook := s.webhook
+	webhook.url = webhookURL
+	return webhook.deliver(ctx, body, "slack message")
+}
+
+// FormatSlackMessage renders event as Slack mrkdwn text
+func FormatSlackMessage(event FiringEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*[%s] %s* on `%s` in namespace `%s`",
+		strings.ToUpper(event.Severity), event.EventType, event.ResourceName, event.Namespace)
+	fmt.Fprintf(&b, " (hook `%s`)", event.Hook)
+	if event.Message != "" {
+		fmt.Fprintf(&b, "\n> %s", event.Message)
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlack_NotifyFiring(t *testing.T) {
+	var received slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	event := FiringEvent{
+		Hook:         "test-hook",
+		Namespace:    "default",
+		EventType:    "oom-kill",
+		ResourceName: "test-pod",
+		Severity:     "high",
+		Message:      "Container app exceeded its memory limit",
+	}
+
+	require.NoError(t, NewSlack().NotifyFiring(context.Background(), server.URL, event))
+	assert.Equal(t, "*[HIGH] oom-kill* on `test-pod` in namespace `default` (hook `test-hook`)\n> Container app exceeded its memory limit", received.Text)
+}
+
+func TestSlack_NotifyFiringRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	slack := NewSlack()
+	slack.webhook.backoff = time.Millisecond
+	require.NoError(t, slack.NotifyFiring(context.Background(), server.URL, FiringEvent{EventType: "pod-restart"}))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestFormatSlackMessage_NoMessage(t *testing.T) {
+	text := FormatSlackMessage(FiringEvent{
+		Hook:         "test-hook",
+		Namespace:    "default",
+		EventType:    "pod-pending",
+		ResourceName: "test-pod",
+		Severity:     "medium",
+	})
+	assert.Equal(t, "*[MEDIUM] pod-pending* on `test-pod` in namespace `default` (hook `test-hook`)", text)
+}
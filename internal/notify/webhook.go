@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// defaultTimeout bounds each webhook delivery attempt
+	defaultTimeout = 5 * time.Second
+
+	// defaultMaxAttempts is the number of delivery attempts, including the first
+	defaultMaxAttempts = 3
+
+	// defaultBackoff is the delay before the first retry; it doubles on each retry
+	defaultBackoff = 1 * time.Second
+)
+
+// ResolvedEvent is the payload posted when an active event transitions from firing to resolved
+type ResolvedEvent struct {
+	Hook         string    `json:"hook"`
+	Namespace    string    `json:"namespace"`
+	EventType    string    `json:"eventType"`
+	ResourceName string    `json:"resourceName"`
+	FirstSeen    time.Time `json:"firstSeen"`
+	LastSeen     time.Time `json:"lastSeen"`
+	Status       string    `json:"status"`
+}
+
+// Webhook posts resolved-event notifications to an HTTP endpoint
+type Webhook struct {
+	url         string
+	httpClient  *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	logger      logr.Logger
+}
+
+// NewWebhook creates a webhook notifier posting to url with a 5s timeout per attempt
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		url:         url,
+		httpClient:  &http.Client{Timeout: defaultTimeout},
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+		logger:      log.Log.WithName("resolve-webhook"),
+	}
+}
+
+// NotifyResolved posts event to the webhook, retrying network errors and 5xx responses
+func (w *Webhook) NotifyResolved(ctx context.Context, event ResolvedEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resolved event: %w", err)
+	}
+	return w.deliver(ctx, body, "resolved event")
+}
+
+// deliver posts body to the webhook, retrying network errors and 5xx responses.
+// what describes the payload in errors and logs.
+func (w *Webhook) deliver(ctx context.Context, body []byte, what string) error {
+	delay := w.backoff
+	for attempt := 1; ; attempt++ {
+		retryable, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= w.maxAttempts {
+			return fmt.Errorf("failed to deliver %s after %d attempt(s): %w", what, attempt, err)
+		}
+
+		w.logger.V(1).Info("Retrying webhook delivery",
+			"payload", what,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to deliver %s: %w", what, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post sends a single delivery attempt and reports whether a failure is retryable
+func (w *Webhook) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 500 {
+		return true, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode >= 400 {
+		return false, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return false, nil
+}
@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhook(url string) *Webhook {
+	w := NewWebhook(url)
+	w.backoff = time.Millisecond
+	return w
+}
+
+func TestWebhook_NotifyResolved(t *testing.T) {
+	var received ResolvedEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	firstSeen := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	event := ResolvedEvent{
+		Hook:         "test-hook",
+		Namespace:    "default",
+		EventType:    "pod-restart",
+		ResourceName: "test-pod",
+		FirstSeen:    firstSeen,
+		LastSeen:     firstSeen.Add(time.Minute),
+		Status:       "resolved",
+	}
+
+	require.NoError(t, newTestWebhook(server.URL).NotifyResolved(context.Background(), event))
+	assert.Equal(t, event, received)
+}
+
+func TestWebhook_NotifyResolvedRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	require.NoError(t, newTestWebhook(server.URL).NotifyResolved(context.Background(), ResolvedEvent{Status: "resolved"}))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestWebhook_NotifyResolvedClientErrorNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := newTestWebhook(server.URL).NotifyResolved(context.Background(), ResolvedEvent{Status: "resolved"})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
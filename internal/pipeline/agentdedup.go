@@ -0,0 +1,69 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+// agentCall is the most recent call of an agent for one event
+type agentCall struct {
+	hookRef types.NamespacedName
+	at      time.Time
+}
+
+// AgentCallDeduplicator suppresses calls from different hooks to the same agent for the
+// same event and resource within window, on top of the per-hook deduplication. One
+// deduplicator is shared by the processors of all namespaces so hooks in different
+// namespaces are deduplicated too.
+type AgentCallDeduplicator struct {
+	window time.Duration
+	calls  map[string]agentCall
+	mutex  sync.Mutex
+}
+
+// NewAgentCallDeduplicator creates a deduplicator suppressing cross-hook calls within window
+func NewAgentCallDeduplicator(window time.Duration) *AgentCallDeduplicator {
+	return &AgentCallDeduplicator{
+		window: window,
+		calls:  make(map[string]agentCall),
+	}
+}
+
+// agentCallKeyFor identifies the agent, event type and resource of an agent call
+func agentCallKeyFor(agentRef types.NamespacedName, event interfaces.Event) string {
+	return agentRef.String() + "|" + event.Type + "|" + event.Namespace + "/" + event.ResourceName
+}
+
+// Claim reserves the call for key to hookRef at now. When another hook claimed key within
+// the window, the call is not allowed and owner is that hook.
+func (d *AgentCallDeduplicator) Claim(key string, hookRef types.NamespacedName, now time.Time) (owner types.NamespacedName, allowed bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for k, call := range d.calls {
+		if now.Sub(call.at) >= d.window {
+			delete(d.calls, k)
+		}
+	}
+
+	if call, ok := d.calls[key]; ok && call.hookRef != hookRef {
+		return call.hookRef, false
+	}
+	d.calls[key] = agentCall{hookRef: hookRef, at: now}
+	return hookRef, true
+}
+
+// Release drops hookRef's claim on key, e.g. after its agent call failed, so other hooks
+// may call the agent for the event
+func (d *AgentCallDeduplicator) Release(key string, hookRef types.NamespacedName) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if call, ok := d.calls[key]; ok && call.hookRef == hookRef {
+		delete(d.calls, key)
+	}
+}
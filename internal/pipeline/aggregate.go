@@ -0,0 +1,269 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/khook/api/v1alpha2"
+	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/metrics"
+)
+
+// eventBatch holds the matches collected within one aggregation window
+type eventBatch struct {
+	agentRef types.NamespacedName
+	matches  []EventMatch
+	timer    *time.Timer
+}
+
+// eventAggregator buffers matches of configurations with an aggregation window, keyed by
+// hook, event type and agent, until their window expires
+type eventAggregator struct {
+	batches map[string]*eventBatch
+	// stopped is set once the workflow owning the aggregator ends
+	stopped bool
+	// workflowCtx and drainer belong to the running workflow; nil outside a workflow
+	workflowCtx context.Context
+	drainer     *drainer
+	mutex       sync.Mutex
+}
+
+// batchKey identifies the batch a match of hookRef for eventType sent to agentRef joins
+func batchKey(hookRef types.NamespacedName, eventType string, agentRef types.NamespacedName) string {
+	return hookRef.String() + "|" + eventType + "|" + agentRef.String()
+}
+
+// Start ties the aggregator to a workflow so that flushes are drained with its events
+// and cancelled with ctx
+func (a *eventAggregator) Start(ctx context.Context, d *drainer) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.workflowCtx = ctx
+	a.drainer = d
+}
+
+// Add appends match to its batch. A match opening a batch schedules flush once window
+// expires. It reports false when the aggregator is stopped and the match was dropped.
+func (a *eventAggregator) Add(key string, match EventMatch, agentRef types.NamespacedName, window time.Duration, flush func()) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.stopped {
+		return false
+	}
+	if a.batches == nil {
+		a.batches = make(map[string]*eventBatch)
+	}
+	batch, ok := a.batches[key]
+	if !ok {
+		batch = &eventBatch{agentRef: agentRef, timer: time.AfterFunc(window, flush)}
+		a.batches[key] = batch
+	}
+	batch.matches = append(batch.matches, match)
+	return true
+}
+
+// Take removes and returns the batch for key with the context to flush it with, derived
+// from ctx and cancelled with the workflow. The flush is registered with the workflow's
+// drainer. It returns a nil batch when there is none, the aggregator is stopped or the
+// workflow is draining; otherwise the returned function must be called once flushed.
+func (a *eventAggregator) Take(ctx context.Context, key string) (*eventBatch, context.Context, func()) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	batch := a.batches[key]
+	delete(a.batches, key)
+	if batch == nil || a.stopped {
+		return nil, nil, nil
+	}
+	if a.workflowCtx == nil {
+		return batch, ctx, func() {}
+	}
+	if !a.drainer.begin() {
+		return nil, nil, nil
+	}
+	flushCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(a.workflowCtx, cancel)
+	return batch, flushCtx, func() {
+		stop()
+		cancel()
+		a.drainer.end()
+	}
+}
+
+// Stop cancels the pending batches and drops matches added later. It returns how many
+// matches were dropped.
+func (a *eventAggregator) Stop() int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.stopped = true
+	dropped := 0
+	for key, batch := range a.batches {
+		batch.timer.Stop()
+		dropped += len(batch.matches)
+		delete(a.batches, key)
+	}
+	return dropped
+}
+
+// aggregateMatch adds the match to its batch, scheduling the batch's agent call when the
+// match opens it. The call runs once the configuration's aggregation window expires.
+func (p *Processor) aggregateMatch(ctx context.Context, match EventMatch, agentRef types.NamespacedName) {
+	hookRef := types.NamespacedName{Namespace: match.Hook.Namespace, Name: match.Hook.Name}
+	key := batchKey(hookRef, match.Event.Type, agentRef)
+
+	// The match's context ends when it returns, so the flush keeps only its values
+	valuesCtx := context.WithoutCancel(ctx)
+	added := p.aggregator.Add(key, match, agentRef, match.Configuration.AggregationWindow.Duration, func() {
+		batch, flushCtx, done := p.aggregator.Take(valuesCtx, key)
+		if batch == nil {
+			return
+		}
+		defer done()
+		if err := p.flushBatch(flushCtx, batch); err != nil {
+			p.logger.Error(err, "Failed to process aggregated events",
+				"hook", hookRef,
+				"eventType", match.Event.Type,
+				"eventCount", len(batch.matches))
+		}
+	})
+	if !added {
+		p.logger.V(1).Info("Dropping event matched after the workflow stopped",
+			"hook", hookRef,
+			"eventType", match.Event.Type,
+			"resourceName", match.Event.ResourceName)
+	}
+}
+
+// stopAggregation cancels the batches still waiting for their window when the workflow
+// ends. The workflow ends whenever one of its hooks is edited or deleted, so a batch is
+// never sent for a hook that changed after its events matched.
+func (p *Processor) stopAggregation() {
+	if dropped := p.aggregator.Stop(); dropped > 0 {
+		p.logger.Info("Dropped aggregated events pending at workflow stop", "eventCount", dropped)
+	}
+}
+
+// flushBatch sends the batch's events to its agent in one call and records the outcome
+// for each of them
+func (p *Processor) flushBatch(ctx context.Context, batch *eventBatch) error {
+	first := batch.matches[0]
+	hookRef := types.NamespacedName{Namespace: first.Hook.Namespace, Name: first.Hook.Name}
+	labels := metrics.HookLabelsFor(first.Hook.Annotations)
+
+	ctx, cancel := p.withConfigurationTimeout(ctx, first.Configuration)
+	defer cancel()
+
+	// Leave out the events another hook already sent to the same agent
+	var agentCallKeys []string
+	if p.agentCalls != nil {
+		claimed := batch.matches[:0:0]
+		for _, match := range batch.matches {
+			agentCallKey := agentCallKeyFor(batch.agentRef, match.Event)
+			callingHook, allowed := p.agentCalls.Claim(agentCallKey, hookRef, p.now())
+			if allowed {
+				claimed = append(claimed, match)
+				agentCallKeys = append(agentCallKeys, agentCallKey)
+				continue
+			}
+			p.logger.V(1).Info("Skipping agent call already made by another hook",
+				"hook", hookRef,
+				"callingHook", callingHook,
+				"agentRef", batch.agentRef,
+				"eventType", match.Event.Type,
+				"resourceName", match.Event.ResourceName)
+			metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultDuplicate, labels)
+			if err := p.statusManager.RecordCrossHookDuplicate(ctx, match.Hook, match.Event, batch.agentRef, callingHook); err != nil {
+				p.logger.Error(err, "Failed to record cross-hook duplicate event", "hook", hookRef)
+				metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
+			}
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+		batch = &eventBatch{agentRef: batch.agentRef, matches: claimed}
+		first = claimed[0]
+	}
+	releaseClaims := func() {
+		for _, agentCallKey := range agentCallKeys {
+			p.agentCalls.Release(agentCallKey, hookRef)
+		}
+	}
+
+	if p.rateLimiter != nil {
+		if allowed, tripped := p.rateLimiter.Allow(hookRef, p.now()); !allowed {
+			p.logger.V(1).Info("Skipping agent call for rate limited hook",
+				"hook", hookRef,
+				"eventType", first.Event.Type,
+				"eventCount", len(batch.matches))
+			releaseClaims()
+			for _, match := range batch.matches {
+				metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultRateLimited, labels)
+			}
+			if err := p.statusManager.RecordRateLimited(ctx, first.Hook, first.Event, tripped); err != nil {
+				p.logger.Error(err, "Failed to record rate limited event", "hook", hookRef)
+				metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
+			}
+			return nil
+		}
+	}
+
+	events := make([]interfaces.Event, 0, len(batch.matches))
+	resources := make([]string, 0, len(batch.matches))
+	for _, match := range batch.matches {
+		events = append(events, match.Event)
+		resources = append(resources, match.Event.ResourceName)
+	}
+
+	agentRequest := p.createAgentRequest(first, batch.agentRef)
+	agentRequest.Prompt = p.expandBatchPromptTemplate(p.promptFor(first), events)
+	if first.Configuration.OutputFormat == v1alpha2.OutputFormatJSON {
+		agentRequest.Prompt = p.structuredPrompt(first, agentRequest.Prompt)
+	}
+	agentRequest.Context["resources"] = resources
+	agentRequest.IdempotencyKey = idempotencyKey(hookRef, p.notification(hookRef, first.Event)) + "-batch"
+
+	callStart := time.Now()
+	response, err := p.kagentClient.CallAgent(ctx, agentRequest)
+	if err != nil {
+		result := metrics.ResultForError(err)
+		metrics.ObserveAgentCall(first.Event.Type, result, labels, time.Since(callStart))
+		metrics.RecordProcessingError(metrics.ErrorCategoryAgent, hookRef.Namespace)
+		// Let other hooks deliver the events since this call did not reach the agent
+		releaseClaims()
+		for _, match := range batch.matches {
+			p.observeProcessing(hookRef, result, match.Event)
+			metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, result, labels)
+			if statusErr := p.statusManager.RecordAgentCallFailure(ctx, match.Hook, match.Event, batch.agentRef, err); statusErr != nil {
+				p.logger.Error(statusErr, "Failed to record agent call failure", "hook", hookRef)
+				metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
+			}
+		}
+		return fmt.Errorf("failed to call agent %s: %w", batch.agentRef.Name, err)
+	}
+
+	metrics.ObserveAgentCall(first.Event.Type, metrics.EventResultSuccess, labels, time.Since(callStart))
+	for _, match := range batch.matches {
+		p.observeProcessing(hookRef, metrics.EventResultSuccess, match.Event)
+		metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultSuccess, labels)
+		if err := p.statusManager.RecordAgentCallSuccess(ctx, match.Hook, match.Event, batch.agentRef, response.RequestId); err != nil {
+			p.logger.Error(err, "Failed to record agent call success", "hook", hookRef)
+			metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
+		}
+		p.deduplicationManager.MarkNotified(hookRef, match.Event, response)
+	}
+
+	p.logger.Info("Successfully processed aggregated events",
+		"hook", hookRef,
+		"eventType", first.Event.Type,
+		"eventCount", len(batch.matches),
+		"agentRef", batch.agentRef,
+		"requestId", response.RequestId)
+	return nil
+}
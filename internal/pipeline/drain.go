@@ -0,0 +1,67 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// drainer tracks the events a workflow is processing so that shutdown can let them
+// finish instead of aborting their agent calls
+type drainer struct {
+	timeout time.Duration
+
+	mutex    sync.Mutex
+	draining bool
+	inFlight int
+	wg       sync.WaitGroup
+}
+
+// begin registers an event about to be processed. It returns false once draining has
+// started, in which case the event must not be processed.
+func (d *drainer) begin() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.draining {
+		return false
+	}
+	d.inFlight++
+	d.wg.Add(1)
+	return true
+}
+
+// end marks an event registered with begin as processed
+func (d *drainer) end() {
+	d.mutex.Lock()
+	d.inFlight--
+	d.mutex.Unlock()
+	d.wg.Done()
+}
+
+// drain stops new events from being processed and waits up to timeout for in-flight
+// events to finish, then calls cancel. It returns how many events finished and how many
+// were still in flight when cancel was called.
+func (d *drainer) drain(cancel context.CancelFunc) (drained, aborted int) {
+	d.mutex.Lock()
+	d.draining = true
+	inFlight := d.inFlight
+	d.mutex.Unlock()
+	defer cancel()
+
+	finished := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return inFlight, 0
+	case <-time.After(d.timeout):
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return inFlight - d.inFlight, d.inFlight
+}
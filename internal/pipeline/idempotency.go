@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+// idempotencyKey identifies one notification of a hook's active event, so retries of a call
+// that did not go through, e.g. after it timed out, carry the same key while notifications
+// after a successful call get a new one. firstSeen is truncated to seconds so the key
+// survives active events being restored from hook status; lastNotifiedAt moves on with every
+// successful call.
+func idempotencyKey(hookRef types.NamespacedName, activeEvent interfaces.ActiveEvent) string {
+	lastNotified := ""
+	if activeEvent.LastNotifiedAt != nil {
+		lastNotified = activeEvent.LastNotifiedAt.UTC().Format(time.RFC3339Nano)
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		hookRef.String(),
+		activeEvent.EventType,
+		activeEvent.Namespace,
+		activeEvent.ResourceName,
+		activeEvent.Reason,
+		activeEvent.FirstSeen.UTC().Format(time.RFC3339),
+		lastNotified,
+	}, "|")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// coalesceKey identifies a call to the agent about one occurrence of the event, so hooks
+// calling the same agent about the same watched event can share a single call
+func coalesceKey(agentRef types.NamespacedName, event interfaces.Event) string {
+	return agentCallKeyFor(agentRef, event) + "|" + event.Timestamp.UTC().Format(time.RFC3339Nano)
+}
+
+// notification returns the hook's active event the event is a duplicate of, or one seen at
+// the event's own timestamp when no active event is tracked for it
+func (p *Processor) notification(hookRef types.NamespacedName, event interfaces.Event) interfaces.ActiveEvent {
+	if activeEvent, ok := p.deduplicationManager.GetActiveEvent(hookRef, event); ok {
+		return activeEvent
+	}
+	return interfaces.ActiveEvent{
+		EventType:    event.Type,
+		Namespace:    event.Namespace,
+		ResourceName: event.ResourceName,
+		Reason:       event.Reason,
+		FirstSeen:    event.Timestamp,
+	}
+}
@@ -14,73 +14,27 @@ import (
 	"k8s.io/client-go/tools/record"
 
 	"github.com/kagent-dev/khook/api/v1alpha2"
+	kclient "github.com/kagent-dev/khook/internal/client"
 	"github.com/kagent-dev/khook/internal/deduplication"
 	"github.com/kagent-dev/khook/internal/event"
 	"github.com/kagent-dev/khook/internal/interfaces"
 	"github.com/kagent-dev/khook/internal/status"
 )
 
-// MockKagentClientForIntegration provides a simple mock for integration testing
-type MockKagentClientForIntegration struct {
-	responses map[string]*interfaces.AgentResponse
-	calls     []interfaces.AgentRequest
-}
-
-func NewMockKagentClientForIntegration() *MockKagentClientForIntegration {
-	return &MockKagentClientForIntegration{
-		responses: make(map[string]*interfaces.AgentResponse),
-		calls:     make([]interfaces.AgentRequest, 0),
-	}
-}
-
-func (m *MockKagentClientForIntegration) CallAgent(ctx context.Context, request interfaces.AgentRequest) (*interfaces.AgentResponse, error) {
-	m.calls = append(m.calls, request)
-
-	if response, exists := m.responses[request.AgentRef.String()]; exists {
-		if response == nil {
-			return nil, errors.New("mock agent call failed")
-		}
-		return response, nil
-	}
-
-	// Default response
-	return &interfaces.AgentResponse{
-		Success:   true,
-		Message:   "Mock response",
-		RequestId: "mock-request-" + request.AgentRef.String(),
-	}, nil
-}
-
-func (m *MockKagentClientForIntegration) Authenticate() error {
-	return nil
-}
-
-func (m *MockKagentClientForIntegration) SetResponse(agentRef types.NamespacedName, response *interfaces.AgentResponse) {
-	m.responses[agentRef.String()] = response
-}
-
-func (m *MockKagentClientForIntegration) GetCalls() []interfaces.AgentRequest {
-	return m.calls
-}
-
-func (m *MockKagentClientForIntegration) ClearCalls() {
-	m.calls = make([]interfaces.AgentRequest, 0)
-}
-
 // TestEventProcessingIntegration tests the complete event processing pipeline
 func TestEventProcessingIntegration(t *testing.T) {
-	// Create real components (except Kagent client which we mock)
+	// Create real components (except Kagent client which we fake)
 	k8sClient := fake.NewSimpleClientset()
 	eventRecorder := record.NewFakeRecorder(100)
 
 	// Create real components
 	eventWatcher := event.NewWatcher(k8sClient, "default")
 	deduplicationManager := deduplication.NewManager()
-	mockKagentClient := NewMockKagentClientForIntegration()
+	kagentClient := kclient.NewFakeClient()
 	statusManager := status.NewManager(nil, eventRecorder) // nil client for this test
 
 	// Create processor
-	processor := NewProcessor(eventWatcher, deduplicationManager, mockKagentClient, statusManager)
+	processor := NewProcessor(eventWatcher, deduplicationManager, kagentClient, statusManager)
 
 	// Create test hooks
 	hook1 := &v1alpha2.Hook{
@@ -131,7 +85,7 @@ func TestEventProcessingIntegration(t *testing.T) {
 
 	// Test 1: Process pod-restart event
 	t.Run("ProcessPodRestartEvent", func(t *testing.T) {
-		mockKagentClient.ClearCalls()
+		kagentClient.ClearCalls()
 
 		event := interfaces.Event{
 			Type:         "pod-restart",
@@ -150,7 +104,7 @@ func TestEventProcessingIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify agent calls
-		calls := mockKagentClient.GetCalls()
+		calls := kagentClient.Calls()
 		assert.Len(t, calls, 2, "Should call both agents for pod-restart event")
 
 		// Verify first call (restart-agent)
@@ -181,7 +135,7 @@ func TestEventProcessingIntegration(t *testing.T) {
 
 	// Test 2: Process duplicate event (should be ignored)
 	t.Run("ProcessDuplicateEvent", func(t *testing.T) {
-		mockKagentClient.ClearCalls()
+		kagentClient.ClearCalls()
 
 		// Same event as before - should be deduplicated
 		event := interfaces.Event{
@@ -198,13 +152,13 @@ func TestEventProcessingIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify no agent calls were made
-		calls := mockKagentClient.GetCalls()
+		calls := kagentClient.Calls()
 		assert.Len(t, calls, 0, "Should not call agents for duplicate event")
 	})
 
 	// Test 3: Process OOM kill event
 	t.Run("ProcessOOMKillEvent", func(t *testing.T) {
-		mockKagentClient.ClearCalls()
+		kagentClient.ClearCalls()
 
 		event := interfaces.Event{
 			Type:         "oom-kill",
@@ -223,7 +177,7 @@ func TestEventProcessingIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify agent calls - only multi-event-hook should match
-		calls := mockKagentClient.GetCalls()
+		calls := kagentClient.Calls()
 		assert.Len(t, calls, 1, "Should call only the OOM agent")
 
 		call := calls[0]
@@ -235,7 +189,7 @@ func TestEventProcessingIntegration(t *testing.T) {
 
 	// Test 4: Process event with no matching hooks
 	t.Run("ProcessUnmatchedEvent", func(t *testing.T) {
-		mockKagentClient.ClearCalls()
+		kagentClient.ClearCalls()
 
 		event := interfaces.Event{
 			Type:         "probe-failed",
@@ -251,7 +205,7 @@ func TestEventProcessingIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify no agent calls were made
-		calls := mockKagentClient.GetCalls()
+		calls := kagentClient.Calls()
 		assert.Len(t, calls, 0, "Should not call agents for unmatched event")
 	})
 
@@ -285,10 +239,10 @@ func TestEventProcessingWithErrors(t *testing.T) {
 
 	eventWatcher := event.NewWatcher(k8sClient, "default")
 	deduplicationManager := deduplication.NewManager()
-	mockKagentClient := NewMockKagentClientForIntegration()
+	kagentClient := kclient.NewFakeClient()
 	statusManager := status.NewManager(nil, eventRecorder)
 
-	processor := NewProcessor(eventWatcher, deduplicationManager, mockKagentClient, statusManager)
+	processor := NewProcessor(eventWatcher, deduplicationManager, kagentClient, statusManager)
 
 	// Create test hooks - separate hooks to avoid deduplication interference
 	hook1 := &v1alpha2.Hook{
@@ -331,8 +285,8 @@ func TestEventProcessingWithErrors(t *testing.T) {
 	ctx := context.Background()
 
 	// Set up one agent to fail and one to succeed
-	mockKagentClient.SetResponse(types.NamespacedName{Name: "failing-agent", Namespace: "default"}, nil) // This will cause an error
-	mockKagentClient.SetResponse(types.NamespacedName{Name: "working-agent", Namespace: "default"}, &interfaces.AgentResponse{
+	kagentClient.SetError(types.NamespacedName{Name: "failing-agent", Namespace: "default"}, errors.New("agent call failed"))
+	kagentClient.SetResponse(types.NamespacedName{Name: "working-agent", Namespace: "default"}, &interfaces.AgentResponse{
 		Success:   true,
 		Message:   "Success",
 		RequestId: "working-request",
@@ -355,7 +309,7 @@ func TestEventProcessingWithErrors(t *testing.T) {
 	// So we expect an error but the working agent should still be called
 	assert.Error(t, err)
 
-	calls := mockKagentClient.GetCalls()
+	calls := kagentClient.Calls()
 	assert.Len(t, calls, 2, "Should attempt to call both agents")
 
 	// Verify both agents were attempted
@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+// DefaultLatencySamples is how many recent processing latencies are kept per hook
+const DefaultLatencySamples = 256
+
+// LatencySummary describes the recent event processing latencies of a hook
+type LatencySummary struct {
+	Samples int
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+}
+
+// latencyRing holds the most recent latencies of one hook, overwriting the oldest once full
+type latencyRing struct {
+	samples []time.Duration
+	next    int
+}
+
+// LatencyTracker keeps the most recent event processing latencies of each hook in a ring
+// buffer, so percentiles reflect recent behavior in bounded memory. One tracker is shared
+// by the processors of all namespaces.
+type LatencyTracker struct {
+	size  int
+	hooks map[types.NamespacedName]*latencyRing
+	mutex sync.Mutex
+}
+
+// NewLatencyTracker creates a tracker keeping the last size latencies of each hook
+func NewLatencyTracker(size int) *LatencyTracker {
+	return &LatencyTracker{
+		size:  max(1, size),
+		hooks: make(map[types.NamespacedName]*latencyRing),
+	}
+}
+
+// Record adds a processing latency of hookRef, dropping its oldest one when the buffer is full
+func (t *LatencyTracker) Record(hookRef types.NamespacedName, latency time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ring, ok := t.hooks[hookRef]
+	if !ok {
+		ring = &latencyRing{samples: make([]time.Duration, 0, t.size)}
+		t.hooks[hookRef] = ring
+	}
+	if len(ring.samples) < t.size {
+		ring.samples = append(ring.samples, latency)
+		return
+	}
+	ring.samples[ring.next] = latency
+	ring.next = (ring.next + 1) % t.size
+}
+
+// Summary returns the percentiles of hookRef's recent latencies, and false when none were
+// recorded
+func (t *LatencyTracker) Summary(hookRef types.NamespacedName) (LatencySummary, bool) {
+	t.mutex.Lock()
+	ring, ok := t.hooks[hookRef]
+	var samples []time.Duration
+	if ok {
+		samples = slices.Clone(ring.samples)
+	}
+	t.mutex.Unlock()
+	if len(samples) == 0 {
+		return LatencySummary{}, false
+	}
+
+	slices.Sort(samples)
+	return LatencySummary{
+		Samples: len(samples),
+		P50:     percentile(samples, 0.50),
+		P90:     percentile(samples, 0.90),
+		P99:     percentile(samples, 0.99),
+	}, true
+}
+
+// percentile returns the nearest-rank percentile q of the sorted samples
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	rank := int(math.Ceil(q * float64(len(sorted))))
+	return sorted[max(0, rank-1)]
+}
+
+// receivedAt returns when the watcher handed the event off for processing, or now for
+// events not delivered by a watcher
+func receivedAt(event interfaces.Event) time.Time {
+	if event.ReceivedAt.IsZero() {
+		return time.Now()
+	}
+	return event.ReceivedAt
+}
@@ -3,19 +3,51 @@ package pipeline
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"slices"
 	"strings"
-	"text/template"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/kagent-dev/khook/api/v1alpha2"
+	khookerrors "github.com/kagent-dev/khook/internal/errors"
 	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/metrics"
+	"github.com/kagent-dev/khook/internal/notify"
+	"github.com/kagent-dev/khook/internal/severity"
 )
 
+const (
+	// lifecycleFiring labels agent calls for an event that started firing
+	lifecycleFiring = "firing"
+
+	// lifecycleResolved labels agent calls for an event that resolved
+	lifecycleResolved = "resolved"
+
+	// fallbackMinSeverity is the lowest severity of unmatched events routed to the fallback agent
+	fallbackMinSeverity = severity.High
+
+	// defaultCleanupInterval is how often the workflow cleans up expired events
+	defaultCleanupInterval = 5 * time.Minute
+
+	// defaultStatusInterval is how often the workflow updates hook statuses
+	defaultStatusInterval = 1 * time.Minute
+
+	// fallbackPrompt is sent to the fallback agent for events no hook matched
+	fallbackPrompt = "No hook matched a {{.EventType}} event for {{.ResourceName}} in namespace {{.Namespace}}: {{.Message}}. Investigate the event and suggest a remediation."
+)
+
+// fallbackHookRef tracks fallback events in the deduplication manager
+var fallbackHookRef = types.NamespacedName{Name: "khook-fallback"}
+
 // Processor handles the complete event processing pipeline
 type Processor struct {
 	eventWatcher         interfaces.EventWatcher
@@ -23,6 +55,238 @@ type Processor struct {
 	kagentClient         interfaces.KagentClient
 	statusManager        interfaces.StatusManager
 	logger               logr.Logger
+
+	// now returns the current time; overridable for testing schedule-based routing
+	now func() time.Time
+
+	// cleanupInterval and statusInterval are the periods of the workflow's cleanup and
+	// status updates; the first run of each is jittered
+	cleanupInterval time.Duration
+	statusInterval  time.Duration
+
+	// drainTimeout is how long events being processed at shutdown may take to finish
+	// before their context is cancelled; zero cancels them immediately
+	drainTimeout time.Duration
+
+	// matchTimeout bounds the processing of each event match so a hung agent call
+	// cannot stall the event loop; zero disables the bound
+	matchTimeout time.Duration
+
+	// severities resolves event severities; nil uses the built-in defaults
+	severities *severity.Mapping
+
+	// matchConcurrency is how many matches of one event are processed in parallel;
+	// values below 2 process matches sequentially
+	matchConcurrency int
+
+	// minAgentSeverity is the lowest event severity that triggers an agent call;
+	// empty means every event triggers one
+	minAgentSeverity severity.Level
+
+	// agentCalls suppresses calls from different hooks to the same agent for the same
+	// event; nil disables cross-hook deduplication
+	agentCalls *AgentCallDeduplicator
+
+	// rateLimiter bounds agent calls per hook; nil disables rate limiting
+	rateLimiter *hookRateLimiter
+
+	// aggregator buffers matches of configurations with an aggregation window
+	aggregator eventAggregator
+
+	// tasks tracks open agent tasks per resource; nil disables task continuation
+	tasks *taskTracker
+
+	// resolveNotifier receives firing->resolved transitions; nil disables webhook notifications
+	resolveNotifier ResolveNotifier
+
+	// sinks receive every firing event
+	sinks *notify.SinkSet
+
+	// latencies keeps recent processing latencies per hook; nil disables tracking
+	latencies *LatencyTracker
+
+	// slackNotifier posts firing events for configurations with a Slack webhook URL
+	slackNotifier SlackNotifier
+
+	// fallbackAgent receives high-severity events that match no hook; nil disables fallback routing
+	fallbackAgent *types.NamespacedName
+
+	// resourceMetadata looks up the labels and annotations of involved objects for
+	// configurations with a resource selector; nil drops matches of those configurations
+	resourceMetadata ResourceMetadataLookup
+
+	// kindEventTypes lists, per additional resource kind, the event types its events map
+	// to, as configured on the event watcher
+	kindEventTypes map[string][]string
+
+	// eventStatuses holds the last observed status per hook event, used to detect
+	// firing->resolved transitions between status updates
+	eventStatuses map[string]string
+}
+
+// ResolveNotifier delivers notifications for events that stopped firing
+type ResolveNotifier interface {
+	NotifyResolved(ctx context.Context, event notify.ResolvedEvent) error
+}
+
+// SlackNotifier posts firing events to Slack incoming webhooks
+type SlackNotifier interface {
+	NotifyFiring(ctx context.Context, webhookURL string, event notify.FiringEvent) error
+}
+
+// ResourceMetadataLookup returns the labels and annotations of the object an event is about
+type ResourceMetadataLookup interface {
+	Lookup(ctx context.Context, event interfaces.Event) (labels, annotations map[string]string, err error)
+}
+
+// Option configures optional Processor behavior
+type Option func(*Processor)
+
+// WithTaskReuse sends events for a resource that already has an open agent task as a
+// continuation of that task. A task resolves once window passes without a new event for it.
+func WithTaskReuse(window time.Duration) Option {
+	return func(p *Processor) {
+		if window > 0 {
+			p.tasks = newTaskTracker(window)
+		}
+	}
+}
+
+// WithAgentDeduplication suppresses agent calls for an event and resource when another
+// hook called the same agent for them within the deduplicator's window. Processors sharing
+// dedup also suppress calls of each other's hooks.
+func WithAgentDeduplication(dedup *AgentCallDeduplicator) Option {
+	return func(p *Processor) {
+		p.agentCalls = dedup
+	}
+}
+
+// WithMaxAgentCallsPerMinute limits each hook to n agent calls per minute. Matches over
+// the limit are recorded as rate limited and do not call the agent.
+func WithMaxAgentCallsPerMinute(n int) Option {
+	return func(p *Processor) {
+		if n > 0 {
+			p.rateLimiter = newHookRateLimiter(n)
+		}
+	}
+}
+
+// WithResolveNotifier notifies n whenever an active event transitions from firing to resolved
+func WithResolveNotifier(n ResolveNotifier) Option {
+	return func(p *Processor) {
+		p.resolveNotifier = n
+	}
+}
+
+// WithSinks notifies sinks whenever an event starts firing for a hook
+func WithSinks(sinks ...notify.Sink) Option {
+	return func(p *Processor) {
+		p.sinks = notify.NewSinkSet(slices.Concat(p.sinks.Sinks(), sinks)...)
+	}
+}
+
+// WithSinkSet notifies the sinks in set whenever an event starts firing for a hook. The
+// set can be shared by processors and its sinks replaced while they run.
+func WithSinkSet(set *notify.SinkSet) Option {
+	return func(p *Processor) {
+		p.sinks = set
+	}
+}
+
+// WithLatencyTracker records the processing latency of every agent call in tracker, from
+// the watcher handing off the event to the agent responding. The tracker can be shared by
+// processors.
+func WithLatencyTracker(tracker *LatencyTracker) Option {
+	return func(p *Processor) {
+		p.latencies = tracker
+	}
+}
+
+// WithFallbackAgent routes high or critical severity events that match no hook to agentRef
+func WithFallbackAgent(agentRef types.NamespacedName) Option {
+	return func(p *Processor) {
+		p.fallbackAgent = &agentRef
+	}
+}
+
+// WithResourceMetadata resolves the resource selectors of event configurations with lookup
+func WithResourceMetadata(lookup ResourceMetadataLookup) Option {
+	return func(p *Processor) {
+		p.resourceMetadata = lookup
+	}
+}
+
+// WithMatchTimeout bounds the processing of each event match, including the agent call.
+// A timed-out agent call is recorded as a failure.
+func WithMatchTimeout(timeout time.Duration) Option {
+	return func(p *Processor) {
+		if timeout > 0 {
+			p.matchTimeout = timeout
+		}
+	}
+}
+
+// WithIntervals sets how often ProcessEventWorkflow cleans up expired events and updates
+// hook statuses. Non-positive values keep the defaults of 5m and 1m.
+func WithIntervals(cleanup, status time.Duration) Option {
+	return func(p *Processor) {
+		if cleanup > 0 {
+			p.cleanupInterval = cleanup
+		}
+		if status > 0 {
+			p.statusInterval = status
+		}
+	}
+}
+
+// WithDrainTimeout lets events that are being processed when ProcessEventWorkflow is
+// cancelled finish for up to timeout, so their agent calls are not aborted mid-flight.
+// No new events are processed once draining starts.
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(p *Processor) {
+		if timeout > 0 {
+			p.drainTimeout = timeout
+		}
+	}
+}
+
+// WithMatchConcurrency processes up to n hooks matching the same event in parallel, so a
+// slow agent does not delay the other hooks
+func WithMatchConcurrency(n int) Option {
+	return func(p *Processor) {
+		p.matchConcurrency = n
+	}
+}
+
+// WithSeverityMapping resolves event severities with mapping instead of the built-in defaults
+func WithSeverityMapping(mapping *severity.Mapping) Option {
+	return func(p *Processor) {
+		p.severities = mapping
+	}
+}
+
+// WithMinAgentSeverity only calls agents for events at or above the given severity.
+// Events below it are still recorded in the hook status.
+func WithMinAgentSeverity(level severity.Level) Option {
+	return func(p *Processor) {
+		p.minAgentSeverity = level
+	}
+}
+
+// WithEventTypeKinds resolves the events of deleted resources of additional kinds with the
+// kind mapping of event.WithEventTypeKinds. kinds maps an event type to the extra kinds
+// that can produce it.
+func WithEventTypeKinds(kinds map[string][]string) Option {
+	return func(p *Processor) {
+		for eventType, kindList := range kinds {
+			for _, kind := range kindList {
+				if p.kindEventTypes == nil {
+					p.kindEventTypes = map[string][]string{}
+				}
+				p.kindEventTypes[kind] = append(p.kindEventTypes[kind], eventType)
+			}
+		}
+	}
 }
 
 // NewProcessor creates a new event processing pipeline
@@ -31,14 +295,24 @@ func NewProcessor(
 	deduplicationManager interfaces.DeduplicationManager,
 	kagentClient interfaces.KagentClient,
 	statusManager interfaces.StatusManager,
+	opts ...Option,
 ) *Processor {
-	return &Processor{
+	p := &Processor{
 		eventWatcher:         eventWatcher,
 		deduplicationManager: deduplicationManager,
 		kagentClient:         kagentClient,
 		statusManager:        statusManager,
 		logger:               log.Log.WithName("event-processor"),
+		now:                  time.Now,
+		cleanupInterval:      defaultCleanupInterval,
+		statusInterval:       defaultStatusInterval,
+		slackNotifier:        notify.NewSlack(),
+		eventStatuses:        make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	return p
 }
 
 // ProcessEvent processes a single event against all provided hooks
@@ -49,12 +323,28 @@ func (p *Processor) ProcessEvent(ctx context.Context, event interfaces.Event, ho
 		"namespace", event.Namespace,
 		"hookCount", len(hooks))
 
+	if event.Type == interfaces.ResourceDeletedEventType {
+		return p.resolveDeletedResource(ctx, event, hooks)
+	}
+
 	// Find matching hooks and configurations for this event
 	matches := p.findEventMatches(event, hooks)
+	if len(matches) > 0 {
+		// Events about resources excluded by a selector are dropped rather than routed to
+		// the fallback agent
+		if matches = p.filterByResourceMetadata(ctx, event, matches); len(matches) == 0 {
+			return nil
+		}
+	}
 	if len(matches) == 0 {
 		p.logger.V(1).Info("No matching hooks found for event",
 			"eventType", event.Type,
 			"resourceName", event.ResourceName)
+		if p.fallbackAgent != nil {
+			fallbackCtx, cancel := p.withMatchTimeout(ctx)
+			defer cancel()
+			return p.processFallbackEvent(fallbackCtx, event)
+		}
 		return nil
 	}
 
@@ -63,22 +353,73 @@ func (p *Processor) ProcessEvent(ctx context.Context, event interfaces.Event, ho
 		"resourceName", event.ResourceName,
 		"matchCount", len(matches))
 
-	// Process each match
-	var lastError error
+	return p.processMatches(ctx, matches)
+}
+
+// processMatches processes the matches of one event, running up to matchConcurrency hooks
+// in parallel, and returns the errors of failed matches joined together. Matches of the
+// same hook run in order on one worker so its deduplication state is updated consistently.
+// A failing match does not stop the others.
+func (p *Processor) processMatches(ctx context.Context, matches []EventMatch) error {
+	var (
+		hookOrder     []*v1alpha2.Hook
+		matchesByHook = map[*v1alpha2.Hook][]EventMatch{}
+	)
 	for _, match := range matches {
-		if err := p.processEventMatch(ctx, match); err != nil {
-			p.logger.Error(err, "Failed to process event match",
-				"hook", match.Hook.Name,
-				"eventType", event.Type,
-				"resourceName", event.ResourceName,
-				"agentRef", match.Configuration.AgentRef)
-			lastError = err
-			// Continue processing other matches even if one fails
-			continue
+		if _, seen := matchesByHook[match.Hook]; !seen {
+			hookOrder = append(hookOrder, match.Hook)
 		}
+		matchesByHook[match.Hook] = append(matchesByHook[match.Hook], match)
 	}
 
-	return lastError
+	var (
+		wg     sync.WaitGroup
+		mutex  sync.Mutex
+		errs   []error
+		limits = make(chan struct{}, max(1, p.matchConcurrency))
+	)
+	for _, hook := range hookOrder {
+		limits <- struct{}{}
+		wg.Add(1)
+		go func(hookMatches []EventMatch) {
+			defer func() {
+				<-limits
+				wg.Done()
+			}()
+			for _, match := range hookMatches {
+				if err := p.processEventMatchWithTimeout(ctx, match); err != nil {
+					p.logger.Error(err, "Failed to process event match",
+						"hook", match.Hook.Name,
+						"eventType", match.Event.Type,
+						"resourceName", match.Event.ResourceName,
+						"agentRef", match.Configuration.AgentRef,
+						"transient", khookerrors.IsTransient(err))
+					mutex.Lock()
+					errs = append(errs, err)
+					mutex.Unlock()
+					// Continue processing other matches even if one fails
+				}
+			}
+		}(matchesByHook[hook])
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// PreviewEvent returns the agent requests the event would produce against the given hooks,
+// without deduplication, status updates or calling any agent. It is meant for debugging
+// hook matching and prompt templates with synthetic events.
+func (p *Processor) PreviewEvent(event interfaces.Event, hooks []*v1alpha2.Hook) ([]interfaces.AgentRequest, error) {
+	var requests []interfaces.AgentRequest
+	for _, match := range p.findEventMatches(event, hooks) {
+		agentRef, err := p.resolveAgentRef(match)
+		if err != nil {
+			return nil, fmt.Errorf("hook %s/%s: %w", match.Hook.Namespace, match.Hook.Name, err)
+		}
+		requests = append(requests, p.createAgentRequest(match, agentRef))
+	}
+	return requests, nil
 }
 
 // EventMatch represents a matched event with its hook and configuration
@@ -93,8 +434,23 @@ func (p *Processor) findEventMatches(event interfaces.Event, hooks []*v1alpha2.H
 	var matches []EventMatch
 
 	for _, hook := range hooks {
+		if hook.Spec.Suspended {
+			continue
+		}
 		for _, config := range hook.Spec.EventConfigurations {
-			if config.EventType == event.Type {
+			if config.EventType != event.Type || !config.MatchesResourceKind(event.Metadata["kind"]) ||
+				!config.MatchesProbeType(event.Metadata["probeType"]) || !config.MatchesReason(event.Reason) ||
+				!config.MatchesEventCount(event.Metadata["count"]) {
+				continue
+			}
+			matched, err := config.MatchesResourceName(event.ResourceName)
+			if err != nil {
+				p.logger.Error(err, "Skipping event configuration with invalid resource name pattern",
+					"hook", hook.Name,
+					"eventType", config.EventType)
+				continue
+			}
+			if matched {
 				matches = append(matches, EventMatch{
 					Hook:          hook,
 					Configuration: config,
@@ -107,85 +463,393 @@ func (p *Processor) findEventMatches(event interfaces.Event, hooks []*v1alpha2.H
 	return matches
 }
 
+// filterByResourceMetadata drops the matches whose configuration has a resource selector
+// that the event's involved object does not satisfy. The object's metadata is looked up at
+// most once per event. Matches are dropped when the lookup fails, so selectors fail closed.
+func (p *Processor) filterByResourceMetadata(ctx context.Context, event interfaces.Event, matches []EventMatch) []EventMatch {
+	var (
+		looked               bool
+		lookupErr            error
+		objLabels, objAnnots map[string]string
+		filtered             = make([]EventMatch, 0, len(matches))
+	)
+	for _, match := range matches {
+		if !match.Configuration.HasResourceSelector() {
+			filtered = append(filtered, match)
+			continue
+		}
+		if !looked {
+			looked = true
+			if p.resourceMetadata == nil {
+				lookupErr = fmt.Errorf("resource metadata lookup is not configured")
+			} else {
+				objLabels, objAnnots, lookupErr = p.resourceMetadata.Lookup(ctx, event)
+			}
+		}
+		if lookupErr != nil {
+			p.logger.Error(lookupErr, "Dropping event for configuration with a resource selector",
+				"hook", match.Hook.Name,
+				"eventType", event.Type,
+				"resourceName", event.ResourceName)
+			continue
+		}
+		matched, err := match.Configuration.MatchesResourceMetadata(objLabels, objAnnots)
+		if err != nil {
+			p.logger.Error(err, "Skipping event configuration with invalid resource selector",
+				"hook", match.Hook.Name,
+				"eventType", match.Configuration.EventType)
+			continue
+		}
+		if !matched {
+			p.logger.V(1).Info("Event resource does not match resource selector",
+				"hook", match.Hook.Name,
+				"eventType", event.Type,
+				"resourceName", event.ResourceName)
+			continue
+		}
+		filtered = append(filtered, match)
+	}
+	return filtered
+}
+
+// withMatchTimeout derives the context for processing one event match, bounded by
+// matchTimeout when it is set
+func (p *Processor) withMatchTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, p.matchTimeout)
+}
+
+// withConfigurationTimeout derives the context for processing a match of config, bounded by
+// the configuration's AgentCallTimeout when it is set and by matchTimeout otherwise
+func (p *Processor) withConfigurationTimeout(ctx context.Context, config v1alpha2.EventConfiguration) (context.Context, context.CancelFunc) {
+	if config.AgentCallTimeout != nil {
+		return withTimeout(ctx, config.AgentCallTimeout.Duration)
+	}
+	return p.withMatchTimeout(ctx)
+}
+
+// withTimeout bounds ctx by timeout when it is positive
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// processEventMatchWithTimeout processes a single event match, giving up once the
+// configuration's AgentCallTimeout or matchTimeout passes. A timed-out agent call is
+// recorded as a failed call.
+func (p *Processor) processEventMatchWithTimeout(ctx context.Context, match EventMatch) error {
+	matchCtx, cancel := p.withConfigurationTimeout(ctx, match.Configuration)
+	defer cancel()
+	return p.processEventMatch(matchCtx, match)
+}
+
 // processEventMatch processes a single event match through the complete pipeline
 func (p *Processor) processEventMatch(ctx context.Context, match EventMatch) error {
 	hookRef := types.NamespacedName{
 		Namespace: match.Hook.Namespace,
 		Name:      match.Hook.Name,
 	}
+	labels := metrics.HookLabelsFor(match.Hook.Annotations)
 
-	// Check deduplication - should we process this event?
-	if !p.deduplicationManager.ShouldProcessEvent(hookRef, match.Event) {
+	// Check deduplication - should we process this event? Configurations with
+	// deduplication disabled call the agent for every occurrence.
+	if !match.Configuration.DisableDeduplication && !p.deduplicationManager.ShouldProcessEvent(hookRef, match.Event) {
 		p.logger.V(1).Info("Event ignored due to deduplication",
 			"hook", hookRef,
 			"eventType", match.Event.Type,
 			"resourceName", match.Event.ResourceName)
+		metrics.RecordDeduplicatedEvent(match.Event.Type, hookRef.Namespace, labels)
+
+		// Track the repeat occurrence so the active event reflects how often it recurs
+		if err := p.deduplicationManager.RecordEvent(hookRef, match.Event); err != nil {
+			p.logger.Error(err, "Failed to record duplicate occurrence", "hook", hookRef)
+			metrics.RecordProcessingError(metrics.ErrorCategoryDedup, hookRef.Namespace)
+		}
 
 		// Record that we ignored a duplicate event
 		if err := p.statusManager.RecordDuplicateEvent(ctx, match.Hook, match.Event); err != nil {
 			p.logger.Error(err, "Failed to record duplicate event", "hook", hookRef)
+			metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
 		}
 		return nil
 	}
 
+	// Hold events of configurations with a minimum active duration until they persist
+	if minActive := match.Configuration.MinActiveDuration; minActive != nil &&
+		!p.deduplicationManager.ConfirmActive(hookRef, match.Event, minActive.Duration) {
+		p.logger.V(1).Info("Event pending confirmation",
+			"hook", hookRef,
+			"eventType", match.Event.Type,
+			"resourceName", match.Event.ResourceName,
+			"minActiveDuration", minActive.Duration)
+		metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultPending, labels)
+		return nil
+	}
+
 	// Record the event in deduplication manager
 	if err := p.deduplicationManager.RecordEvent(hookRef, match.Event); err != nil {
+		metrics.RecordProcessingError(metrics.ErrorCategoryDedup, hookRef.Namespace)
+		metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultFailure, labels)
 		return fmt.Errorf("failed to record event in deduplication manager: %w", err)
 	}
 
-	agentRefNs := match.Hook.Namespace
-	if match.Configuration.AgentRef.Namespace != nil {
-		agentRefNs = *match.Configuration.AgentRef.Namespace
-	}
-	agentRef := types.NamespacedName{
-		Name:      match.Configuration.AgentRef.Name,
-		Namespace: agentRefNs,
+	agentRef, err := p.resolveAgentRef(match)
+	if err != nil {
+		metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.ResultForError(err), labels)
+		metrics.RecordProcessingError(metrics.ErrorCategoryAgent, hookRef.Namespace)
+		if statusErr := p.statusManager.RecordError(ctx, match.Hook, match.Event, err, agentRef); statusErr != nil {
+			p.logger.Error(statusErr, "Failed to record agent resolution error", "hook", hookRef)
+			metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
+		}
+		return fmt.Errorf("failed to resolve agent: %w", err)
 	}
 
 	// Record that the event is firing
 	if err := p.statusManager.RecordEventFiring(ctx, match.Hook, match.Event, agentRef); err != nil {
 		p.logger.Error(err, "Failed to record event firing", "hook", hookRef)
+		metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
 		// Continue processing even if status recording fails
 	}
 
+	if match.Configuration.SlackWebhookURL != "" {
+		p.notifySlack(ctx, hookRef, match)
+	}
+	if sinks := p.sinks.Sinks(); len(sinks) > 0 {
+		p.notifySinks(ctx, hookRef, match, sinks)
+	}
+
+	// Record-only events below the agent severity threshold
+	if eventSeverity := p.severities.ForEventType(match.Event.Type); p.minAgentSeverity != "" && !eventSeverity.AtLeast(p.minAgentSeverity) {
+		p.logger.V(1).Info("Skipping agent call for event below minimum severity",
+			"hook", hookRef,
+			"eventType", match.Event.Type,
+			"severity", eventSeverity,
+			"minAgentSeverity", p.minAgentSeverity)
+		metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultSkipped, labels)
+		return nil
+	}
+
+	// Collect events of aggregating configurations and send them in one call once the window expires
+	if match.Configuration.AggregationWindow != nil {
+		p.aggregateMatch(ctx, match, agentRef)
+		return nil
+	}
+
+	// Skip the call when another hook already sent this event to the same agent
+	var agentCallKey string
+	if p.agentCalls != nil {
+		agentCallKey = agentCallKeyFor(agentRef, match.Event)
+		if callingHook, allowed := p.agentCalls.Claim(agentCallKey, hookRef, p.now()); !allowed {
+			p.logger.V(1).Info("Skipping agent call already made by another hook",
+				"hook", hookRef,
+				"callingHook", callingHook,
+				"agentRef", agentRef,
+				"eventType", match.Event.Type,
+				"resourceName", match.Event.ResourceName)
+			metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultDuplicate, labels)
+			if err := p.statusManager.RecordCrossHookDuplicate(ctx, match.Hook, match.Event, agentRef, callingHook); err != nil {
+				p.logger.Error(err, "Failed to record cross-hook duplicate event", "hook", hookRef)
+				metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
+			}
+			return nil
+		}
+	}
+
+	// Stop calling the agent for hooks flooded with events, e.g. by a crash-looping deployment
+	if p.rateLimiter != nil {
+		if allowed, tripped := p.rateLimiter.Allow(hookRef, p.now()); !allowed {
+			p.logger.V(1).Info("Skipping agent call for rate limited hook",
+				"hook", hookRef,
+				"eventType", match.Event.Type,
+				"resourceName", match.Event.ResourceName)
+			metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultRateLimited, labels)
+			if p.agentCalls != nil {
+				p.agentCalls.Release(agentCallKey, hookRef)
+			}
+			if err := p.statusManager.RecordRateLimited(ctx, match.Hook, match.Event, tripped); err != nil {
+				p.logger.Error(err, "Failed to record rate limited event", "hook", hookRef)
+				metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
+			}
+			return nil
+		}
+	}
+
 	// Create agent request with event context
 	agentRequest := p.createAgentRequest(match, agentRef)
+	agentRequest.IdempotencyKey = idempotencyKey(hookRef, p.notification(hookRef, match.Event))
+	agentRequest.CoalesceKey = coalesceKey(agentRef, match.Event)
+
+	// Continue the resource's open agent task, if any
+	taskKey := taskKey(hookRef, match.Event.Namespace, match.Event.ResourceName)
+	if p.tasks != nil {
+		agentRequest.TaskID = p.tasks.Open(taskKey, agentRef, p.now())
+	}
 
 	// Call the Kagent agent
+	callStart := time.Now()
 	response, err := p.kagentClient.CallAgent(ctx, agentRequest)
 	if err != nil {
+		// Classify the failure so transient and permanent failures can be told apart
+		result := metrics.ResultForError(err)
+		metrics.ObserveAgentCall(match.Event.Type, result, labels, time.Since(callStart))
+		p.observeProcessing(hookRef, result, match.Event)
+		metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, result, labels)
+		metrics.RecordProcessingError(metrics.ErrorCategoryAgent, hookRef.Namespace)
+		if p.agentCalls != nil {
+			// Let other hooks deliver the event since this call did not reach the agent
+			p.agentCalls.Release(agentCallKey, hookRef)
+		}
+		if p.tasks != nil {
+			// Start a fresh task next time rather than continuing one the agent may have dropped
+			p.tasks.Forget(taskKey)
+		}
 		// Record the failure
 		if statusErr := p.statusManager.RecordAgentCallFailure(ctx, match.Hook, match.Event, agentRef, err); statusErr != nil {
 			p.logger.Error(statusErr, "Failed to record agent call failure", "hook", hookRef)
+			metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
 		}
 		return fmt.Errorf("failed to call agent %s: %w", agentRef.Name, err)
 	}
 
+	metrics.ObserveAgentCall(match.Event.Type, metrics.EventResultSuccess, labels, time.Since(callStart))
+	p.observeProcessing(hookRef, metrics.EventResultSuccess, match.Event)
+	metrics.RecordEventProcessed(match.Event.Type, hookRef.Namespace, metrics.EventResultSuccess, labels)
+	if p.tasks != nil {
+		p.tasks.Record(taskKey, agentRef, response.RequestId, p.now())
+	}
+
 	// Record successful agent call
 	if err := p.statusManager.RecordAgentCallSuccess(ctx, match.Hook, match.Event, agentRef, response.RequestId); err != nil {
 		p.logger.Error(err, "Failed to record agent call success", "hook", hookRef)
+		metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
 		// Continue even if status recording fails
 	}
 
 	// Mark event as notified to suppress re-sending within suppression window
-	p.deduplicationManager.MarkNotified(hookRef, match.Event)
+	p.deduplicationManager.MarkNotified(hookRef, match.Event, response)
 
 	p.logger.Info("Successfully processed event match",
 		"hook", hookRef,
 		"eventType", match.Event.Type,
 		"resourceName", match.Event.ResourceName,
 		"agentRef", agentRef,
-		"requestId", response.RequestId)
+		"requestId", response.RequestId,
+		"continuedTask", agentRequest.TaskID != "")
 
 	return nil
 }
 
+// processFallbackEvent sends an unmatched event to the fallback agent when its severity
+// is at least fallbackMinSeverity
+func (p *Processor) processFallbackEvent(ctx context.Context, event interfaces.Event) error {
+	if eventSeverity := p.severities.ForEventType(event.Type); !eventSeverity.AtLeast(fallbackMinSeverity) {
+		return nil
+	}
+
+	if !p.deduplicationManager.ShouldProcessEvent(fallbackHookRef, event) {
+		metrics.RecordDeduplicatedEvent(event.Type, event.Namespace, metrics.HookLabels{})
+		return nil
+	}
+	if err := p.deduplicationManager.RecordEvent(fallbackHookRef, event); err != nil {
+		metrics.RecordProcessingError(metrics.ErrorCategoryDedup, event.Namespace)
+		return fmt.Errorf("failed to record fallback event in deduplication manager: %w", err)
+	}
+
+	request := interfaces.AgentRequest{
+		AgentRef:     *p.fallbackAgent,
+		Prompt:       p.expandPromptTemplate(fallbackPrompt, event),
+		EventName:    event.Type,
+		EventTime:    event.Timestamp,
+		ResourceName: event.ResourceName,
+		Context: map[string]interface{}{
+			"namespace": event.Namespace,
+			"reason":    event.Reason,
+			"message":   event.Message,
+			"uid":       event.UID,
+			"metadata":  event.Metadata,
+			"fallback":  true,
+		},
+	}
+	request.IdempotencyKey = idempotencyKey(fallbackHookRef, p.notification(fallbackHookRef, event))
+	request.CoalesceKey = coalesceKey(*p.fallbackAgent, event)
+
+	callStart := time.Now()
+	response, err := p.kagentClient.CallAgent(ctx, request)
+	if err != nil {
+		result := metrics.ResultForError(err)
+		metrics.ObserveAgentCall(event.Type, result, metrics.HookLabels{}, time.Since(callStart))
+		metrics.RecordEventProcessed(event.Type, event.Namespace, result, metrics.HookLabels{})
+		metrics.RecordProcessingError(metrics.ErrorCategoryAgent, event.Namespace)
+		return fmt.Errorf("failed to call fallback agent %s: %w", p.fallbackAgent.Name, err)
+	}
+	metrics.ObserveAgentCall(event.Type, metrics.EventResultSuccess, metrics.HookLabels{}, time.Since(callStart))
+	metrics.RecordEventProcessed(event.Type, event.Namespace, metrics.EventResultSuccess, metrics.HookLabels{})
+	p.deduplicationManager.MarkNotified(fallbackHookRef, event, response)
+
+	p.logger.Info("Routed unmatched event to fallback agent",
+		"eventType", event.Type,
+		"resourceName", event.ResourceName,
+		"namespace", event.Namespace,
+		"agentRef", *p.fallbackAgent,
+		"requestId", response.RequestId)
+	return nil
+}
+
+// resolveAgentRef determines which agent should handle the match, honoring the
+// configuration's business hours schedule and agent name template when set
+func (p *Processor) resolveAgentRef(match EventMatch) (types.NamespacedName, error) {
+	ref := match.Configuration.AgentRef
+	if schedule := match.Configuration.Schedule; schedule != nil {
+		inBusinessHours, err := schedule.InBusinessHours(p.now())
+		if err != nil {
+			p.logger.Error(err, "Failed to evaluate agent schedule, using default agent",
+				"hook", match.Hook.Name,
+				"eventType", match.Event.Type)
+		} else if !inBusinessHours {
+			ref = schedule.OffHoursAgentRef
+		}
+	}
+
+	agentRefNs := match.Hook.Namespace
+	if ref.Namespace != nil {
+		agentRefNs = *ref.Namespace
+	}
+	agentRef := types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: agentRefNs,
+	}
+
+	// Expand a templated agent name with the event context and require a valid object name
+	if match.Configuration.AgentRefTemplate {
+		agentRef.Name = p.expandPromptTemplate(ref.Name, match.Event)
+		if errs := validation.IsDNS1123Subdomain(agentRef.Name); len(errs) > 0 {
+			return agentRef, khookerrors.New(khookerrors.KindInvalidConfig, fmt.Errorf("agentRef.name template %q expanded to invalid name %q: %s",
+				ref.Name, agentRef.Name, strings.Join(errs, "; ")))
+		}
+	}
+	return agentRef, nil
+}
+
+// observeProcessing records how long the hook took to process the event, from the watcher
+// handing it off until its agent call returned with result
+func (p *Processor) observeProcessing(hookRef types.NamespacedName, result metrics.EventResult, event interfaces.Event) {
+	latency := time.Since(receivedAt(event))
+	metrics.ObserveEventProcessing(hookRef.Namespace, hookRef.Name, result, latency)
+	if p.latencies != nil {
+		p.latencies.Record(hookRef, latency)
+	}
+}
+
 // createAgentRequest creates an agent request from an event match
 func (p *Processor) createAgentRequest(match EventMatch, agentRef types.NamespacedName) interfaces.AgentRequest {
-	// Expand prompt template with event context
-	prompt := p.expandPromptTemplate(match.Configuration.Prompt, match.Event)
+	// Expand the prompt template for the event's severity with event context
+	prompt := p.expandPromptTemplate(p.promptFor(match), match.Event)
+	if match.Configuration.OutputFormat == v1alpha2.OutputFormatJSON {
+		prompt = p.structuredPrompt(match, prompt)
+	}
 
-	return interfaces.AgentRequest{
+	request := interfaces.AgentRequest{
 		AgentRef:     agentRef,
 		Prompt:       prompt,
 		EventName:    match.Event.Type,
@@ -196,15 +860,93 @@ func (p *Processor) createAgentRequest(match EventMatch, agentRef types.Namespac
 			"reason":        match.Event.Reason,
 			"message":       match.Event.Message,
 			"uid":           match.Event.UID,
-			"metadata":      match.Event.Metadata,
+			"metadata":      match.Configuration.FilterContextMetadata(match.Event.Metadata),
 			"hookName":      match.Hook.Name,
 			"hookNamespace": match.Hook.Namespace,
 		},
 	}
+	if schedulingReason := match.Event.Metadata["schedulingReason"]; schedulingReason != "" {
+		request.Context["schedulingReason"] = schedulingReason
+	}
+	if probeType := match.Event.Metadata["probeType"]; probeType != "" {
+		request.Context["probeType"] = probeType
+	}
+	if ownerKind, ownerName := match.Event.Metadata["ownerKind"], match.Event.Metadata["ownerName"]; ownerKind != "" && ownerName != "" {
+		request.Context["owner"] = ownerKind + "/" + ownerName
+	}
+	if match.Hook.Spec.LifecycleNotifications {
+		request.Context["lifecycle"] = lifecycleFiring
+	}
+	if match.Configuration.AgentCallTimeout != nil {
+		// Let each attempt run as long as the configuration allows the whole call
+		request.Timeout = match.Configuration.AgentCallTimeout.Duration
+	}
+
+	return request
+}
+
+// structuredPromptHook identifies the hook in a structured prompt
+type structuredPromptHook struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// structuredPrompt is the JSON prompt sent for configurations with the json output format
+type structuredPrompt struct {
+	Prompt       string               `json:"prompt"`
+	EventType    string               `json:"eventType"`
+	ResourceName string               `json:"resourceName"`
+	Namespace    string               `json:"namespace"`
+	Reason       string               `json:"reason"`
+	Message      string               `json:"message"`
+	Timestamp    string               `json:"timestamp"`
+	UID          string               `json:"uid,omitempty"`
+	Metadata     map[string]string    `json:"metadata,omitempty"`
+	Hook         structuredPromptHook `json:"hook"`
+}
+
+// structuredPrompt serializes the match's event context and the expanded prompt as JSON.
+// The expanded prompt is sent as is if serialization fails.
+func (p *Processor) structuredPrompt(match EventMatch, prompt string) string {
+	data, err := json.Marshal(structuredPrompt{
+		Prompt:       prompt,
+		EventType:    match.Event.Type,
+		ResourceName: match.Event.ResourceName,
+		Namespace:    match.Event.Namespace,
+		Reason:       match.Event.Reason,
+		Message:      match.Event.Message,
+		Timestamp:    match.Event.Timestamp.Format(time.RFC3339),
+		UID:          match.Event.UID,
+		Metadata:     match.Configuration.FilterContextMetadata(match.Event.Metadata),
+		Hook: structuredPromptHook{
+			Name:      match.Hook.Name,
+			Namespace: match.Hook.Namespace,
+		},
+	})
+	if err != nil {
+		p.logger.Error(err, "Failed to serialize structured prompt, sending text prompt",
+			"hook", match.Hook.Name,
+			"eventType", match.Event.Type)
+		return prompt
+	}
+	return string(data)
+}
+
+// promptFor returns the match configuration's prompt template for the event's severity
+func (p *Processor) promptFor(match EventMatch) string {
+	return match.Configuration.PromptFor(string(p.severities.ForEventType(match.Event.Type)))
 }
 
 // expandPromptTemplate expands template variables in the prompt using Go's text/template
 func (p *Processor) expandPromptTemplate(templateStr string, event interfaces.Event) string {
+	return p.expandBatchPromptTemplate(templateStr, []interfaces.Event{event})
+}
+
+// expandBatchPromptTemplate expands the prompt for events sent in one agent call. The
+// first event provides the single-event variables; all of them are listed in {{.Resources}}.
+func (p *Processor) expandBatchPromptTemplate(templateStr string, events []interfaces.Event) string {
+	event := events[0]
+
 	// Validate template for security
 	if err := p.validateTemplate(templateStr); err != nil {
 		p.logger.Error(err, "Template validation failed, using original template",
@@ -213,23 +955,14 @@ func (p *Processor) expandPromptTemplate(templateStr string, event interfaces.Ev
 		return templateStr
 	}
 
-	// First, try to expand known placeholders using the original manual method
-	// This ensures backward compatibility for unknown placeholders
-	result := p.expandKnownPlaceholders(templateStr, event)
-
-	// Check if there are still unexpanded template placeholders
-	// If so, skip text/template processing to maintain backward compatibility
-	if strings.Contains(result, "{{") && strings.Contains(result, "}}") {
-		p.logger.V(2).Info("Template contains unknown placeholders, skipping advanced processing",
-			"template", result)
+	// Expand with text/template, which supports conditionals and template functions
+	if result, err := p.expandWithTextTemplate(templateStr, event, events); err == nil {
 		return result
 	}
 
-	// Then try to use text/template for more advanced templating
-	// This allows for complex template expressions while maintaining backward compatibility
-	result = p.expandWithTextTemplate(result, event)
-
-	return result
+	// Fall back to replacing known placeholders so templates referencing unknown
+	// placeholders keep them for backward compatibility
+	return p.expandKnownPlaceholders(templateStr, event)
 }
 
 // validateTemplate performs security validation on template strings
@@ -285,6 +1018,9 @@ func (p *Processor) expandKnownPlaceholders(template string, event interfaces.Ev
 		"{{.Timestamp}}":    event.Timestamp.Format(time.RFC3339),
 		"{{.EventTime}}":    event.Timestamp.Format(time.RFC3339),
 		"{{.EventMessage}}": event.Message,
+		"{{.ProbeType}}":    event.Metadata["probeType"],
+		"{{.OwnerKind}}":    event.Metadata["ownerKind"],
+		"{{.OwnerName}}":    event.Metadata["ownerName"],
 	}
 
 	for placeholder, value := range replacements {
@@ -294,8 +1030,9 @@ func (p *Processor) expandKnownPlaceholders(template string, event interfaces.Ev
 	return expanded
 }
 
-// expandWithTextTemplate attempts to use text/template for advanced features
-func (p *Processor) expandWithTextTemplate(templateStr string, event interfaces.Event) string {
+// expandWithTextTemplate expands the template with text/template and the prompt template
+// functions. Unknown fields fail the expansion instead of rendering as "<no value>".
+func (p *Processor) expandWithTextTemplate(templateStr string, event interfaces.Event, resources []interfaces.Event) (string, error) {
 	// Create template data for advanced templating
 	templateData := map[string]interface{}{
 		"EventType":    event.Type,
@@ -306,26 +1043,27 @@ func (p *Processor) expandWithTextTemplate(templateStr string, event interfaces.
 		"Timestamp":    event.Timestamp.Format(time.RFC3339),
 		"EventTime":    event.Timestamp.Format(time.RFC3339),
 		"EventMessage": event.Message,
+		"ProbeType":    event.Metadata["probeType"],
+		"OwnerKind":    event.Metadata["ownerKind"],
+		"OwnerName":    event.Metadata["ownerName"],
 		"Event":        event, // Full event access for advanced templating
+		"Resources":    resources,
 	}
 
-	// Try to parse and execute the template
-	tmpl, err := template.New("prompt").Parse(templateStr)
+	tmpl, err := v1alpha2.ParsePromptTemplate(templateStr)
 	if err != nil {
-		// If parsing fails, return the original string (likely already processed)
-		p.logger.V(3).Info("Template parsing failed, using already expanded template",
+		p.logger.V(3).Info("Template parsing failed, falling back to placeholder expansion",
 			"template", templateStr,
 			"error", err.Error())
-		return templateStr
+		return "", err
 	}
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, templateData); err != nil {
-		// If execution fails, return the original string
-		p.logger.V(3).Info("Template execution failed, using already expanded template",
+	if err := tmpl.Option("missingkey=error").Execute(&buf, templateData); err != nil {
+		p.logger.V(3).Info("Template execution failed, falling back to placeholder expansion",
 			"template", templateStr,
 			"error", err.Error())
-		return templateStr
+		return "", err
 	}
 
 	result := buf.String()
@@ -333,7 +1071,7 @@ func (p *Processor) expandWithTextTemplate(templateStr string, event interfaces.
 		"originalLength", len(templateStr),
 		"expandedLength", len(result))
 
-	return result
+	return result, nil
 }
 
 // UpdateHookStatuses updates the status of all hooks with their current active events
@@ -349,9 +1087,14 @@ func (p *Processor) UpdateHookStatuses(ctx context.Context, hooks []*v1alpha2.Ho
 		// Get active events for this hook with current status
 		activeEvents := p.deduplicationManager.GetActiveEventsWithStatus(hookRef)
 
+		for _, resolved := range p.resolvedTransitions(hookRef, activeEvents) {
+			p.emitResolved(ctx, hook, resolved)
+		}
+
 		// Update the hook status
 		if err := p.statusManager.UpdateHookStatus(ctx, hook, activeEvents); err != nil {
 			p.logger.Error(err, "Failed to update hook status", "hook", hookRef)
+			metrics.RecordProcessingError(metrics.ErrorCategoryStatus, hookRef.Namespace)
 			// Continue updating other hooks even if one fails
 			continue
 		}
@@ -364,10 +1107,279 @@ func (p *Processor) UpdateHookStatuses(ctx context.Context, hooks []*v1alpha2.Ho
 	return nil
 }
 
+// resolveDeletedResource resolves the active events of a deleted resource for every hook,
+// using the event types that apply to the resource's kind. The resolve notifications are
+// sent right away and the status of the affected hooks is updated rather than waiting for
+// the next status update.
+func (p *Processor) resolveDeletedResource(ctx context.Context, event interfaces.Event, hooks []*v1alpha2.Hook) error {
+	kind := event.Metadata["kind"]
+	eventTypes := p.eventTypesForKind(kind)
+
+	var affected []*v1alpha2.Hook
+	for _, hook := range hooks {
+		hookRef := types.NamespacedName{Namespace: hook.Namespace, Name: hook.Name}
+		resolvedTypes := map[string]struct{}{}
+		for _, eventType := range eventTypes {
+			if !p.deduplicationManager.ResolveEvent(hookRef, eventType, event.Namespace, event.ResourceName) {
+				continue
+			}
+			resolvedTypes[eventType] = struct{}{}
+			p.logger.Info("Resolved event of deleted resource",
+				"hook", hookRef,
+				"eventType", eventType,
+				"namespace", event.Namespace,
+				"resourceName", event.ResourceName)
+		}
+		if len(resolvedTypes) == 0 {
+			continue
+		}
+		affected = append(affected, hook)
+
+		for _, activeEvent := range p.deduplicationManager.GetActiveEventsWithStatus(hookRef) {
+			if _, ok := resolvedTypes[activeEvent.EventType]; !ok || eventNamespace(hook.Namespace, activeEvent) != event.Namespace ||
+				activeEvent.ResourceName != event.ResourceName || activeEvent.Status != "resolved" {
+				continue
+			}
+			// Record the transition so the next status update does not notify it again
+			p.eventStatuses[eventStatusKey(hookRef, activeEvent)] = activeEvent.Status
+			p.emitResolved(ctx, hook, activeEvent)
+		}
+	}
+
+	if len(affected) == 0 {
+		p.logger.V(1).Info("Deleted resource had no active events",
+			"kind", kind,
+			"resourceName", event.ResourceName,
+			"namespace", event.Namespace)
+		return nil
+	}
+	return p.UpdateHookStatuses(ctx, affected)
+}
+
+// eventTypesForKind returns the event types that events about resources of kind map to,
+// matching the event watcher's mapping
+func (p *Processor) eventTypesForKind(kind string) []string {
+	var eventTypes []string
+	for _, info := range v1alpha2.SupportedEventTypes {
+		if info.ResourceKind == kind {
+			eventTypes = append(eventTypes, info.Name)
+		}
+	}
+	return append(eventTypes, p.kindEventTypes[kind]...)
+}
+
+// eventNamespace returns the namespace of the resource an active event is about. Events
+// tracked before namespaces were recorded are about resources in the hook's namespace.
+func eventNamespace(hookNamespace string, activeEvent interfaces.ActiveEvent) string {
+	if activeEvent.Namespace != "" {
+		return activeEvent.Namespace
+	}
+	return hookNamespace
+}
+
+// eventStatusKey identifies a hook event in eventStatuses
+func eventStatusKey(hookRef types.NamespacedName, activeEvent interfaces.ActiveEvent) string {
+	return hookRef.String() + "|" + activeEvent.EventType + "|" + activeEvent.Namespace + "/" + activeEvent.ResourceName
+}
+
+// emitResolved closes the agent task of an event of hook that stopped firing and sends its
+// resolve notifications
+func (p *Processor) emitResolved(ctx context.Context, hook *v1alpha2.Hook, resolved interfaces.ActiveEvent) {
+	hookRef := types.NamespacedName{Namespace: hook.Namespace, Name: hook.Name}
+	if p.tasks != nil {
+		// The next event about the resource starts a new agent task
+		p.tasks.Forget(taskKey(hookRef, eventNamespace(hook.Namespace, resolved), resolved.ResourceName))
+	}
+	if p.resolveNotifier != nil {
+		p.notifyResolved(ctx, hookRef, resolved)
+	}
+	if hook.Spec.LifecycleNotifications {
+		if err := p.callAgentResolved(ctx, hook, resolved); err != nil {
+			p.logger.Error(err, "Failed to notify agent of resolved event",
+				"hook", hookRef,
+				"eventType", resolved.EventType,
+				"resourceName", resolved.ResourceName)
+		}
+	}
+}
+
+// resolvedTransitions returns the events that were firing at the previous status
+// update for this hook and are now resolved
+func (p *Processor) resolvedTransitions(hookRef types.NamespacedName, activeEvents []interfaces.ActiveEvent) []interfaces.ActiveEvent {
+	prefix := hookRef.String() + "|"
+	seen := make(map[string]struct{}, len(activeEvents))
+	var resolved []interfaces.ActiveEvent
+	for _, activeEvent := range activeEvents {
+		key := eventStatusKey(hookRef, activeEvent)
+		seen[key] = struct{}{}
+
+		previous := p.eventStatuses[key]
+		p.eventStatuses[key] = activeEvent.Status
+		if previous == "firing" && activeEvent.Status == "resolved" {
+			resolved = append(resolved, activeEvent)
+		}
+	}
+
+	// Forget events that are no longer tracked for this hook
+	for key := range p.eventStatuses {
+		if _, ok := seen[key]; !ok && strings.HasPrefix(key, prefix) {
+			delete(p.eventStatuses, key)
+		}
+	}
+
+	return resolved
+}
+
+// notifyResolved sends a resolve notification for an event that stopped firing
+func (p *Processor) notifyResolved(ctx context.Context, hookRef types.NamespacedName, activeEvent interfaces.ActiveEvent) {
+	resolved := notify.ResolvedEvent{
+		Hook:         hookRef.Name,
+		Namespace:    eventNamespace(hookRef.Namespace, activeEvent),
+		EventType:    activeEvent.EventType,
+		ResourceName: activeEvent.ResourceName,
+		FirstSeen:    activeEvent.FirstSeen,
+		LastSeen:     activeEvent.LastSeen,
+		Status:       activeEvent.Status,
+	}
+	// Deliver asynchronously so retries do not stall the event loop
+	go func() {
+		if err := p.resolveNotifier.NotifyResolved(ctx, resolved); err != nil {
+			p.logger.Error(err, "Failed to send resolve notification",
+				"hook", hookRef,
+				"eventType", resolved.EventType,
+				"resourceName", resolved.ResourceName)
+			metrics.RecordProcessingError(metrics.ErrorCategoryNotify, hookRef.Namespace)
+		}
+	}()
+}
+
+// notifySlack posts the firing event to the configuration's Slack webhook. Delivery is
+// asynchronous and failures are only logged, so Slack never affects the agent call.
+func (p *Processor) notifySlack(ctx context.Context, hookRef types.NamespacedName, match EventMatch) {
+	firing := p.firingEvent(hookRef, match)
+	webhookURL := match.Configuration.SlackWebhookURL
+	// Delivery outlives the match's timeout; the notifier bounds its own attempts
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := p.slackNotifier.NotifyFiring(ctx, webhookURL, firing); err != nil {
+			p.logger.Error(err, "Failed to send Slack notification",
+				"hook", hookRef,
+				"eventType", firing.EventType,
+				"resourceName", firing.ResourceName)
+			metrics.RecordProcessingError(metrics.ErrorCategoryNotify, hookRef.Namespace)
+		}
+	}()
+}
+
+// notifySinks sends the firing event to every sink. Delivery is asynchronous and failures
+// are joined and logged, so sinks never affect the agent call.
+func (p *Processor) notifySinks(ctx context.Context, hookRef types.NamespacedName, match EventMatch, sinks []notify.Sink) {
+	firing := p.firingEvent(hookRef, match)
+	// Delivery outlives the match's timeout; each sink bounds its own attempts
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		var errs []error
+		for _, sink := range sinks {
+			if err := sink.Notify(ctx, firing); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			p.logger.Error(err, "Failed to notify sinks",
+				"hook", hookRef,
+				"eventType", firing.EventType,
+				"resourceName", firing.ResourceName,
+				"failedSinks", len(errs))
+			metrics.RecordProcessingError(metrics.ErrorCategoryNotify, hookRef.Namespace)
+		}
+	}()
+}
+
+// firingEvent describes the match's event for Slack and sinks
+func (p *Processor) firingEvent(hookRef types.NamespacedName, match EventMatch) notify.FiringEvent {
+	return notify.FiringEvent{
+		Hook:         hookRef.Name,
+		Namespace:    match.Event.Namespace,
+		EventType:    match.Event.Type,
+		ResourceName: match.Event.ResourceName,
+		Severity:     string(p.severities.ForEventType(match.Event.Type)),
+		Message:      match.Event.Message,
+	}
+}
+
+// resolvedEventMatch finds the configuration of hook that a resolved event fired for. Only
+// the event type and resource name are known once an event resolves, so the filters on the
+// firing event's kind, reason, probe type, count and resource metadata are not applied.
+func (p *Processor) resolvedEventMatch(hook *v1alpha2.Hook, event interfaces.Event) (EventMatch, bool) {
+	for _, config := range hook.Spec.EventConfigurations {
+		if config.EventType != event.Type {
+			continue
+		}
+		if matched, err := config.MatchesResourceName(event.ResourceName); err != nil || !matched {
+			continue
+		}
+		return EventMatch{Hook: hook, Configuration: config, Event: event}, true
+	}
+	return EventMatch{}, false
+}
+
+// callAgentResolved tells the agent configured for an event that the event resolved
+func (p *Processor) callAgentResolved(ctx context.Context, hook *v1alpha2.Hook, activeEvent interfaces.ActiveEvent) error {
+	event := interfaces.Event{
+		Type:         activeEvent.EventType,
+		ResourceName: activeEvent.ResourceName,
+		Timestamp:    activeEvent.LastSeen,
+		Namespace:    eventNamespace(hook.Namespace, activeEvent),
+	}
+
+	match, ok := p.resolvedEventMatch(hook, event)
+	if !ok {
+		// The configuration for this event was removed since it fired
+		return nil
+	}
+
+	agentRef, err := p.resolveAgentRef(match)
+	if err != nil {
+		metrics.RecordProcessingError(metrics.ErrorCategoryAgent, hook.Namespace)
+		return fmt.Errorf("failed to resolve agent: %w", err)
+	}
+	request := p.createAgentRequest(match, agentRef)
+	request.Context["lifecycle"] = lifecycleResolved
+	request.Context["firstSeen"] = activeEvent.FirstSeen
+	request.Context["lastSeen"] = activeEvent.LastSeen
+	request.IdempotencyKey = idempotencyKey(types.NamespacedName{Namespace: hook.Namespace, Name: hook.Name}, activeEvent) + "-" + lifecycleResolved
+
+	callCtx, cancel := p.withConfigurationTimeout(ctx, match.Configuration)
+	defer cancel()
+
+	labels := metrics.HookLabelsFor(hook.Annotations)
+	callStart := time.Now()
+	if _, err := p.kagentClient.CallAgent(callCtx, request); err != nil {
+		metrics.ObserveAgentCall(event.Type, metrics.ResultForError(err), labels, time.Since(callStart))
+		metrics.RecordProcessingError(metrics.ErrorCategoryAgent, hook.Namespace)
+		return fmt.Errorf("failed to call agent %s: %w", agentRef.Name, err)
+	}
+	metrics.ObserveAgentCall(event.Type, metrics.EventResultSuccess, labels, time.Since(callStart))
+
+	p.logger.Info("Notified agent of resolved event",
+		"hook", hook.Namespace+"/"+hook.Name,
+		"eventType", event.Type,
+		"resourceName", event.ResourceName,
+		"agentRef", agentRef)
+	return nil
+}
+
 // CleanupExpiredEvents cleans up expired events for all hooks
 func (p *Processor) CleanupExpiredEvents(ctx context.Context, hooks []*v1alpha2.Hook) error {
 	p.logger.V(1).Info("Cleaning up expired events", "hookCount", len(hooks))
 
+	if p.fallbackAgent != nil {
+		if err := p.deduplicationManager.CleanupExpiredEvents(fallbackHookRef); err != nil {
+			p.logger.Error(err, "Failed to cleanup expired fallback events")
+			metrics.RecordProcessingError(metrics.ErrorCategoryDedup, "")
+		}
+	}
+
 	for _, hook := range hooks {
 		hookRef := types.NamespacedName{
 			Namespace: hook.Namespace,
@@ -376,6 +1388,7 @@ func (p *Processor) CleanupExpiredEvents(ctx context.Context, hooks []*v1alpha2.
 
 		if err := p.deduplicationManager.CleanupExpiredEvents(hookRef); err != nil {
 			p.logger.Error(err, "Failed to cleanup expired events", "hook", hookRef)
+			metrics.RecordProcessingError(metrics.ErrorCategoryDedup, hookRef.Namespace)
 			// Continue cleaning up other hooks even if one fails
 			continue
 		}
@@ -396,11 +1409,17 @@ func (p *Processor) ProcessEventWorkflow(ctx context.Context, eventTypes []strin
 		return fmt.Errorf("failed to start event watching: %w", err)
 	}
 
-	// Set up periodic cleanup and status updates
-	cleanupTicker := time.NewTicker(5 * time.Minute)
-	statusTicker := time.NewTicker(1 * time.Minute)
-	defer cleanupTicker.Stop()
-	defer statusTicker.Stop()
+	processCtx, drainer, stopDraining := p.drainingContext(ctx)
+	defer stopDraining()
+	p.aggregator.Start(processCtx, drainer)
+	defer p.stopAggregation()
+
+	// Set up periodic cleanup and status updates. The first run of each is jittered so
+	// workflows started together do not update statuses in lockstep.
+	cleanupTimer := time.NewTimer(firstTickDelay(p.cleanupInterval))
+	statusTimer := time.NewTimer(firstTickDelay(p.statusInterval))
+	defer cleanupTimer.Stop()
+	defer statusTimer.Stop()
 
 	for {
 		select {
@@ -414,25 +1433,82 @@ func (p *Processor) ProcessEventWorkflow(ctx context.Context, eventTypes []strin
 				return nil
 			}
 
+			// Stop accepting new events once shutdown has started
+			if !drainer.begin() {
+				continue
+			}
+
 			// Process the event
-			if err := p.ProcessEvent(ctx, event, hooks); err != nil {
+			err := p.ProcessEvent(processCtx, event, hooks)
+			drainer.end()
+			if err != nil {
 				p.logger.Error(err, "Failed to process event",
 					"eventType", event.Type,
 					"resourceName", event.ResourceName)
 				// Continue processing other events
 			}
 
-		case <-cleanupTicker.C:
+		case <-cleanupTimer.C:
 			// Periodic cleanup of expired events
 			if err := p.CleanupExpiredEvents(ctx, hooks); err != nil {
 				p.logger.Error(err, "Failed to cleanup expired events")
 			}
+			cleanupTimer.Reset(p.cleanupInterval)
 
-		case <-statusTicker.C:
+		case <-statusTimer.C:
 			// Periodic status updates
 			if err := p.UpdateHookStatuses(ctx, hooks); err != nil {
 				p.logger.Error(err, "Failed to update hook statuses")
 			}
+			statusTimer.Reset(p.statusInterval)
 		}
 	}
 }
+
+// drainingContext returns the context events are processed with and the drainer tracking
+// them. Without a drain timeout this is ctx. Otherwise the returned context outlives ctx:
+// once ctx is cancelled, in-flight events get up to drainTimeout to finish before it is
+// cancelled too. The returned function releases the context when the workflow ends,
+// first waiting for the drain when ctx was cancelled so that aggregated flushes still in
+// flight can finish.
+func (p *Processor) drainingContext(ctx context.Context) (context.Context, *drainer, context.CancelFunc) {
+	d := &drainer{timeout: p.drainTimeout}
+	if p.drainTimeout <= 0 {
+		return ctx, d, func() {}
+	}
+
+	processCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		select {
+		case <-processCtx.Done():
+			return
+		case <-ctx.Done():
+		}
+
+		drained, aborted := d.drain(cancel)
+		if aborted > 0 {
+			p.logger.Info("Aborted in-flight events after drain timeout",
+				"drained", drained,
+				"aborted", aborted,
+				"drainTimeout", p.drainTimeout)
+			return
+		}
+		p.logger.Info("Drained in-flight events", "drained", drained)
+	}()
+	return processCtx, d, func() {
+		if ctx.Err() != nil {
+			<-finished
+		}
+		cancel()
+	}
+}
+
+// firstTickDelay returns a random delay in (0, interval] for the first run of a periodic task
+func firstTickDelay(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return interval
+	}
+	return time.Duration(rand.Int64N(int64(interval))) + 1
+}
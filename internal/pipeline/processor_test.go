@@ -2,17 +2,29 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kagent-dev/khook/api/v1alpha2"
+	"github.com/kagent-dev/khook/internal/deduplication"
+	khookerrors "github.com/kagent-dev/khook/internal/errors"
 	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/metrics"
+	"github.com/kagent-dev/khook/internal/notify"
+	"github.com/kagent-dev/khook/internal/severity"
 )
 
 // Mock implementations for testing
@@ -64,13 +76,28 @@ func (m *MockDeduplicationManager) GetActiveEvents(hookRef types.NamespacedName)
 	return args.Get(0).([]interfaces.ActiveEvent)
 }
 
+func (m *MockDeduplicationManager) GetActiveEvent(hookRef types.NamespacedName, event interfaces.Event) (interfaces.ActiveEvent, bool) {
+	args := m.Called(hookRef, event)
+	return args.Get(0).(interfaces.ActiveEvent), args.Bool(1)
+}
+
 func (m *MockDeduplicationManager) GetActiveEventsWithStatus(hookRef types.NamespacedName) []interfaces.ActiveEvent {
 	args := m.Called(hookRef)
 	return args.Get(0).([]interfaces.ActiveEvent)
 }
 
-func (m *MockDeduplicationManager) MarkNotified(hookRef types.NamespacedName, event interfaces.Event) {
-	m.Called(hookRef, event)
+func (m *MockDeduplicationManager) MarkNotified(hookRef types.NamespacedName, event interfaces.Event, response *interfaces.AgentResponse) {
+	m.Called(hookRef, event, response)
+}
+
+func (m *MockDeduplicationManager) ResolveEvent(hookRef types.NamespacedName, eventType, namespace, resourceName string) bool {
+	args := m.Called(hookRef, eventType, namespace, resourceName)
+	return args.Bool(0)
+}
+
+func (m *MockDeduplicationManager) ConfirmActive(hookRef types.NamespacedName, event interfaces.Event, minActive time.Duration) bool {
+	args := m.Called(hookRef, event, minActive)
+	return args.Bool(0)
 }
 
 type MockKagentClient struct {
@@ -129,6 +156,26 @@ func (m *MockStatusManager) RecordDuplicateEvent(ctx context.Context, hook *v1al
 	return args.Error(0)
 }
 
+func (m *MockStatusManager) RecordCrossHookDuplicate(ctx context.Context, hook *v1alpha2.Hook, event interfaces.Event, agentRef, callingHook types.NamespacedName) error {
+	args := m.Called(ctx, hook, event, agentRef, callingHook)
+	return args.Error(0)
+}
+
+func (m *MockStatusManager) RecordRateLimited(ctx context.Context, hook *v1alpha2.Hook, event interfaces.Event, tripped bool) error {
+	args := m.Called(ctx, hook, event, tripped)
+	return args.Error(0)
+}
+
+func (m *MockStatusManager) RecordHookConflict(ctx context.Context, hook *v1alpha2.Hook, message string) error {
+	args := m.Called(ctx, hook, message)
+	return args.Error(0)
+}
+
+func (m *MockStatusManager) RecordMissingAgent(ctx context.Context, hook *v1alpha2.Hook, message string) error {
+	args := m.Called(ctx, hook, message)
+	return args.Error(0)
+}
+
 func (m *MockStatusManager) GetHookStatus(ctx context.Context, hookRef types.NamespacedName) (*v1alpha2.HookStatus, error) {
 	args := m.Called(ctx, hookRef)
 	if args.Get(0) == nil {
@@ -201,12 +248,15 @@ func TestProcessor_ProcessEvent_Success(t *testing.T) {
 	// Setup expectations
 	mockDeduplicationManager.On("ShouldProcessEvent", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event).Return(true)
 	mockDeduplicationManager.On("RecordEvent", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
 	mockStatusManager.On("RecordEventFiring", ctx, hook, event, types.NamespacedName{Name: "test-agent", Namespace: "default"}).Return(nil)
 
 	expectedResponse := &interfaces.AgentResponse{
 		Success:   true,
 		Message:   "Success",
 		RequestId: "test-request-id",
+		SessionID: "test-request-id",
+		Summary:   "Restarted the pod",
 	}
 	mockKagentClient.On("CallAgent", ctx, mock.MatchedBy(func(req interfaces.AgentRequest) bool {
 		return req.AgentRef.Name == "test-agent" &&
@@ -215,7 +265,8 @@ func TestProcessor_ProcessEvent_Success(t *testing.T) {
 	})).Return(expectedResponse, nil)
 
 	mockStatusManager.On("RecordAgentCallSuccess", ctx, hook, event, types.NamespacedName{Name: "test-agent", Namespace: "default"}, "test-request-id").Return(nil)
-	mockDeduplicationManager.On("MarkNotified", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event).Return()
+	// The agent's session and reply are kept with the event for its status
+	mockDeduplicationManager.On("MarkNotified", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event, expectedResponse).Return()
 
 	// Execute
 	err := processor.ProcessEvent(ctx, event, hooks)
@@ -254,6 +305,8 @@ func TestProcessor_ProcessEvent_DuplicateEvent(t *testing.T) {
 
 	// Setup expectations - event should be ignored due to deduplication
 	mockDeduplicationManager.On("ShouldProcessEvent", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event).Return(false)
+	mockDeduplicationManager.On("RecordEvent", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
 	mockStatusManager.On("RecordDuplicateEvent", ctx, hook, event).Return(nil)
 
 	// Execute
@@ -296,6 +349,7 @@ func TestProcessor_ProcessEvent_AgentCallFailure(t *testing.T) {
 	// Setup expectations
 	mockDeduplicationManager.On("ShouldProcessEvent", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event).Return(true)
 	mockDeduplicationManager.On("RecordEvent", types.NamespacedName{Name: "test-hook", Namespace: "default"}, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
 	mockStatusManager.On("RecordEventFiring", ctx, hook, event, types.NamespacedName{Name: "test-agent", Namespace: "default"}).Return(nil)
 	mockKagentClient.On("CallAgent", ctx, mock.AnythingOfType("interfaces.AgentRequest")).Return(nil, agentError)
 	mockStatusManager.On("RecordAgentCallFailure", ctx, hook, event, types.NamespacedName{Name: "test-agent", Namespace: "default"}, agentError).Return(nil)
@@ -311,6 +365,501 @@ func TestProcessor_ProcessEvent_AgentCallFailure(t *testing.T) {
 	mockStatusManager.AssertExpectations(t)
 }
 
+func TestProcessor_ProcessEventWorkflow_MatchTimeout(t *testing.T) {
+	mockEventWatcher := &MockEventWatcher{}
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+
+	processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithMatchTimeout(50*time.Millisecond))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{
+			EventType: "pod-restart",
+			AgentRef:  v1alpha2.ObjectReference{Name: "test-agent"},
+			Prompt:    "Handle pod restart",
+		},
+	})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+	hung := createTestEvent("pod-restart", "hung-pod", "default")
+	next := createTestEvent("pod-restart", "next-pod", "default")
+
+	eventCh := make(chan interfaces.Event, 2)
+	eventCh <- hung
+	eventCh <- next
+	mockEventWatcher.On("WatchEvents", mock.Anything).Return((<-chan interfaces.Event)(eventCh), nil)
+
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+	mockDeduplicationManager.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, next, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, mock.Anything, agentRef).Return(nil)
+
+	// The first agent call hangs until its context expires
+	mockKagentClient.On("CallAgent", mock.Anything, mock.MatchedBy(func(req interfaces.AgentRequest) bool {
+		return req.ResourceName == "hung-pod"
+	})).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(nil, context.DeadlineExceeded)
+	mockStatusManager.On("RecordAgentCallFailure", mock.Anything, hook, hung, agentRef, context.DeadlineExceeded).Return(nil)
+
+	processed := make(chan struct{})
+	mockKagentClient.On("CallAgent", mock.Anything, mock.MatchedBy(func(req interfaces.AgentRequest) bool {
+		return req.ResourceName == "next-pod"
+	})).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, next, agentRef, "req").Return(nil).
+		Run(func(mock.Arguments) { close(processed) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- processor.ProcessEventWorkflow(ctx, []string{"pod-restart"}, []*v1alpha2.Hook{hook}) }()
+
+	select {
+	case <-processed:
+	case <-ctx.Done():
+		t.Fatal("event loop was starved by the hung agent call")
+	}
+	cancel()
+	<-done
+
+	mockStatusManager.AssertCalled(t, "RecordAgentCallFailure", mock.Anything, hook, hung, agentRef, context.DeadlineExceeded)
+}
+
+func TestProcessor_ProcessEvent_AgentCallTimeout(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithMatchTimeout(time.Minute))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{
+			EventType: "pod-restart",
+			AgentRef:  v1alpha2.ObjectReference{Name: "fast-agent"},
+			Prompt:    "Handle pod restart",
+		},
+		{
+			EventType:        "pod-restart",
+			AgentRef:         v1alpha2.ObjectReference{Name: "deep-agent"},
+			Prompt:           "Analyze pod restart",
+			AgentCallTimeout: &metav1.Duration{Duration: 20 * time.Minute},
+		},
+	})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	event := createTestEvent("pod-restart", "test-pod", "default")
+
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+	mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, mock.Anything, "req").Return(nil)
+
+	var mu sync.Mutex
+	deadlines := map[string]time.Duration{}
+	timeouts := map[string]time.Duration{}
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		deadline, ok := args.Get(0).(context.Context).Deadline()
+		require.True(t, ok)
+		request := args.Get(1).(interfaces.AgentRequest)
+		mu.Lock()
+		defer mu.Unlock()
+		deadlines[request.AgentRef.Name] = time.Until(deadline)
+		timeouts[request.AgentRef.Name] = request.Timeout
+	}).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+
+	assert.LessOrEqual(t, deadlines["fast-agent"], time.Minute)
+	assert.Greater(t, deadlines["deep-agent"], time.Minute)
+	assert.LessOrEqual(t, deadlines["deep-agent"], 20*time.Minute)
+	assert.Equal(t, time.Duration(0), timeouts["fast-agent"])
+	assert.Equal(t, 20*time.Minute, timeouts["deep-agent"])
+}
+
+func TestProcessor_ProcessEvent_AggregationWindow(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:         "pod-restart",
+		AgentRef:          v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:            "Pods restarted:{{range .Resources}} {{.ResourceName}}{{end}}",
+		AggregationWindow: &metav1.Duration{Duration: 50 * time.Millisecond},
+	}})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	events := []interfaces.Event{
+		createTestEvent("pod-restart", "pod-a", "default"),
+		createTestEvent("pod-restart", "pod-b", "default"),
+	}
+
+	notified := make(chan struct{}, len(events))
+	for _, event := range events {
+		mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+		mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+		mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Run(func(mock.Arguments) {
+			notified <- struct{}{}
+		}).Return()
+		mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, mock.Anything).Return(nil)
+		mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, mock.Anything, "req").Return(nil)
+	}
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+
+	requests := make(chan interfaces.AgentRequest, 2)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		requests <- args.Get(1).(interfaces.AgentRequest)
+	}).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	for _, event := range events {
+		require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+	}
+	mockKagentClient.AssertNotCalled(t, "CallAgent", mock.Anything, mock.Anything)
+
+	select {
+	case request := <-requests:
+		assert.Equal(t, "Pods restarted: pod-a pod-b", request.Prompt)
+		assert.Equal(t, []string{"pod-a", "pod-b"}, request.Context["resources"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("aggregated events were not sent to the agent")
+	}
+
+	for range events {
+		select {
+		case <-notified:
+		case <-time.After(5 * time.Second):
+			t.Fatal("aggregated events were not marked notified")
+		}
+	}
+	assert.Empty(t, requests, "expected a single agent call for the batch")
+	mockStatusManager.AssertNumberOfCalls(t, "RecordAgentCallSuccess", len(events))
+}
+
+func TestProcessor_ProcessEventWorkflow_AggregationStoppedWithWorkflow(t *testing.T) {
+	mockEventWatcher := &MockEventWatcher{}
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:         "pod-restart",
+		AgentRef:          v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:            "Pods restarted",
+		AggregationWindow: &metav1.Duration{Duration: 100 * time.Millisecond},
+	}})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	event := createTestEvent("pod-restart", "pod-a", "default")
+
+	eventCh := make(chan interfaces.Event, 1)
+	eventCh <- event
+	mockEventWatcher.On("WatchEvents", mock.Anything).Return((<-chan interfaces.Event)(eventCh), nil)
+
+	matched := make(chan struct{})
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+	mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, mock.Anything).Return(nil).
+		Run(func(mock.Arguments) { close(matched) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- processor.ProcessEventWorkflow(ctx, []string{"pod-restart"}, []*v1alpha2.Hook{hook}) }()
+
+	select {
+	case <-matched:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not matched")
+	}
+	// Stopping the workflow, as editing or deleting the hook does, cancels the pending batch
+	cancel()
+	<-done
+
+	time.Sleep(200 * time.Millisecond)
+	mockKagentClient.AssertNotCalled(t, "CallAgent", mock.Anything, mock.Anything)
+}
+
+func TestProcessor_ProcessEvent_AggregationAgentDeduplication(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	agentCalls := NewAgentCallDeduplicator(5 * time.Minute)
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithAgentDeduplication(agentCalls))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:         "pod-restart",
+		AgentRef:          v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:            "Pods restarted:{{range .Resources}} {{.ResourceName}}{{end}}",
+		AggregationWindow: &metav1.Duration{Duration: 50 * time.Millisecond},
+	}})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+	otherHook := types.NamespacedName{Name: "other-hook", Namespace: "default"}
+	sent, claimed := createTestEvent("pod-restart", "pod-a", "default"), createTestEvent("pod-restart", "pod-b", "default")
+
+	// Another hook already sent pod-b to the agent
+	_, allowed := agentCalls.Claim(agentCallKeyFor(agentRef, claimed), otherHook, time.Now())
+	require.True(t, allowed)
+
+	duplicate := make(chan struct{})
+	for _, event := range []interfaces.Event{sent, claimed} {
+		mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+		mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+		mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, mock.Anything).Return(nil)
+	}
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, sent, mock.Anything).Return()
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, sent, agentRef, "req").Return(nil)
+	mockStatusManager.On("RecordCrossHookDuplicate", mock.Anything, hook, claimed, agentRef, otherHook).Return(nil).
+		Run(func(mock.Arguments) { close(duplicate) })
+
+	requests := make(chan interfaces.AgentRequest, 1)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		requests <- args.Get(1).(interfaces.AgentRequest)
+	}).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	for _, event := range []interfaces.Event{sent, claimed} {
+		require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+	}
+
+	select {
+	case request := <-requests:
+		assert.Equal(t, "Pods restarted: pod-a", request.Prompt)
+	case <-time.After(5 * time.Second):
+		t.Fatal("aggregated events were not sent to the agent")
+	}
+	select {
+	case <-duplicate:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event claimed by another hook was not recorded as a duplicate")
+	}
+}
+
+func TestProcessor_ProcessEvent_MinActiveDuration(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:         "pod-pending",
+		AgentRef:          v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:            "Pod {{.ResourceName}} is pending",
+		MinActiveDuration: &metav1.Duration{Duration: time.Minute},
+	}})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+	event := createTestEvent("pod-pending", "test-pod", "default")
+	ctx := context.Background()
+
+	// A new event is held without recording it or calling the agent
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+	mockDeduplicationManager.On("ConfirmActive", hookRef, event, time.Minute).Return(false).Once()
+	require.NoError(t, processor.ProcessEvent(ctx, event, []*v1alpha2.Hook{hook}))
+	mockDeduplicationManager.AssertNotCalled(t, "RecordEvent", hookRef, event)
+	mockKagentClient.AssertNotCalled(t, "CallAgent", mock.Anything, mock.Anything)
+
+	// Once confirmed, the event fires as usual
+	mockDeduplicationManager.On("ConfirmActive", hookRef, event, time.Minute).Return(true).Once()
+	mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", ctx, hook, event, agentRef).Return(nil)
+	mockKagentClient.On("CallAgent", ctx, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req-1"}, nil)
+	mockStatusManager.On("RecordAgentCallSuccess", ctx, hook, event, agentRef, "req-1").Return(nil)
+	require.NoError(t, processor.ProcessEvent(ctx, event, []*v1alpha2.Hook{hook}))
+
+	mockDeduplicationManager.AssertExpectations(t)
+	mockKagentClient.AssertExpectations(t)
+	mockStatusManager.AssertExpectations(t)
+}
+
+func TestProcessor_ProcessEventWorkflow_Drain(t *testing.T) {
+	tests := []struct {
+		name         string
+		drainTimeout time.Duration
+		release      bool
+		expectErr    error
+	}{
+		{name: "in-flight call finishes within the drain timeout", drainTimeout: 5 * time.Second, release: true},
+		{name: "in-flight call is cancelled after the drain timeout", drainTimeout: 50 * time.Millisecond, expectErr: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventWatcher := &MockEventWatcher{}
+			mockDeduplicationManager := &MockDeduplicationManager{}
+			mockKagentClient := &MockKagentClient{}
+			mockStatusManager := &MockStatusManager{}
+
+			processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+				WithDrainTimeout(tt.drainTimeout))
+
+			hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+				{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+			})
+			hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+			agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+			event := createTestEvent("pod-restart", "test-pod", "default")
+
+			eventCh := make(chan interfaces.Event, 1)
+			eventCh <- event
+			mockEventWatcher.On("WatchEvents", mock.Anything).Return((<-chan interfaces.Event)(eventCh), nil)
+			mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+			mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+			mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+			mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+			mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, agentRef).Return(nil)
+			mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, agentRef, "req").Return(nil)
+			mockStatusManager.On("RecordAgentCallFailure", mock.Anything, hook, event, agentRef, mock.Anything).Return(nil)
+
+			// The agent call completes when released, or fails once its context is cancelled
+			calling := make(chan struct{})
+			release := make(chan struct{})
+			var callErr error
+			call := mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				close(calling)
+				select {
+				case <-release:
+				case <-args.Get(0).(context.Context).Done():
+					callErr = args.Get(0).(context.Context).Err()
+				}
+			})
+			if tt.release {
+				call.Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+			} else {
+				call.Return(nil, context.Canceled)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- processor.ProcessEventWorkflow(ctx, []string{"pod-restart"}, []*v1alpha2.Hook{hook}) }()
+
+			<-calling
+			cancel()
+			if tt.release {
+				// The call keeps running after shutdown starts
+				time.Sleep(20 * time.Millisecond)
+				close(release)
+			}
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("workflow did not stop after draining")
+			}
+
+			assert.ErrorIs(t, callErr, tt.expectErr)
+			if tt.expectErr == nil {
+				mockStatusManager.AssertCalled(t, "RecordAgentCallSuccess", mock.Anything, hook, event, agentRef, "req")
+			} else {
+				mockStatusManager.AssertCalled(t, "RecordAgentCallFailure", mock.Anything, hook, event, agentRef, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProcessor_ProcessEventWorkflow_Intervals(t *testing.T) {
+	mockEventWatcher := &MockEventWatcher{}
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+
+	processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithIntervals(time.Hour, 10*time.Millisecond))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	eventCh := make(chan interfaces.Event)
+	mockEventWatcher.On("WatchEvents", mock.Anything).Return((<-chan interfaces.Event)(eventCh), nil)
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return([]interfaces.ActiveEvent{})
+
+	updates := make(chan struct{}, 10)
+	mockStatusManager.On("UpdateHookStatus", mock.Anything, hook, []interfaces.ActiveEvent{}).Return(nil).
+		Run(func(mock.Arguments) { updates <- struct{}{} })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- processor.ProcessEventWorkflow(ctx, []string{"pod-restart"}, []*v1alpha2.Hook{hook}) }()
+
+	// Statuses are updated repeatedly at the configured interval
+	for i := 0; i < 2; i++ {
+		select {
+		case <-updates:
+		case <-ctx.Done():
+			t.Fatal("hook statuses were not updated at the configured interval")
+		}
+	}
+	cancel()
+	<-done
+}
+
+func TestFirstTickDelay(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		delay := firstTickDelay(time.Minute)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, time.Minute)
+	}
+}
+
+func TestProcessor_ProcessEvent_MatchConcurrency(t *testing.T) {
+	dedup := deduplication.NewManager()
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, dedup, mockKagentClient, mockStatusManager, WithMatchConcurrency(4))
+
+	var hooks []*v1alpha2.Hook
+	for _, name := range []string{"hook-a", "hook-b", "hook-c", "hook-d"} {
+		hooks = append(hooks, createTestHook(name, "default", []v1alpha2.EventConfiguration{
+			{
+				EventType: "pod-restart",
+				AgentRef:  v1alpha2.ObjectReference{Name: "agent-" + name},
+				Prompt:    "Handle pod restart",
+			},
+		}))
+	}
+	event := createTestEvent("pod-restart", "test-pod", "default")
+
+	// Each agent call waits until every hook is being processed, which only
+	// completes when the matches run in parallel
+	var started sync.WaitGroup
+	started.Add(len(hooks))
+	agentErr := errors.New("agent unavailable")
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		started.Done()
+		started.Wait()
+	}).Return(nil, agentErr)
+	mockStatusManager.On("RecordEventFiring", mock.Anything, mock.Anything, event, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallFailure", mock.Anything, mock.Anything, event, mock.Anything, agentErr).Return(nil)
+
+	done := make(chan error, 1)
+	go func() { done <- processor.ProcessEvent(context.Background(), event, hooks) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("matches were not processed in parallel")
+	}
+
+	// Every failure is reported and every hook's event is tracked
+	require.Error(t, err)
+	for _, hook := range hooks {
+		assert.Contains(t, err.Error(), "failed to call agent agent-"+hook.Name)
+		hookRef := types.NamespacedName{Name: hook.Name, Namespace: hook.Namespace}
+		assert.Len(t, dedup.GetActiveEvents(hookRef), 1)
+	}
+}
+
 func TestProcessor_ProcessEvent_MultipleHooks(t *testing.T) {
 	// Setup mocks
 	mockEventWatcher := &MockEventWatcher{}
@@ -349,6 +898,7 @@ func TestProcessor_ProcessEvent_MultipleHooks(t *testing.T) {
 	// Setup expectations for both hooks
 	mockDeduplicationManager.On("ShouldProcessEvent", types.NamespacedName{Name: "hook1", Namespace: "default"}, event).Return(true)
 	mockDeduplicationManager.On("RecordEvent", types.NamespacedName{Name: "hook1", Namespace: "default"}, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
 	mockStatusManager.On("RecordEventFiring", ctx, hook1, event, types.NamespacedName{Name: "agent1", Namespace: "default"}).Return(nil)
 
 	mockDeduplicationManager.On("ShouldProcessEvent", types.NamespacedName{Name: "hook2", Namespace: "default"}, event).Return(true)
@@ -368,8 +918,8 @@ func TestProcessor_ProcessEvent_MultipleHooks(t *testing.T) {
 
 	mockStatusManager.On("RecordAgentCallSuccess", ctx, hook1, event, types.NamespacedName{Name: "agent1", Namespace: "default"}, "req1").Return(nil)
 	mockStatusManager.On("RecordAgentCallSuccess", ctx, hook2, event, types.NamespacedName{Name: "agent2", Namespace: "default"}, "req2").Return(nil)
-	mockDeduplicationManager.On("MarkNotified", types.NamespacedName{Name: "hook1", Namespace: "default"}, event).Return()
-	mockDeduplicationManager.On("MarkNotified", types.NamespacedName{Name: "hook2", Namespace: "default"}, event).Return()
+	mockDeduplicationManager.On("MarkNotified", types.NamespacedName{Name: "hook1", Namespace: "default"}, event, mock.Anything).Return()
+	mockDeduplicationManager.On("MarkNotified", types.NamespacedName{Name: "hook2", Namespace: "default"}, event, mock.Anything).Return()
 
 	// Execute
 	err := processor.ProcessEvent(ctx, event, hooks)
@@ -416,89 +966,1890 @@ func TestProcessor_ProcessEvent_NoMatchingHooks(t *testing.T) {
 	mockStatusManager.AssertNotCalled(t, "RecordEventFiring")
 }
 
-func TestProcessor_ExpandPromptTemplate(t *testing.T) {
-	processor := &Processor{}
+func TestProcessor_ProcessEvent_FallbackAgent(t *testing.T) {
+	fallbackAgent := types.NamespacedName{Name: "fallback-agent", Namespace: "kagent"}
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "restart"},
+	})
+	hooks := []*v1alpha2.Hook{hook}
 
-	event := interfaces.Event{
-		Type:         "pod-restart",
-		ResourceName: "test-pod",
-		Namespace:    "default",
-		Reason:       "BackOff",
-		Message:      "Container failed to start",
-		Timestamp:    time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	t.Run("unmatched critical event calls fallback agent", func(t *testing.T) {
+		mockDeduplicationManager := &MockDeduplicationManager{}
+		mockKagentClient := &MockKagentClient{}
+		processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, &MockStatusManager{},
+			WithFallbackAgent(fallbackAgent))
+
+		event := createTestEvent("oom-kill", "test-pod", "default")
+		mockDeduplicationManager.On("ShouldProcessEvent", fallbackHookRef, event).Return(true)
+		mockDeduplicationManager.On("RecordEvent", fallbackHookRef, event).Return(nil)
+		mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+		mockDeduplicationManager.On("MarkNotified", fallbackHookRef, event, mock.Anything).Return()
+		mockKagentClient.On("CallAgent", mock.Anything, mock.MatchedBy(func(request interfaces.AgentRequest) bool {
+			return request.AgentRef == fallbackAgent && request.Context["fallback"] == true
+		})).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+		require.NoError(t, processor.ProcessEvent(context.Background(), event, hooks))
+		mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 1)
+		mockDeduplicationManager.AssertExpectations(t)
+	})
+
+	t.Run("unmatched low severity event is ignored", func(t *testing.T) {
+		mockDeduplicationManager := &MockDeduplicationManager{}
+		mockKagentClient := &MockKagentClient{}
+		processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, &MockStatusManager{},
+			WithFallbackAgent(fallbackAgent))
+
+		require.NoError(t, processor.ProcessEvent(context.Background(), createTestEvent("deployment-scaled", "web", "default"), hooks))
+		mockKagentClient.AssertNotCalled(t, "CallAgent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("matched event does not call fallback agent", func(t *testing.T) {
+		mockDeduplicationManager := &MockDeduplicationManager{}
+		mockKagentClient := &MockKagentClient{}
+		mockStatusManager := &MockStatusManager{}
+		processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+			WithFallbackAgent(fallbackAgent))
+
+		hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+		agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+		event := createTestEvent("pod-restart", "test-pod", "default")
+		mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+		mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+		mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+		mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+		mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, agentRef).Return(nil)
+		mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, agentRef, "req").Return(nil)
+		mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil).
+			Run(func(args mock.Arguments) {
+				assert.Equal(t, agentRef, args.Get(1).(interfaces.AgentRequest).AgentRef)
+			})
+
+		require.NoError(t, processor.ProcessEvent(context.Background(), event, hooks))
+		mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 1)
+	})
+}
+
+func TestProcessor_ProcessEvent_SlackNotification(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+	}{
+		{name: "delivered", statusCode: http.StatusOK},
+		{name: "slack failure does not fail the pipeline", statusCode: http.StatusBadRequest},
 	}
 
-	template := "Event {{.EventType}} occurred for {{.ResourceName}} in {{.Namespace}} at {{.Timestamp}}"
-	expected := "Event pod-restart occurred for test-pod in default at 2023-01-01T12:00:00Z"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received := make(chan string, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var message struct {
+					Text string `json:"text"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+				w.WriteHeader(tt.statusCode)
+				received <- message.Text
+			}))
+			defer server.Close()
+
+			mockDeduplicationManager := &MockDeduplicationManager{}
+			mockKagentClient := &MockKagentClient{}
+			mockStatusManager := &MockStatusManager{}
+			processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+			hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+				{
+					EventType:       "oom-kill",
+					AgentRef:        v1alpha2.ObjectReference{Name: "test-agent"},
+					Prompt:          "OOM",
+					SlackWebhookURL: server.URL,
+				},
+			})
+			hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+			agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+			event := createTestEvent("oom-kill", "test-pod", "default")
+
+			mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+			mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+			mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+			mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+			mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, agentRef).Return(nil)
+			mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, agentRef, "req").Return(nil)
+			mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+			require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+			mockKagentClient.AssertExpectations(t)
+
+			select {
+			case text := <-received:
+				assert.Equal(t, "*[CRITICAL] oom-kill* on `test-pod` in namespace `default` (hook `test-hook`)\n> Test message", text)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for Slack notification")
+			}
+		})
+	}
+}
 
-	result := processor.expandPromptTemplate(template, event)
-	assert.Equal(t, expected, result)
+type recordingSink struct {
+	events chan notify.FiringEvent
+	err    error
 }
 
-func TestProcessor_UpdateHookStatuses(t *testing.T) {
-	// Setup mocks
-	mockEventWatcher := &MockEventWatcher{}
+func (s *recordingSink) Notify(ctx context.Context, event notify.FiringEvent) error {
+	s.events <- event
+	return s.err
+}
+
+func TestProcessor_ProcessEvent_Sinks(t *testing.T) {
+	failing := &recordingSink{events: make(chan notify.FiringEvent, 1), err: errors.New("sink unavailable")}
+	working := &recordingSink{events: make(chan notify.FiringEvent, 1)}
+
 	mockDeduplicationManager := &MockDeduplicationManager{}
 	mockKagentClient := &MockKagentClient{}
 	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithSinks(failing, working))
 
-	processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager)
-
-	// Create test data
 	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
-		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+		{EventType: "oom-kill", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "OOM"},
 	})
-	hooks := []*v1alpha2.Hook{hook}
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+	event := createTestEvent("oom-kill", "test-pod", "default")
+
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+	mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, agentRef).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, agentRef, "req").Return(nil)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	// A failing sink neither fails the pipeline nor stops the other sinks
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+	mockKagentClient.AssertExpectations(t)
 
-	activeEvents := []interfaces.ActiveEvent{
+	expected := notify.FiringEvent{
+		Hook:         "test-hook",
+		Namespace:    "default",
+		EventType:    "oom-kill",
+		ResourceName: "test-pod",
+		Severity:     "critical",
+		Message:      "Test message",
+	}
+	for _, sink := range []*recordingSink{failing, working} {
+		select {
+		case received := <-sink.events:
+			assert.Equal(t, expected, received)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for sink notification")
+		}
+	}
+}
+
+func TestProcessor_ProcessEvent_AgentRefTemplate(t *testing.T) {
+	newHook := func(agentName string) *v1alpha2.Hook {
+		return createTestHook("test-hook", "prod", []v1alpha2.EventConfiguration{
+			{
+				EventType:        "pod-restart",
+				AgentRef:         v1alpha2.ObjectReference{Name: agentName},
+				Prompt:           "restart",
+				AgentRefTemplate: true,
+			},
+		})
+	}
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "prod"}
+
+	t.Run("name expanded with event context", func(t *testing.T) {
+		mockDeduplicationManager := &MockDeduplicationManager{}
+		mockKagentClient := &MockKagentClient{}
+		mockStatusManager := &MockStatusManager{}
+		processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+		hook := newHook("{{.Namespace}}-agent")
+		agentRef := types.NamespacedName{Name: "prod-agent", Namespace: "prod"}
+		event := createTestEvent("pod-restart", "test-pod", "prod")
+		mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+		mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+		mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+		mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+		mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, agentRef).Return(nil)
+		mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, agentRef, "req").Return(nil)
+		mockKagentClient.On("CallAgent", mock.Anything, mock.MatchedBy(func(request interfaces.AgentRequest) bool {
+			return request.AgentRef == agentRef
+		})).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+		require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+		mockKagentClient.AssertExpectations(t)
+	})
+
+	t.Run("invalid expansion records failure", func(t *testing.T) {
+		mockDeduplicationManager := &MockDeduplicationManager{}
+		mockKagentClient := &MockKagentClient{}
+		mockStatusManager := &MockStatusManager{}
+		processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+		hook := newHook("{{.ResourceName}}")
+		event := createTestEvent("pod-restart", "Invalid_Pod", "prod")
+		mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+		mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+		mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+		mockStatusManager.On("RecordError", mock.Anything, hook, event, mock.Anything, mock.Anything).Return(nil)
+
+		err := processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid name")
+		mockStatusManager.AssertCalled(t, "RecordError", mock.Anything, hook, event, mock.Anything, mock.Anything)
+		mockKagentClient.AssertNotCalled(t, "CallAgent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProcessor_PreviewEvent(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	hooks := []*v1alpha2.Hook{
+		createTestHook("restart-hook", "default", []v1alpha2.EventConfiguration{
+			{
+				EventType: "pod-restart",
+				AgentRef:  v1alpha2.ObjectReference{Name: "restart-agent"},
+				Prompt:    "Pod {{.ResourceName}} restarted: {{.Reason}}",
+			},
+		}),
+		createTestHook("oom-hook", "default", []v1alpha2.EventConfiguration{
+			{
+				EventType: "oom-kill",
+				AgentRef:  v1alpha2.ObjectReference{Name: "oom-agent"},
+				Prompt:    "OOM",
+			},
+		}),
+	}
+	event := createTestEvent("pod-restart", "test-pod", "default")
+
+	requests, err := processor.PreviewEvent(event, hooks)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, types.NamespacedName{Name: "restart-agent", Namespace: "default"}, requests[0].AgentRef)
+	assert.Equal(t, "Pod test-pod restarted: TestReason", requests[0].Prompt)
+	assert.Equal(t, "restart-hook", requests[0].Context["hookName"])
+
+	// Previewing has no side effects
+	mockDeduplicationManager.AssertNotCalled(t, "ShouldProcessEvent", mock.Anything, mock.Anything)
+	mockStatusManager.AssertNotCalled(t, "RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockKagentClient.AssertNotCalled(t, "CallAgent", mock.Anything, mock.Anything)
+
+	requests, err = processor.PreviewEvent(createTestEvent("probe-failed", "test-pod", "default"), hooks)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+}
+
+func TestProcessor_CreateAgentRequest_JSONOutputFormat(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
+
+	hook := createTestHook("restart-hook", "default", []v1alpha2.EventConfiguration{
 		{
 			EventType:    "pod-restart",
-			ResourceName: "test-pod",
-			FirstSeen:    time.Now(),
-			LastSeen:     time.Now(),
-			Status:       "firing",
+			AgentRef:     v1alpha2.ObjectReference{Name: "structured-agent"},
+			Prompt:       "Pod {{.ResourceName}} restarted",
+			OutputFormat: v1alpha2.OutputFormatJSON,
 		},
-	}
+	})
+	event := createTestEvent("pod-restart", "test-pod", "default")
+	event.Timestamp = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	requests, err := processor.PreviewEvent(event, []*v1alpha2.Hook{hook})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+
+	var prompt map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(requests[0].Prompt), &prompt))
+	assert.Equal(t, map[string]interface{}{
+		"prompt":       "Pod test-pod restarted",
+		"eventType":    "pod-restart",
+		"resourceName": "test-pod",
+		"namespace":    "default",
+		"reason":       "TestReason",
+		"message":      "Test message",
+		"timestamp":    "2024-01-15T10:30:00Z",
+		"uid":          "test-uid",
+		"metadata":     map[string]interface{}{"kind": "Pod"},
+		"hook":         map[string]interface{}{"name": "restart-hook", "namespace": "default"},
+	}, prompt)
+}
 
-	ctx := context.Background()
+func TestProcessor_CreateAgentRequest_ContextMetadataKeys(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
 
-	// Setup expectations
-	mockDeduplicationManager.On("GetActiveEventsWithStatus", types.NamespacedName{Name: "test-hook", Namespace: "default"}).Return(activeEvents)
-	mockStatusManager.On("UpdateHookStatus", ctx, hook, activeEvents).Return(nil)
+	hook := createTestHook("restart-hook", "default", []v1alpha2.EventConfiguration{
+		{
+			EventType: "pod-restart",
+			AgentRef:  v1alpha2.ObjectReference{Name: "all-metadata-agent"},
+			Prompt:    "Pod restarted",
+		},
+		{
+			EventType:           "pod-restart",
+			AgentRef:            v1alpha2.ObjectReference{Name: "lean-agent"},
+			Prompt:              "Pod restarted",
+			ResourceKind:        "Pod",
+			ContextMetadataKeys: []string{"kind", "count", "missing"},
+		},
+	})
+	event := createTestEvent("pod-restart", "test-pod", "default")
+	event.Metadata["count"] = "3"
+	event.Metadata["reportingInstance"] = "node1"
 
-	// Execute
-	err := processor.UpdateHookStatuses(ctx, hooks)
+	requests, err := processor.PreviewEvent(event, []*v1alpha2.Hook{hook})
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
 
-	// Assert
-	assert.NoError(t, err)
-	mockDeduplicationManager.AssertExpectations(t)
-	mockStatusManager.AssertExpectations(t)
+	assert.Equal(t, event.Metadata, requests[0].Context["metadata"])
+	assert.Equal(t, map[string]string{"kind": "Pod", "count": "3"}, requests[1].Context["metadata"])
 }
 
-func TestProcessor_CleanupExpiredEvents(t *testing.T) {
-	// Setup mocks
-	mockEventWatcher := &MockEventWatcher{}
-	mockDeduplicationManager := &MockDeduplicationManager{}
-	mockKagentClient := &MockKagentClient{}
-	mockStatusManager := &MockStatusManager{}
+func TestProcessor_CreateAgentRequest_SeverityPrompts(t *testing.T) {
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{
+			EventType: "pod-restart",
+			AgentRef:  v1alpha2.ObjectReference{Name: "test-agent"},
+			Prompt:    "Pod {{.ResourceName}} restarted",
+			Prompts: map[string]string{
+				"critical": "Critical: investigate {{.ResourceName}} in detail",
+				"low":      "FYI: {{.ResourceName}} restarted",
+			},
+		},
+	})
+	event := createTestEvent("pod-restart", "test-pod", "default")
 
-	processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+	// pod-restart is high severity by default, which has no prompt of its own
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
+	requests, err := processor.PreviewEvent(event, []*v1alpha2.Hook{hook})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "Pod test-pod restarted", requests[0].Prompt)
+
+	mapping, err := severity.NewMapping(map[string]string{"pod-restart": "critical"})
+	require.NoError(t, err)
+	processor = NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{},
+		WithSeverityMapping(mapping))
+	requests, err = processor.PreviewEvent(event, []*v1alpha2.Hook{hook})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "Critical: investigate test-pod in detail", requests[0].Prompt)
+}
+
+func TestProcessor_FindEventMatches_ResourceNamePattern(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
 
-	// Create test data
 	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
-		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+		{
+			EventType:           "pod-restart",
+			AgentRef:            v1alpha2.ObjectReference{Name: "payments-agent"},
+			Prompt:              "Payment pod restarted",
+			ResourceNamePattern: "payment-*",
+		},
+		{
+			EventType:           "pod-restart",
+			AgentRef:            v1alpha2.ObjectReference{Name: "worker-agent"},
+			Prompt:              "Worker pod restarted",
+			ResourceNamePattern: "re:^.+-worker-[0-9]+$",
+		},
+		{
+			EventType: "pod-restart",
+			AgentRef:  v1alpha2.ObjectReference{Name: "catch-all-agent"},
+			Prompt:    "Pod restarted",
+		},
 	})
 	hooks := []*v1alpha2.Hook{hook}
 
-	ctx := context.Background()
+	agentsFor := func(resourceName string) []string {
+		var agents []string
+		for _, match := range processor.findEventMatches(createTestEvent("pod-restart", resourceName, "default"), hooks) {
+			agents = append(agents, match.Configuration.AgentRef.Name)
+		}
+		return agents
+	}
 
-	// Setup expectations
-	mockDeduplicationManager.On("CleanupExpiredEvents", types.NamespacedName{Name: "test-hook", Namespace: "default"}).Return(nil)
+	assert.Equal(t, []string{"payments-agent", "catch-all-agent"}, agentsFor("payment-api-7d9f"))
+	assert.Equal(t, []string{"worker-agent", "catch-all-agent"}, agentsFor("checkout-worker-3"))
+	assert.Equal(t, []string{"catch-all-agent"}, agentsFor("frontend-5c8b"))
+}
 
-	// Execute
-	err := processor.CleanupExpiredEvents(ctx, hooks)
+func TestProcessor_FindEventMatches_ResourceKind(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
 
-	// Assert
-	assert.NoError(t, err)
-	mockDeduplicationManager.AssertExpectations(t)
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{
+			EventType:    "oom-kill",
+			AgentRef:     v1alpha2.ObjectReference{Name: "sandbox-agent"},
+			Prompt:       "Sandbox workload was OOM killed",
+			ResourceKind: "SandboxWorkload",
+		},
+		{
+			EventType:    "oom-kill",
+			AgentRef:     v1alpha2.ObjectReference{Name: "pod-agent"},
+			Prompt:       "Pod was OOM killed",
+			ResourceKind: "Pod",
+		},
+		{
+			EventType: "oom-kill",
+			AgentRef:  v1alpha2.ObjectReference{Name: "catch-all-agent"},
+			Prompt:    "OOM kill",
+		},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+
+	agentsFor := func(kind string) []string {
+		event := createTestEvent("oom-kill", "test-resource", "default")
+		event.Metadata["kind"] = kind
+		var agents []string
+		for _, match := range processor.findEventMatches(event, hooks) {
+			agents = append(agents, match.Configuration.AgentRef.Name)
+		}
+		return agents
+	}
+
+	assert.Equal(t, []string{"sandbox-agent", "catch-all-agent"}, agentsFor("SandboxWorkload"))
+	assert.Equal(t, []string{"pod-agent", "catch-all-agent"}, agentsFor("Pod"))
+	assert.Equal(t, []string{"catch-all-agent"}, agentsFor("StatefulSet"))
+}
+
+func TestProcessor_FindEventMatches_ProbeType(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{
+			EventType: "probe-failed",
+			AgentRef:  v1alpha2.ObjectReference{Name: "liveness-agent"},
+			Prompt:    "Liveness probe failed",
+			ProbeType: "liveness",
+		},
+		{
+			EventType: "probe-failed",
+			AgentRef:  v1alpha2.ObjectReference{Name: "catch-all-agent"},
+			Prompt:    "{{.ProbeType}} probe failed",
+		},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+
+	agentsFor := func(probeType string) []string {
+		event := createTestEvent("probe-failed", "test-resource", "default")
+		if probeType != "" {
+			event.Metadata["probeType"] = probeType
+		}
+		var agents []string
+		for _, match := range processor.findEventMatches(event, hooks) {
+			agents = append(agents, match.Configuration.AgentRef.Name)
+		}
+		return agents
+	}
+
+	assert.Equal(t, []string{"liveness-agent", "catch-all-agent"}, agentsFor("liveness"))
+	assert.Equal(t, []string{"catch-all-agent"}, agentsFor("readiness"))
+	assert.Equal(t, []string{"catch-all-agent"}, agentsFor(""))
+
+	event := createTestEvent("probe-failed", "test-resource", "default")
+	event.Metadata["probeType"] = "startup"
+	assert.Equal(t, "startup probe failed", processor.expandPromptTemplate("{{.ProbeType}} probe failed", event))
+}
+
+func TestProcessor_FindEventMatches_ExcludeReasons(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:      "pod-restart",
+		AgentRef:       v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:         "Pod restarted",
+		ExcludeReasons: []string{"BackOff"},
+	}})
+
+	event := createTestEvent("pod-restart", "flaky-job", "default")
+	event.Reason = "backoff"
+	assert.Empty(t, processor.findEventMatches(event, []*v1alpha2.Hook{hook}))
+
+	event.Reason = "Killing"
+	assert.Len(t, processor.findEventMatches(event, []*v1alpha2.Hook{hook}), 1)
+}
+
+// stubMetadataLookup returns fixed labels and annotations and counts lookups
+type stubMetadataLookup struct {
+	labels      map[string]string
+	annotations map[string]string
+	err         error
+	lookups     int
+}
+
+func (s *stubMetadataLookup) Lookup(ctx context.Context, event interfaces.Event) (map[string]string, map[string]string, error) {
+	s.lookups++
+	return s.labels, s.annotations, s.err
+}
+
+func TestProcessor_FilterByResourceMetadata(t *testing.T) {
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{
+			EventType:        "pod-restart",
+			AgentRef:         v1alpha2.ObjectReference{Name: "watched-agent"},
+			Prompt:           "Watched pod restarted",
+			ResourceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"khook.io/watch": "true"}},
+		},
+		{
+			EventType:           "pod-restart",
+			AgentRef:            v1alpha2.ObjectReference{Name: "team-agent"},
+			Prompt:              "Team pod restarted",
+			ResourceKind:        "Pod",
+			ResourceAnnotations: map[string]string{"team": "payments"},
+		},
+		{
+			EventType:    "pod-restart",
+			AgentRef:     v1alpha2.ObjectReference{Name: "catch-all-agent"},
+			Prompt:       "Pod restarted",
+			ResourceKind: "Pod",
+		},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+
+	agentsFor := func(lookup ResourceMetadataLookup) []string {
+		var opts []Option
+		if lookup != nil {
+			opts = append(opts, WithResourceMetadata(lookup))
+		}
+		processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{}, opts...)
+		event := createTestEvent("pod-restart", "test-pod", "default")
+		event.Metadata["kind"] = "Pod"
+		var agents []string
+		for _, match := range processor.filterByResourceMetadata(context.Background(), event, processor.findEventMatches(event, hooks)) {
+			agents = append(agents, match.Configuration.AgentRef.Name)
+		}
+		return agents
+	}
+
+	lookup := &stubMetadataLookup{
+		labels:      map[string]string{"khook.io/watch": "true"},
+		annotations: map[string]string{"team": "payments"},
+	}
+	assert.Equal(t, []string{"watched-agent", "team-agent", "catch-all-agent"}, agentsFor(lookup))
+	assert.Equal(t, 1, lookup.lookups, "metadata should be looked up once per event")
+
+	lookup = &stubMetadataLookup{labels: map[string]string{"khook.io/watch": "false"}}
+	assert.Equal(t, []string{"catch-all-agent"}, agentsFor(lookup))
+
+	// Selectors fail closed when the object cannot be looked up
+	assert.Equal(t, []string{"catch-all-agent"}, agentsFor(&stubMetadataLookup{err: fmt.Errorf("forbidden")}))
+	assert.Equal(t, []string{"catch-all-agent"}, agentsFor(nil))
+}
+
+func TestProcessor_ProcessEvent_ResourceSelectorDropsEvent(t *testing.T) {
+	// Strict mocks: a dropped event must not touch deduplication, the agent or the status
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{},
+		WithResourceMetadata(&stubMetadataLookup{}),
+		WithFallbackAgent(types.NamespacedName{Namespace: "kagent", Name: "fallback-agent"}))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:        "pod-restart",
+		AgentRef:         v1alpha2.ObjectReference{Name: "watched-agent"},
+		Prompt:           "Watched pod restarted",
+		ResourceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"khook.io/watch": "true"}},
+	}})
+
+	event := createTestEvent("pod-restart", "unlabeled-pod", "default")
+	event.Metadata["kind"] = "Pod"
+	assert.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+}
+
+func TestProcessor_FindEventMatches_MinEventCount(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:     "pod-restart",
+		AgentRef:      v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:        "Pod keeps restarting",
+		MinEventCount: 5,
+	}})
+
+	event := createTestEvent("pod-restart", "test-pod", "default")
+	event.Metadata["count"] = "4"
+	assert.Empty(t, processor.findEventMatches(event, []*v1alpha2.Hook{hook}))
+
+	event.Metadata["count"] = "50"
+	assert.Len(t, processor.findEventMatches(event, []*v1alpha2.Hook{hook}), 1)
+}
+
+func TestProcessor_FindEventMatches_SuspendedHook(t *testing.T) {
+	processor := NewProcessor(&MockEventWatcher{}, &MockDeduplicationManager{}, &MockKagentClient{}, &MockStatusManager{})
+
+	config := v1alpha2.EventConfiguration{
+		EventType: "pod-restart",
+		AgentRef:  v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:    "Pod restarted",
+	}
+	active := createTestHook("active-hook", "default", []v1alpha2.EventConfiguration{config})
+	suspended := createTestHook("suspended-hook", "default", []v1alpha2.EventConfiguration{config})
+	suspended.Spec.Suspended = true
+
+	matches := processor.findEventMatches(createTestEvent("pod-restart", "test-pod", "default"), []*v1alpha2.Hook{active, suspended})
+	require.Len(t, matches, 1)
+	assert.Equal(t, "active-hook", matches[0].Hook.Name)
+}
+
+func TestProcessor_ExpandPromptTemplate(t *testing.T) {
+	processor := &Processor{}
+
+	event := interfaces.Event{
+		Type:         "pod-restart",
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Reason:       "BackOff",
+		Message:      "Container failed to start",
+		Timestamp:    time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	template := "Event {{.EventType}} occurred for {{.ResourceName}} in {{.Namespace}} at {{.Timestamp}}"
+	expected := "Event pod-restart occurred for test-pod in default at 2023-01-01T12:00:00Z"
+
+	result := processor.expandPromptTemplate(template, event)
+	assert.Equal(t, expected, result)
+}
+
+func TestProcessor_ExpandPromptTemplate_Functions(t *testing.T) {
+	processor := &Processor{}
+
+	event := interfaces.Event{
+		Type:         "pod-restart",
+		ResourceName: "web-api-7d9f",
+		Namespace:    "default",
+		Message:      "  Back-off restarting failed container  ",
+	}
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{template: "{{.ResourceName | upper}}", expected: "WEB-API-7D9F"},
+		{template: "{{.EventType | lower}}", expected: "pod-restart"},
+		{template: "[{{.Message | trim}}]", expected: "[Back-off restarting failed container]"},
+		{template: "{{.Reason | default \"unknown\"}}", expected: "unknown"},
+		{template: "{{.Message | trim | truncate 8}}", expected: "Back-off"},
+		{template: "{{.ResourceName | replace \"-\" \"_\"}}", expected: "web_api_7d9f"},
+		// Disallowed builtins are not executed, even through pipes
+		{template: "{{.ResourceName | printf \"%q\"}}", expected: "{{.ResourceName | printf \"%q\"}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.expected, processor.expandPromptTemplate(tt.template, event))
+		})
+	}
+}
+
+func TestProcessor_UpdateHookStatuses(t *testing.T) {
+	// Setup mocks
+	mockEventWatcher := &MockEventWatcher{}
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+
+	processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	// Create test data
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+
+	activeEvents := []interfaces.ActiveEvent{
+		{
+			EventType:    "pod-restart",
+			ResourceName: "test-pod",
+			FirstSeen:    time.Now(),
+			LastSeen:     time.Now(),
+			Status:       "firing",
+		},
+	}
+
+	ctx := context.Background()
+
+	// Setup expectations
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", types.NamespacedName{Name: "test-hook", Namespace: "default"}).Return(activeEvents)
+	mockStatusManager.On("UpdateHookStatus", ctx, hook, activeEvents).Return(nil)
+
+	// Execute
+	err := processor.UpdateHookStatuses(ctx, hooks)
+
+	// Assert
+	assert.NoError(t, err)
+	mockDeduplicationManager.AssertExpectations(t)
+	mockStatusManager.AssertExpectations(t)
+}
+
+func TestProcessor_ProcessEvent_ResourceDeleted(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, &MockKagentClient{}, mockStatusManager)
+
+	tracking := createTestHook("tracking", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	other := createTestHook("other", "default", []v1alpha2.EventConfiguration{
+		{EventType: "oom-kill", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	trackingRef := types.NamespacedName{Name: "tracking", Namespace: "default"}
+	otherRef := types.NamespacedName{Name: "other", Namespace: "default"}
+
+	ctx := context.Background()
+	resolved := []interfaces.ActiveEvent{{EventType: "pod-restart", ResourceName: "crashing", Status: "resolved"}}
+
+	// Only pod event types are resolved, and only the hook tracking the pod is updated
+	mockDeduplicationManager.On("ResolveEvent", trackingRef, "pod-restart", "default", "crashing").Return(true).Once()
+	mockDeduplicationManager.On("ResolveEvent", mock.Anything, mock.Anything, "default", "crashing").Return(false)
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", trackingRef).Return(resolved)
+	mockStatusManager.On("UpdateHookStatus", ctx, tracking, resolved).Return(nil)
+
+	err := processor.ProcessEvent(ctx, interfaces.Event{
+		Type:         interfaces.ResourceDeletedEventType,
+		ResourceName: "crashing",
+		Namespace:    "default",
+		Metadata:     map[string]string{"kind": "Pod"},
+	}, []*v1alpha2.Hook{tracking, other})
+	require.NoError(t, err)
+
+	mockDeduplicationManager.AssertExpectations(t)
+	mockStatusManager.AssertExpectations(t)
+	mockDeduplicationManager.AssertNotCalled(t, "ResolveEvent", trackingRef, "deployment-unavailable", "default", "crashing")
+	mockDeduplicationManager.AssertNotCalled(t, "GetActiveEventsWithStatus", otherRef)
+}
+
+func TestProcessor_ProcessEvent_ResourceDeletedConfiguredKind(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockStatusManager := &MockStatusManager{}
+	notifier := &recordingResolveNotifier{events: make(chan notify.ResolvedEvent, 4)}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, &MockKagentClient{}, mockStatusManager,
+		WithResolveNotifier(notifier),
+		WithEventTypeKinds(map[string][]string{"oom-kill": {"Job"}}))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "oom-kill", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	ctx := context.Background()
+	resolved := []interfaces.ActiveEvent{{EventType: "oom-kill", ResourceName: "batch-job", Status: "resolved"}}
+	mockDeduplicationManager.On("ResolveEvent", hookRef, "oom-kill", "default", "batch-job").Return(true).Once()
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return(resolved)
+	mockStatusManager.On("UpdateHookStatus", ctx, hook, resolved).Return(nil)
+
+	// No status update saw the event firing, yet the deletion still notifies its resolution
+	err := processor.ProcessEvent(ctx, interfaces.Event{
+		Type:         interfaces.ResourceDeletedEventType,
+		ResourceName: "batch-job",
+		Namespace:    "default",
+		Metadata:     map[string]string{"kind": "Job"},
+	}, []*v1alpha2.Hook{hook})
+	require.NoError(t, err)
+
+	select {
+	case event := <-notifier.events:
+		assert.Equal(t, "oom-kill", event.EventType)
+		assert.Equal(t, "batch-job", event.ResourceName)
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolve notification was not sent")
+	}
+
+	// The next status update does not notify the resolution again
+	require.NoError(t, processor.UpdateHookStatuses(ctx, []*v1alpha2.Hook{hook}))
+	select {
+	case event := <-notifier.events:
+		t.Fatalf("unexpected second resolve notification: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+	mockDeduplicationManager.AssertExpectations(t)
+}
+
+func TestProcessor_ProcessEvent_ResourceDeletedWatchAllNamespaces(t *testing.T) {
+	dedup := deduplication.NewManager()
+	mockStatusManager := &MockStatusManager{}
+	notifier := &recordingResolveNotifier{events: make(chan notify.ResolvedEvent, 4)}
+	processor := NewProcessor(&MockEventWatcher{}, dedup, &MockKagentClient{}, mockStatusManager,
+		WithResolveNotifier(notifier))
+
+	// A hook watching all namespaces tracks pods with the same name in two namespaces
+	hook := createTestHook("test-hook", "ops", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "ops"}
+	for _, namespace := range []string{"a", "b"} {
+		event := interfaces.Event{Type: "pod-restart", ResourceName: "web-0", Namespace: namespace, Timestamp: time.Now()}
+		require.NoError(t, dedup.RecordEvent(hookRef, event))
+	}
+
+	ctx := context.Background()
+	mockStatusManager.On("UpdateHookStatus", ctx, hook, mock.Anything).Return(nil)
+
+	err := processor.ProcessEvent(ctx, interfaces.Event{
+		Type:         interfaces.ResourceDeletedEventType,
+		ResourceName: "web-0",
+		Namespace:    "a",
+		Metadata:     map[string]string{"kind": "Pod"},
+	}, []*v1alpha2.Hook{hook})
+	require.NoError(t, err)
+
+	select {
+	case event := <-notifier.events:
+		assert.Equal(t, "a", event.Namespace)
+		assert.Equal(t, "web-0", event.ResourceName)
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolve notification was not sent")
+	}
+	select {
+	case event := <-notifier.events:
+		t.Fatalf("unexpected resolve notification: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Only the deleted pod's event is resolved
+	statuses := make(map[string]string)
+	for _, activeEvent := range dedup.GetActiveEventsWithStatus(hookRef) {
+		statuses[activeEvent.Namespace] = activeEvent.Status
+	}
+	assert.Equal(t, map[string]string{"a": "resolved", "b": "firing"}, statuses)
+}
+
+type recordingResolveNotifier struct {
+	events chan notify.ResolvedEvent
+}
+
+func (n *recordingResolveNotifier) NotifyResolved(ctx context.Context, event notify.ResolvedEvent) error {
+	n.events <- event
+	return nil
+}
+
+func TestProcessor_UpdateHookStatusesNotifiesResolved(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockStatusManager := &MockStatusManager{}
+	notifier := &recordingResolveNotifier{events: make(chan notify.ResolvedEvent, 4)}
+
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, &MockKagentClient{}, mockStatusManager,
+		WithResolveNotifier(notifier))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	firstSeen := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	firing := interfaces.ActiveEvent{
+		EventType:    "pod-restart",
+		ResourceName: "test-pod",
+		FirstSeen:    firstSeen,
+		LastSeen:     firstSeen.Add(time.Minute),
+		Status:       "firing",
+	}
+	resolved := firing
+	resolved.Status = "resolved"
+
+	ctx := context.Background()
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return([]interfaces.ActiveEvent{firing}).Once()
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return([]interfaces.ActiveEvent{resolved}).Twice()
+	mockStatusManager.On("UpdateHookStatus", ctx, hook, mock.Anything).Return(nil)
+
+	// firing -> resolved -> resolved sends exactly one notification
+	for i := 0; i < 3; i++ {
+		require.NoError(t, processor.UpdateHookStatuses(ctx, hooks))
+	}
+
+	select {
+	case event := <-notifier.events:
+		assert.Equal(t, notify.ResolvedEvent{
+			Hook:         "test-hook",
+			Namespace:    "default",
+			EventType:    "pod-restart",
+			ResourceName: "test-pod",
+			FirstSeen:    firstSeen,
+			LastSeen:     firstSeen.Add(time.Minute),
+			Status:       "resolved",
+		}, event)
+	case <-time.After(time.Second):
+		t.Fatal("expected a resolve notification")
+	}
+
+	select {
+	case event := <-notifier.events:
+		t.Fatalf("unexpected second notification: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+	mockDeduplicationManager.AssertExpectations(t)
+}
+
+func TestProcessor_LifecycleNotifications(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "restart"},
+	})
+	hook.Spec.LifecycleNotifications = true
+	hooks := []*v1alpha2.Hook{hook}
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+
+	event := createTestEvent("pod-restart", "test-pod", "default")
+	firing := interfaces.ActiveEvent{
+		EventType:    "pod-restart",
+		ResourceName: "test-pod",
+		FirstSeen:    event.Timestamp,
+		LastSeen:     event.Timestamp,
+		Status:       "firing",
+	}
+	resolved := firing
+	resolved.Status = "resolved"
+
+	ctx := context.Background()
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true).Once()
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(false)
+	mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return([]interfaces.ActiveEvent{firing}).Once()
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return([]interfaces.ActiveEvent{resolved})
+	mockStatusManager.On("RecordEventFiring", ctx, hook, event, agentRef).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", ctx, hook, event, agentRef, "req").Return(nil)
+	mockStatusManager.On("RecordDuplicateEvent", ctx, hook, event).Return(nil)
+	mockStatusManager.On("UpdateHookStatus", ctx, hook, mock.Anything).Return(nil)
+
+	var lifecycles []interface{}
+	mockKagentClient.On("CallAgent", ctx, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil).
+		Run(func(args mock.Arguments) {
+			request := args.Get(1).(interfaces.AgentRequest)
+			assert.Equal(t, agentRef, request.AgentRef)
+			lifecycles = append(lifecycles, request.Context["lifecycle"])
+		})
+
+	// The event fires, repeats, and then resolves across several status updates
+	require.NoError(t, processor.ProcessEvent(ctx, event, hooks))
+	require.NoError(t, processor.UpdateHookStatuses(ctx, hooks))
+	require.NoError(t, processor.ProcessEvent(ctx, event, hooks))
+	require.NoError(t, processor.UpdateHookStatuses(ctx, hooks))
+	require.NoError(t, processor.UpdateHookStatuses(ctx, hooks))
+
+	assert.Equal(t, []interface{}{"firing", "resolved"}, lifecycles)
+}
+
+func TestProcessor_LifecycleNotifications_FilteredConfiguration(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	// The resolved event carries no probe type, but still reaches the agent that handled it
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType: "probe-failed",
+		AgentRef:  v1alpha2.ObjectReference{Name: "liveness-agent"},
+		Prompt:    "Liveness probe failed",
+		ProbeType: "liveness",
+	}})
+	hook.Spec.LifecycleNotifications = true
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	ctx := context.Background()
+	now := time.Now()
+	firing := interfaces.ActiveEvent{EventType: "probe-failed", ResourceName: "test-pod", FirstSeen: now, LastSeen: now, Status: "firing"}
+	resolved := firing
+	resolved.Status = "resolved"
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return([]interfaces.ActiveEvent{firing}).Once()
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return([]interfaces.ActiveEvent{resolved})
+	mockStatusManager.On("UpdateHookStatus", ctx, hook, mock.Anything).Return(nil)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil).
+		Run(func(args mock.Arguments) {
+			request := args.Get(1).(interfaces.AgentRequest)
+			assert.Equal(t, types.NamespacedName{Name: "liveness-agent", Namespace: "default"}, request.AgentRef)
+			assert.Equal(t, lifecycleResolved, request.Context["lifecycle"])
+		})
+
+	require.NoError(t, processor.UpdateHookStatuses(ctx, []*v1alpha2.Hook{hook}))
+	require.NoError(t, processor.UpdateHookStatuses(ctx, []*v1alpha2.Hook{hook}))
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 1)
+}
+
+func TestProcessor_CleanupExpiredEvents(t *testing.T) {
+	// Setup mocks
+	mockEventWatcher := &MockEventWatcher{}
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+
+	processor := NewProcessor(mockEventWatcher, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	// Create test data
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "agent1"}, Prompt: "prompt1"},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+
+	ctx := context.Background()
+
+	// Setup expectations
+	mockDeduplicationManager.On("CleanupExpiredEvents", types.NamespacedName{Name: "test-hook", Namespace: "default"}).Return(nil)
+
+	// Execute
+	err := processor.CleanupExpiredEvents(ctx, hooks)
+
+	// Assert
+	assert.NoError(t, err)
+	mockDeduplicationManager.AssertExpectations(t)
+}
+
+func TestProcessor_ProcessEvent_ErrorMetricsByCategory(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		setup     func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName)
+		category  metrics.ErrorCategory
+	}{
+		{
+			name:      "agent failure",
+			namespace: "metrics-agent",
+			setup: func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName) {
+				dedup.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+				dedup.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+				dedup.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+				status.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, agentRef).Return(nil)
+				kagent.On("CallAgent", mock.Anything, mock.Anything).Return(nil, errors.New("agent unavailable"))
+				status.On("RecordAgentCallFailure", mock.Anything, mock.Anything, mock.Anything, agentRef, mock.Anything).Return(nil)
+			},
+			category: metrics.ErrorCategoryAgent,
+		},
+		{
+			name:      "status failure",
+			namespace: "metrics-status",
+			setup: func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName) {
+				dedup.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+				dedup.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+				dedup.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+				status.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, agentRef).Return(errors.New("api server unavailable"))
+				kagent.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+				status.On("RecordAgentCallSuccess", mock.Anything, mock.Anything, mock.Anything, agentRef, "req").Return(nil)
+				dedup.On("MarkNotified", hookRef, mock.Anything, mock.Anything).Return()
+			},
+			category: metrics.ErrorCategoryStatus,
+		},
+		{
+			name:      "dedup failure",
+			namespace: "metrics-dedup",
+			setup: func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName) {
+				dedup.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+				dedup.On("RecordEvent", hookRef, mock.Anything).Return(errors.New("storage full"))
+				dedup.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+			},
+			category: metrics.ErrorCategoryDedup,
+		},
+	}
+
+	categories := []metrics.ErrorCategory{metrics.ErrorCategoryAgent, metrics.ErrorCategoryStatus, metrics.ErrorCategoryDedup}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDeduplicationManager := &MockDeduplicationManager{}
+			mockKagentClient := &MockKagentClient{}
+			mockStatusManager := &MockStatusManager{}
+			processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+			hook := createTestHook("test-hook", tt.namespace, []v1alpha2.EventConfiguration{
+				{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+			})
+			hookRef := types.NamespacedName{Name: "test-hook", Namespace: tt.namespace}
+			agentRef := types.NamespacedName{Name: "test-agent", Namespace: tt.namespace}
+			tt.setup(mockDeduplicationManager, mockKagentClient, mockStatusManager, hookRef, agentRef)
+
+			before := make(map[metrics.ErrorCategory]float64)
+			for _, c := range categories {
+				before[c] = testutil.ToFloat64(metrics.ProcessingErrorsTotal.WithLabelValues(string(c), tt.namespace))
+			}
+
+			_ = processor.ProcessEvent(context.Background(), createTestEvent("pod-restart", "test-pod", tt.namespace), []*v1alpha2.Hook{hook})
+
+			for _, c := range categories {
+				delta := testutil.ToFloat64(metrics.ProcessingErrorsTotal.WithLabelValues(string(c), tt.namespace)) - before[c]
+				if c == tt.category {
+					assert.Equal(t, float64(1), delta, "expected %s counter to increment", c)
+				} else {
+					assert.Equal(t, float64(0), delta, "expected %s counter to be unchanged", c)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessor_ProcessEvent_ProcessedMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		setup     func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName)
+		result    metrics.EventResult
+	}{
+		{
+			name:      "success",
+			namespace: "processed-success",
+			setup: func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName) {
+				dedup.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+				dedup.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+				dedup.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+				status.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, agentRef).Return(nil)
+				kagent.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+				status.On("RecordAgentCallSuccess", mock.Anything, mock.Anything, mock.Anything, agentRef, "req").Return(nil)
+				dedup.On("MarkNotified", hookRef, mock.Anything, mock.Anything).Return()
+			},
+			result: metrics.EventResultSuccess,
+		},
+		{
+			name:      "failure",
+			namespace: "processed-failure",
+			setup: func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName) {
+				dedup.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+				dedup.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+				dedup.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+				status.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, agentRef).Return(nil)
+				kagent.On("CallAgent", mock.Anything, mock.Anything).Return(nil, errors.New("agent unavailable"))
+				status.On("RecordAgentCallFailure", mock.Anything, mock.Anything, mock.Anything, agentRef, mock.Anything).Return(nil)
+			},
+			result: metrics.EventResultFailure,
+		},
+		{
+			name:      "agent unreachable",
+			namespace: "processed-unreachable",
+			setup: func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName) {
+				dedup.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+				dedup.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+				dedup.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+				status.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, agentRef).Return(nil)
+				kagent.On("CallAgent", mock.Anything, mock.Anything).Return(nil,
+					fmt.Errorf("failed to send A2A message: %w", khookerrors.New(khookerrors.KindAgentUnreachable, errors.New("connection refused"))))
+				status.On("RecordAgentCallFailure", mock.Anything, mock.Anything, mock.Anything, agentRef, mock.Anything).Return(nil)
+			},
+			result: metrics.EventResultAgentUnreachable,
+		},
+		{
+			name:      "duplicate",
+			namespace: "processed-duplicate",
+			setup: func(dedup *MockDeduplicationManager, kagent *MockKagentClient, status *MockStatusManager, hookRef, agentRef types.NamespacedName) {
+				dedup.On("ShouldProcessEvent", hookRef, mock.Anything).Return(false)
+				dedup.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+				dedup.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+				status.On("RecordDuplicateEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			result: metrics.EventResultDuplicate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDeduplicationManager := &MockDeduplicationManager{}
+			mockKagentClient := &MockKagentClient{}
+			mockStatusManager := &MockStatusManager{}
+			processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+			hook := createTestHook("test-hook", tt.namespace, []v1alpha2.EventConfiguration{
+				{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+			})
+			hookRef := types.NamespacedName{Name: "test-hook", Namespace: tt.namespace}
+			agentRef := types.NamespacedName{Name: "test-agent", Namespace: tt.namespace}
+			tt.setup(mockDeduplicationManager, mockKagentClient, mockStatusManager, hookRef, agentRef)
+			latencySeries := testutil.CollectAndCount(metrics.EventProcessingDurationSeconds)
+
+			_ = processor.ProcessEvent(context.Background(), createTestEvent("pod-restart", "test-pod", tt.namespace), []*v1alpha2.Hook{hook})
+
+			// Only events that reach the agent observe the per-hook latency histogram
+			expectedLatencySeries := latencySeries + 1
+			if tt.result == metrics.EventResultDuplicate {
+				expectedLatencySeries = latencySeries
+			}
+			assert.Equal(t, expectedLatencySeries, testutil.CollectAndCount(metrics.EventProcessingDurationSeconds))
+
+			assert.Equal(t, float64(1), testutil.ToFloat64(metrics.EventsProcessedTotal.WithLabelValues("pod-restart", tt.namespace, string(tt.result), "", "")))
+			expectedDeduplicated := float64(0)
+			if tt.result == metrics.EventResultDuplicate {
+				expectedDeduplicated = 1
+			}
+			assert.Equal(t, expectedDeduplicated, testutil.ToFloat64(metrics.DeduplicatedEventsTotal.WithLabelValues("pod-restart", tt.namespace)))
+		})
+	}
+}
+
+func TestProcessor_ProcessEvent_HookMetricLabels(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	const namespace = "metric-labels"
+	annotated := createTestHook("annotated-hook", namespace, []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+	})
+	annotated.Annotations = map[string]string{
+		metrics.HookLabelAnnotationPrefix + "team":    "payments",
+		metrics.HookLabelAnnotationPrefix + "service": "checkout",
+	}
+	plain := createTestHook("plain-hook", namespace, []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+	})
+
+	mockDeduplicationManager.On("ShouldProcessEvent", mock.Anything, mock.Anything).Return(true)
+	mockDeduplicationManager.On("RecordEvent", mock.Anything, mock.Anything).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	event := createTestEvent("pod-restart", "test-pod", namespace)
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{annotated}))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.EventsProcessedTotal.WithLabelValues("pod-restart", namespace, string(metrics.EventResultSuccess), "payments", "checkout")))
+
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{plain}))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.EventsProcessedTotal.WithLabelValues("pod-restart", namespace, string(metrics.EventResultSuccess), "", "")))
+}
+
+func TestProcessor_ProcessEvent_MaxAgentCallsPerMinute(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithMaxAgentCallsPerMinute(2))
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	processor.now = func() time.Time { return now }
+
+	const namespace = "rate-limited"
+	hook := createTestHook("test-hook", namespace, []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+	})
+	otherHook := createTestHook("other-hook", namespace, []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+	})
+
+	mockDeduplicationManager.On("ShouldProcessEvent", mock.Anything, mock.Anything).Return(true)
+	mockDeduplicationManager.On("RecordEvent", mock.Anything, mock.Anything).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordRateLimited", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	process := func(h *v1alpha2.Hook, resourceName string) interfaces.Event {
+		event := createTestEvent("pod-restart", resourceName, namespace)
+		require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{h}))
+		return event
+	}
+
+	process(hook, "pod-1")
+	process(hook, "pod-2")
+	limited := process(hook, "pod-3")
+	stillLimited := process(hook, "pod-4")
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 2)
+
+	// Only the first rejection trips the breaker
+	mockStatusManager.AssertCalled(t, "RecordRateLimited", mock.Anything, hook, limited, true)
+	mockStatusManager.AssertCalled(t, "RecordRateLimited", mock.Anything, hook, stillLimited, false)
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.EventsProcessedTotal.WithLabelValues("pod-restart", namespace, string(metrics.EventResultRateLimited), "", "")))
+
+	// Other hooks have their own budget
+	process(otherHook, "pod-1")
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 3)
+
+	// A token is refilled every 30 seconds
+	now = now.Add(30 * time.Second)
+	process(hook, "pod-5")
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 4)
+}
+
+func TestProcessor_ProcessEvent_AgentDeduplication(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithAgentDeduplication(NewAgentCallDeduplicator(5*time.Minute)))
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	processor.now = func() time.Time { return now }
+
+	const namespace = "agent-dedup"
+	newHook := func(name, agent string) *v1alpha2.Hook {
+		return createTestHook(name, namespace, []v1alpha2.EventConfiguration{
+			{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: agent}, Prompt: "prompt"},
+		})
+	}
+	first := newHook("first-hook", "test-agent")
+	second := newHook("second-hook", "test-agent")
+	otherAgent := newHook("other-agent-hook", "other-agent")
+
+	mockDeduplicationManager.On("ShouldProcessEvent", mock.Anything, mock.Anything).Return(true)
+	mockDeduplicationManager.On("RecordEvent", mock.Anything, mock.Anything).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordCrossHookDuplicate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	process := func(h *v1alpha2.Hook, resourceName string) interfaces.Event {
+		event := createTestEvent("pod-restart", resourceName, namespace)
+		require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{h}))
+		return event
+	}
+
+	process(first, "pod-1")
+	duplicate := process(second, "pod-1")
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 1)
+	agentRef := types.NamespacedName{Namespace: namespace, Name: "test-agent"}
+	mockStatusManager.AssertCalled(t, "RecordCrossHookDuplicate", mock.Anything, second, duplicate, agentRef,
+		types.NamespacedName{Namespace: namespace, Name: "first-hook"})
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.EventsProcessedTotal.WithLabelValues("pod-restart", namespace, string(metrics.EventResultDuplicate), "", "")))
+
+	// Other resources, other agents and the claiming hook itself are not suppressed
+	process(second, "pod-2")
+	process(otherAgent, "pod-1")
+	process(first, "pod-1")
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 4)
+
+	// Calls are allowed again once the window passes
+	now = now.Add(5 * time.Minute)
+	process(second, "pod-1")
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 5)
+}
+
+func TestProcessor_ProcessEvent_AgentDeduplicationAcrossNamespaces(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	mockDeduplicationManager.On("ShouldProcessEvent", mock.Anything, mock.Anything).Return(true)
+	mockDeduplicationManager.On("RecordEvent", mock.Anything, mock.Anything).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordCrossHookDuplicate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	// Each namespace workflow has its own processor sharing one deduplicator
+	agentCalls := NewAgentCallDeduplicator(5 * time.Minute)
+	newProcessor := func() *Processor {
+		return NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+			WithAgentDeduplication(agentCalls))
+	}
+	agentNamespace := "kagent"
+	newHook := func(namespace string) *v1alpha2.Hook {
+		return createTestHook("pod-hook", namespace, []v1alpha2.EventConfiguration{{
+			EventType: "pod-restart",
+			AgentRef:  v1alpha2.ObjectReference{Name: "test-agent", Namespace: &agentNamespace},
+			Prompt:    "prompt",
+		}})
+	}
+	teamA, teamB := newHook("team-a"), newHook("team-b")
+
+	event := createTestEvent("pod-restart", "pod-1", "shared")
+	require.NoError(t, newProcessor().ProcessEvent(context.Background(), event, []*v1alpha2.Hook{teamA}))
+	require.NoError(t, newProcessor().ProcessEvent(context.Background(), event, []*v1alpha2.Hook{teamB}))
+
+	mockKagentClient.AssertNumberOfCalls(t, "CallAgent", 1)
+	mockStatusManager.AssertCalled(t, "RecordCrossHookDuplicate", mock.Anything, teamB, event,
+		types.NamespacedName{Namespace: agentNamespace, Name: "test-agent"},
+		types.NamespacedName{Namespace: "team-a", Name: "pod-hook"})
+}
+
+func TestAgentCallDeduplicator_ReleaseAfterFailure(t *testing.T) {
+	dedup := NewAgentCallDeduplicator(time.Minute)
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	first := types.NamespacedName{Namespace: "default", Name: "first"}
+	second := types.NamespacedName{Namespace: "default", Name: "second"}
+	key := agentCallKeyFor(types.NamespacedName{Namespace: "default", Name: "agent"}, createTestEvent("pod-restart", "pod-1", "default"))
+
+	_, allowed := dedup.Claim(key, first, now)
+	require.True(t, allowed)
+	owner, allowed := dedup.Claim(key, second, now)
+	assert.False(t, allowed)
+	assert.Equal(t, first, owner)
+
+	// Only the claiming hook can release its claim
+	dedup.Release(key, second)
+	_, allowed = dedup.Claim(key, second, now)
+	assert.False(t, allowed)
+
+	dedup.Release(key, first)
+	_, allowed = dedup.Claim(key, second, now)
+	assert.True(t, allowed)
+}
+
+func TestProcessor_ProcessEvent_MinAgentSeverity(t *testing.T) {
+	tests := []struct {
+		name         string
+		eventType    string
+		expectCalled bool
+	}{
+		{name: "medium event is recorded without agent call", eventType: "pod-pending", expectCalled: false},
+		{name: "critical event triggers agent call", eventType: "oom-kill", expectCalled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDeduplicationManager := &MockDeduplicationManager{}
+			mockKagentClient := &MockKagentClient{}
+			mockStatusManager := &MockStatusManager{}
+			processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+				WithMinAgentSeverity(severity.High))
+
+			hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+				{EventType: tt.eventType, AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "prompt"},
+			})
+			hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+			agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+
+			mockDeduplicationManager.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+			mockDeduplicationManager.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+			mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+			mockStatusManager.On("RecordEventFiring", mock.Anything, hook, mock.Anything, agentRef).Return(nil)
+			if tt.expectCalled {
+				mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+				mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, mock.Anything, agentRef, "req").Return(nil)
+				mockDeduplicationManager.On("MarkNotified", hookRef, mock.Anything, mock.Anything).Return()
+			}
+
+			err := processor.ProcessEvent(context.Background(), createTestEvent(tt.eventType, "test-pod", "default"), []*v1alpha2.Hook{hook})
+			require.NoError(t, err)
+
+			mockDeduplicationManager.AssertExpectations(t)
+			mockStatusManager.AssertExpectations(t)
+			if tt.expectCalled {
+				mockKagentClient.AssertExpectations(t)
+			} else {
+				mockKagentClient.AssertNotCalled(t, "CallAgent", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProcessor_ProcessEvent_TaskContinuation(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithTaskReuse(10*time.Minute))
+
+	now := time.Date(2024, 1, 17, 10, 0, 0, 0, time.UTC)
+	processor.now = func() time.Time { return now }
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "restart"},
+		{EventType: "oom-kill", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "oom"},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+	mockDeduplicationManager.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, mock.Anything, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, mock.Anything, agentRef).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, mock.Anything, agentRef, mock.Anything).Return(nil)
+
+	var taskIDs []string
+	respond := func(requestID string) {
+		mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: requestID}, nil).Once().
+			Run(func(args mock.Arguments) {
+				taskIDs = append(taskIDs, args.Get(1).(interfaces.AgentRequest).TaskID)
+			})
+	}
+
+	// First event starts a new task
+	respond("task-1")
+	require.NoError(t, processor.ProcessEvent(context.Background(), createTestEvent("pod-restart", "test-pod", "default"), hooks))
+
+	// A new event for the same resource within the window continues it
+	now = now.Add(5 * time.Minute)
+	respond("task-1")
+	require.NoError(t, processor.ProcessEvent(context.Background(), createTestEvent("oom-kill", "test-pod", "default"), hooks))
+
+	// Another resource gets its own task
+	respond("task-2")
+	require.NoError(t, processor.ProcessEvent(context.Background(), createTestEvent("pod-restart", "other-pod", "default"), hooks))
+
+	// Once the prior task resolves a new one starts
+	now = now.Add(11 * time.Minute)
+	respond("task-3")
+	require.NoError(t, processor.ProcessEvent(context.Background(), createTestEvent("pod-restart", "test-pod", "default"), hooks))
+
+	assert.Equal(t, []string{"", "task-1", "", ""}, taskIDs)
+	mockKagentClient.AssertExpectations(t)
+}
+
+func TestTaskTracker_ForgetsExpiredTasks(t *testing.T) {
+	tracker := newTaskTracker(10 * time.Minute)
+	now := time.Date(2024, 1, 17, 10, 0, 0, 0, time.UTC)
+	hookRef := types.NamespacedName{Namespace: "default", Name: "hook"}
+	agentRef := types.NamespacedName{Namespace: "default", Name: "agent"}
+
+	tracker.Record(taskKey(hookRef, "default", "web-0"), agentRef, "task-1", now)
+	tracker.Record(taskKey(hookRef, "default", "web-1"), agentRef, "task-2", now)
+
+	// Forgetting a resource's task keeps the others open
+	tracker.Forget(taskKey(hookRef, "default", "web-0"))
+	assert.Empty(t, tracker.Open(taskKey(hookRef, "default", "web-0"), agentRef, now))
+	assert.Equal(t, "task-2", tracker.Open(taskKey(hookRef, "default", "web-1"), agentRef, now))
+
+	// Recording a task drops the ones that resolved without new events
+	tracker.Record(taskKey(hookRef, "default", "web-2"), agentRef, "task-3", now.Add(11*time.Minute))
+	assert.Len(t, tracker.tasks, 1)
+}
+
+func TestProcessor_ProcessEvent_TaskClosedOnResolve(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager,
+		WithTaskReuse(10*time.Minute))
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+		{EventType: "pod-restart", AgentRef: v1alpha2.ObjectReference{Name: "test-agent"}, Prompt: "restart"},
+	})
+	hooks := []*v1alpha2.Hook{hook}
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	agentRef := types.NamespacedName{Name: "test-agent", Namespace: "default"}
+	event := createTestEvent("pod-restart", "test-pod", "default")
+
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, mock.Anything).Return(true)
+	mockDeduplicationManager.On("RecordEvent", hookRef, mock.Anything).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+	mockDeduplicationManager.On("MarkNotified", hookRef, mock.Anything, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, mock.Anything, agentRef).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, mock.Anything, agentRef, mock.Anything).Return(nil)
+
+	var taskIDs []string
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "task-1"}, nil).
+		Run(func(args mock.Arguments) {
+			taskIDs = append(taskIDs, args.Get(1).(interfaces.AgentRequest).TaskID)
+		})
+
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, hooks))
+
+	// The event resolves once the pod is deleted
+	resolved := []interfaces.ActiveEvent{{EventType: "pod-restart", ResourceName: "test-pod", Status: "resolved"}}
+	mockDeduplicationManager.On("ResolveEvent", hookRef, mock.Anything, "default", "test-pod").Return(true)
+	mockDeduplicationManager.On("GetActiveEventsWithStatus", hookRef).Return(resolved)
+	mockStatusManager.On("UpdateHookStatus", mock.Anything, hook, resolved).Return(nil)
+	require.NoError(t, processor.ProcessEvent(context.Background(), interfaces.Event{
+		Type:         interfaces.ResourceDeletedEventType,
+		ResourceName: "test-pod",
+		Namespace:    "default",
+		Metadata:     map[string]string{"kind": "Pod"},
+	}, hooks))
+
+	// A pod recreated with the same name starts a new task
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, hooks))
+	assert.Equal(t, []string{"", ""}, taskIDs)
+}
+
+func TestProcessor_ProcessEvent_DisableDeduplication(t *testing.T) {
+	tests := []struct {
+		name                 string
+		disableDeduplication bool
+		expectedCalls        int
+	}{
+		{name: "repeated events suppressed by default", disableDeduplication: false, expectedCalls: 1},
+		{name: "every event calls agent when disabled", disableDeduplication: true, expectedCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockKagentClient := &MockKagentClient{}
+			mockStatusManager := &MockStatusManager{}
+			processor := NewProcessor(&MockEventWatcher{}, deduplication.NewManager(), mockKagentClient, mockStatusManager)
+
+			hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+				{
+					EventType:            "pod-restart",
+					AgentRef:             v1alpha2.ObjectReference{Name: "test-agent"},
+					Prompt:               "restart",
+					DisableDeduplication: tt.disableDeduplication,
+				},
+			})
+			hooks := []*v1alpha2.Hook{hook}
+
+			mockStatusManager.On("RecordEventFiring", mock.Anything, hook, mock.Anything, mock.Anything).Return(nil)
+			mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockStatusManager.On("RecordDuplicateEvent", mock.Anything, hook, mock.Anything).Return(nil)
+			mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+			for i := 0; i < 3; i++ {
+				require.NoError(t, processor.ProcessEvent(context.Background(), createTestEvent("pod-restart", "test-pod", "default"), hooks))
+			}
+
+			mockKagentClient.AssertNumberOfCalls(t, "CallAgent", tt.expectedCalls)
+		})
+	}
+}
+
+func TestProcessor_ProcessEvent_BusinessHoursRouting(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		expectedAgent string
+	}{
+		{
+			name:          "weekday within business hours",
+			now:           time.Date(2024, 1, 17, 10, 30, 0, 0, berlin), // Wednesday
+			expectedAgent: "automated-agent",
+		},
+		{
+			name:          "weekday outside business hours",
+			now:           time.Date(2024, 1, 17, 19, 0, 0, 0, berlin),
+			expectedAgent: "paging-agent",
+		},
+		{
+			name:          "business hours in UTC but not in the schedule time zone",
+			now:           time.Date(2024, 1, 17, 8, 30, 0, 0, time.UTC), // 09:30 in Berlin
+			expectedAgent: "automated-agent",
+		},
+		{
+			name:          "weekend",
+			now:           time.Date(2024, 1, 20, 10, 30, 0, 0, berlin), // Saturday
+			expectedAgent: "paging-agent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDeduplicationManager := &MockDeduplicationManager{}
+			mockKagentClient := &MockKagentClient{}
+			mockStatusManager := &MockStatusManager{}
+
+			processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+			processor.now = func() time.Time { return tt.now }
+
+			hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{
+				{
+					EventType: "pod-restart",
+					AgentRef:  v1alpha2.ObjectReference{Name: "automated-agent"},
+					Prompt:    "Handle pod restart",
+					Schedule: &v1alpha2.AgentSchedule{
+						Timezone: "Europe/Berlin",
+						BusinessHours: []v1alpha2.BusinessHoursWindow{
+							{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00"},
+						},
+						OffHoursAgentRef: v1alpha2.ObjectReference{Name: "paging-agent"},
+					},
+				},
+			})
+			hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+			agentRef := types.NamespacedName{Name: tt.expectedAgent, Namespace: "default"}
+			event := createTestEvent("pod-restart", "test-pod", "default")
+			ctx := context.Background()
+
+			mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+			mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+			mockDeduplicationManager.On("GetActiveEvent", mock.Anything, mock.Anything).Return(interfaces.ActiveEvent{}, false).Maybe()
+			mockStatusManager.On("RecordEventFiring", ctx, hook, event, agentRef).Return(nil)
+			mockKagentClient.On("CallAgent", ctx, mock.MatchedBy(func(req interfaces.AgentRequest) bool {
+				return req.AgentRef == agentRef
+			})).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+			mockStatusManager.On("RecordAgentCallSuccess", ctx, hook, event, agentRef, "req").Return(nil)
+			mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+
+			err := processor.ProcessEvent(ctx, event, []*v1alpha2.Hook{hook})
+
+			assert.NoError(t, err)
+			mockKagentClient.AssertExpectations(t)
+			mockStatusManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLatencyTracker(t *testing.T) {
+	tracker := NewLatencyTracker(4)
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+
+	_, ok := tracker.Summary(hookRef)
+	assert.False(t, ok)
+
+	// Only the most recent latencies are kept
+	for _, seconds := range []int{60, 1, 2, 3, 4} {
+		tracker.Record(hookRef, time.Duration(seconds)*time.Second)
+	}
+	summary, ok := tracker.Summary(hookRef)
+	require.True(t, ok)
+	assert.Equal(t, LatencySummary{Samples: 4, P50: 2 * time.Second, P90: 4 * time.Second, P99: 4 * time.Second}, summary)
+}
+
+func TestProcessor_ProcessEvent_LatencyFromHandOff(t *testing.T) {
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	tracker := NewLatencyTracker(DefaultLatencySamples)
+	processor := NewProcessor(&MockEventWatcher{}, deduplication.NewManager(), mockKagentClient, mockStatusManager,
+		WithLatencyTracker(tracker))
+
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType: "pod-restart",
+		AgentRef:  v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:    "Pod restarted",
+	}})
+	event := createTestEvent("pod-restart", "test-pod", "default")
+	event.ReceivedAt = time.Now().Add(-time.Minute)
+
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, mock.Anything, "req").Return(nil)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+
+	// The time the event waited before processing counts towards its latency
+	summary, ok := tracker.Summary(hookRef)
+	require.True(t, ok)
+	assert.Equal(t, 1, summary.Samples)
+	assert.GreaterOrEqual(t, summary.P50, time.Minute)
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	firstSeen := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	activeEvent := interfaces.ActiveEvent{
+		EventType:    "pod-restart",
+		Namespace:    "default",
+		ResourceName: "test-pod",
+		Reason:       "BackOff",
+		FirstSeen:    firstSeen,
+	}
+
+	key := idempotencyKey(hookRef, activeEvent)
+	assert.Len(t, key, 32)
+	restored := activeEvent
+	restored.FirstSeen = firstSeen.Add(400 * time.Millisecond)
+	assert.Equal(t, key, idempotencyKey(hookRef, restored), "sub-second precision must not change the key")
+
+	notified := firstSeen.Add(time.Minute)
+	renotified := notified.Add(time.Millisecond)
+	for name, change := range map[string]func(*interfaces.ActiveEvent){
+		"event type":     func(e *interfaces.ActiveEvent) { e.EventType = "oom-kill" },
+		"namespace":      func(e *interfaces.ActiveEvent) { e.Namespace = "other" },
+		"resource":       func(e *interfaces.ActiveEvent) { e.ResourceName = "other-pod" },
+		"reason":         func(e *interfaces.ActiveEvent) { e.Reason = "Killing" },
+		"first seen":     func(e *interfaces.ActiveEvent) { e.FirstSeen = firstSeen.Add(time.Minute) },
+		"notified":       func(e *interfaces.ActiveEvent) { e.LastNotifiedAt = &notified },
+		"notified again": func(e *interfaces.ActiveEvent) { e.LastNotifiedAt = &renotified },
+	} {
+		changed := activeEvent
+		change(&changed)
+		assert.NotEqual(t, key, idempotencyKey(hookRef, changed), name)
+	}
+	assert.NotEqual(t, key, idempotencyKey(types.NamespacedName{Name: "other-hook", Namespace: "default"}, activeEvent))
+}
+
+func TestProcessor_ProcessEvent_IdempotencyKey(t *testing.T) {
+	mockDeduplicationManager := &MockDeduplicationManager{}
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, mockDeduplicationManager, mockKagentClient, mockStatusManager)
+
+	hookRef := types.NamespacedName{Name: "test-hook", Namespace: "default"}
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType: "pod-restart",
+		AgentRef:  v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:    "Pod restarted",
+	}})
+	event := createTestEvent("pod-restart", "test-pod", "default")
+	activeEvent := interfaces.ActiveEvent{
+		EventType:    "pod-restart",
+		Namespace:    "default",
+		ResourceName: "test-pod",
+		FirstSeen:    event.Timestamp.Add(-10 * time.Minute),
+	}
+
+	mockDeduplicationManager.On("ShouldProcessEvent", hookRef, event).Return(true)
+	mockDeduplicationManager.On("RecordEvent", hookRef, event).Return(nil)
+	mockDeduplicationManager.On("GetActiveEvent", hookRef, event).Return(activeEvent, true)
+	mockDeduplicationManager.On("MarkNotified", hookRef, event, mock.Anything).Return()
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, mock.Anything, "req").Return(nil)
+
+	wantKey := idempotencyKey(hookRef, activeEvent)
+	mockKagentClient.On("CallAgent", mock.Anything, mock.MatchedBy(func(req interfaces.AgentRequest) bool {
+		return req.IdempotencyKey == wantKey
+	})).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil)
+
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook}))
+	mockKagentClient.AssertExpectations(t)
+}
+
+func TestProcessor_ProcessEvent_CoalesceKeySharedAcrossHooks(t *testing.T) {
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, deduplication.NewManager(), mockKagentClient, mockStatusManager)
+
+	configs := []v1alpha2.EventConfiguration{{
+		EventType: "pod-restart",
+		AgentRef:  v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:    "Pod restarted",
+	}}
+	hooks := []*v1alpha2.Hook{createTestHook("hook-a", "default", configs), createTestHook("hook-b", "default", configs)}
+	event := createTestEvent("pod-restart", "test-pod", "default")
+
+	mockStatusManager.On("RecordEventFiring", mock.Anything, mock.Anything, event, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, mock.Anything, event, mock.Anything, mock.Anything).Return(nil)
+
+	var mutex sync.Mutex
+	var requests []interfaces.AgentRequest
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil).
+		Run(func(args mock.Arguments) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			requests = append(requests, args.Get(1).(interfaces.AgentRequest))
+		})
+
+	require.NoError(t, processor.ProcessEvent(context.Background(), event, hooks))
+
+	// The hooks' calls can be coalesced, while each keeps its own idempotency key
+	require.Len(t, requests, 2)
+	assert.Equal(t, requests[0].CoalesceKey, requests[1].CoalesceKey)
+	assert.NotEmpty(t, requests[0].CoalesceKey)
+	assert.NotEqual(t, requests[0].IdempotencyKey, requests[1].IdempotencyKey)
+}
+
+func TestProcessor_ProcessEvent_IdempotencyKeyPerNotification(t *testing.T) {
+	mockKagentClient := &MockKagentClient{}
+	mockStatusManager := &MockStatusManager{}
+	processor := NewProcessor(&MockEventWatcher{}, deduplication.NewManager(), mockKagentClient, mockStatusManager)
+
+	hook := createTestHook("test-hook", "default", []v1alpha2.EventConfiguration{{
+		EventType:            "pod-restart",
+		AgentRef:             v1alpha2.ObjectReference{Name: "test-agent"},
+		Prompt:               "Pod restarted",
+		DisableDeduplication: true,
+	}})
+	event := createTestEvent("pod-restart", "test-pod", "default")
+
+	mockStatusManager.On("RecordEventFiring", mock.Anything, hook, event, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallSuccess", mock.Anything, hook, event, mock.Anything, mock.Anything).Return(nil)
+	mockStatusManager.On("RecordAgentCallFailure", mock.Anything, hook, event, mock.Anything, mock.Anything).Return(nil)
+
+	var keys []string
+	record := func(args mock.Arguments) {
+		keys = append(keys, args.Get(1).(interfaces.AgentRequest).IdempotencyKey)
+	}
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(nil, errors.New("timeout")).Run(record).Once()
+	mockKagentClient.On("CallAgent", mock.Anything, mock.Anything).Return(&interfaces.AgentResponse{Success: true, RequestId: "req"}, nil).Run(record)
+
+	// A failed call, its retry, and a later notification of the same event
+	for range 3 {
+		_ = processor.ProcessEvent(context.Background(), event, []*v1alpha2.Hook{hook})
+		time.Sleep(time.Millisecond)
+	}
+
+	require.Len(t, keys, 3)
+	assert.Equal(t, keys[0], keys[1], "a retry reuses the failed call's key")
+	assert.NotEqual(t, keys[1], keys[2], "a new notification gets a new key")
 }
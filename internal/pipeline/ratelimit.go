@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// hookLimit is the agent call budget of one hook
+type hookLimit struct {
+	limiter *rate.Limiter
+	tripped bool
+}
+
+// hookRateLimiter limits agent calls per hook with a token bucket refilled at
+// callsPerMinute, allowing bursts of up to callsPerMinute calls
+type hookRateLimiter struct {
+	callsPerMinute int
+	hooks          map[types.NamespacedName]*hookLimit
+	mutex          sync.Mutex
+}
+
+// newHookRateLimiter creates a limiter allowing callsPerMinute agent calls per hook
+func newHookRateLimiter(callsPerMinute int) *hookRateLimiter {
+	return &hookRateLimiter{
+		callsPerMinute: callsPerMinute,
+		hooks:          make(map[types.NamespacedName]*hookLimit),
+	}
+}
+
+// Allow reports whether hookRef may call its agent at now. When the call is not allowed,
+// tripped reports whether this is the first rejection since the hook was last allowed.
+func (l *hookRateLimiter) Allow(hookRef types.NamespacedName, now time.Time) (allowed, tripped bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	limit, ok := l.hooks[hookRef]
+	if !ok {
+		limit = &hookLimit{
+			limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(l.callsPerMinute)), l.callsPerMinute),
+		}
+		l.hooks[hookRef] = limit
+	}
+
+	if limit.limiter.AllowN(now, 1) {
+		limit.tripped = false
+		return true, false
+	}
+	tripped = !limit.tripped
+	limit.tripped = true
+	return false, tripped
+}
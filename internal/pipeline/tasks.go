@@ -0,0 +1,85 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// openTask is an agent task that is still accepting continuations
+type openTask struct {
+	taskID   string
+	agentRef types.NamespacedName
+	lastSeen time.Time
+}
+
+// taskTracker remembers the open agent task for each hook and resource so that
+// follow-up events are sent as continuations instead of starting a new task
+type taskTracker struct {
+	window time.Duration
+	tasks  map[string]*openTask
+	mutex  sync.Mutex
+}
+
+// newTaskTracker creates a tracker whose tasks resolve after window without new events
+func newTaskTracker(window time.Duration) *taskTracker {
+	return &taskTracker{
+		window: window,
+		tasks:  make(map[string]*openTask),
+	}
+}
+
+// taskKey identifies a resource watched by a hook
+func taskKey(hookRef types.NamespacedName, namespace, resourceName string) string {
+	return hookRef.String() + "|" + namespace + "/" + resourceName
+}
+
+// Open returns the open task for key handled by agentRef, or an empty string when the
+// prior task has resolved or was handled by a different agent
+func (t *taskTracker) Open(key string, agentRef types.NamespacedName, now time.Time) string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	task, ok := t.tasks[key]
+	if !ok {
+		return ""
+	}
+	if now.Sub(task.lastSeen) > t.window {
+		delete(t.tasks, key)
+		return ""
+	}
+	if task.agentRef != agentRef {
+		return ""
+	}
+	return task.taskID
+}
+
+// Record stores taskID as the open task for key, dropping tasks that have resolved
+func (t *taskTracker) Record(key string, agentRef types.NamespacedName, taskID string, now time.Time) {
+	if taskID == "" {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for k, task := range t.tasks {
+		if now.Sub(task.lastSeen) > t.window {
+			delete(t.tasks, k)
+		}
+	}
+	t.tasks[key] = &openTask{
+		taskID:   taskID,
+		agentRef: agentRef,
+		lastSeen: now,
+	}
+}
+
+// Forget drops the open task for key
+func (t *taskTracker) Forget(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.tasks, key)
+}
@@ -0,0 +1,96 @@
+package severity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level represents the severity of an event
+type Level string
+
+const (
+	// Low severity events are informational and rarely need action
+	Low Level = "low"
+
+	// Medium severity events may need attention
+	Medium Level = "medium"
+
+	// High severity events usually need action
+	High Level = "high"
+
+	// Critical severity events need immediate action
+	Critical Level = "critical"
+)
+
+// rank orders severity levels from least to most severe
+var rank = map[Level]int{
+	Low:      1,
+	Medium:   2,
+	High:     3,
+	Critical: 4,
+}
+
+// defaultEventSeverities maps supported event types to their built-in severity
+var defaultEventSeverities = map[string]Level{
+	"pod-restart":            High,
+	"pod-pending":            Medium,
+	"oom-kill":               Critical,
+	"probe-failed":           Medium,
+	"deployment-unavailable": High,
+	"deployment-scaled":      Low,
+	"container-started":      Low,
+	"node-cordoned":          Medium,
+	"node-drained":           High,
+}
+
+// Parse converts a string into a severity Level (case-insensitive)
+func Parse(value string) (Level, error) {
+	level := Level(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := rank[level]; !ok {
+		return "", fmt.Errorf("invalid severity '%s', must be one of: low, medium, high, critical", value)
+	}
+	return level, nil
+}
+
+// AtLeast reports whether l is as severe as or more severe than other
+func (l Level) AtLeast(other Level) bool {
+	return rank[l] >= rank[other]
+}
+
+// ForEventType returns the severity of an event type, defaulting to Medium for unknown types
+func ForEventType(eventType string) Level {
+	if level, ok := defaultEventSeverities[eventType]; ok {
+		return level
+	}
+	return Medium
+}
+
+// Mapping resolves event type severities, applying configured overrides on top of the
+// built-in defaults. A nil Mapping uses the defaults only.
+type Mapping struct {
+	overrides map[string]Level
+}
+
+// NewMapping creates a Mapping from event type overrides such as {"pod-pending": "low"}
+func NewMapping(overrides map[string]string) (*Mapping, error) {
+	m := &Mapping{overrides: make(map[string]Level, len(overrides))}
+	for eventType, value := range overrides {
+		level, err := Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("event type '%s': %w", eventType, err)
+		}
+		m.overrides[eventType] = level
+	}
+	return m, nil
+}
+
+// ForEventType returns the configured severity of an event type, falling back to the
+// built-in severity and to Medium for unknown types
+func (m *Mapping) ForEventType(eventType string) Level {
+	if m != nil {
+		if level, ok := m.overrides[eventType]; ok {
+			return level
+		}
+	}
+	return ForEventType(eventType)
+}
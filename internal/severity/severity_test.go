@@ -0,0 +1,77 @@
+package severity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/khook/api/v1alpha2"
+)
+
+func TestParse(t *testing.T) {
+	level, err := Parse("High")
+	require.NoError(t, err)
+	assert.Equal(t, High, level)
+
+	level, err = Parse(" critical ")
+	require.NoError(t, err)
+	assert.Equal(t, Critical, level)
+
+	_, err = Parse("urgent")
+	assert.Error(t, err)
+
+	_, err = Parse("")
+	assert.Error(t, err)
+}
+
+func TestAtLeast(t *testing.T) {
+	assert.True(t, Critical.AtLeast(High))
+	assert.True(t, High.AtLeast(High))
+	assert.False(t, Medium.AtLeast(High))
+	assert.True(t, Low.AtLeast(Low))
+}
+
+func TestForEventType(t *testing.T) {
+	assert.Equal(t, Critical, ForEventType("oom-kill"))
+	assert.Equal(t, High, ForEventType("pod-restart"))
+	assert.Equal(t, Medium, ForEventType("pod-pending"))
+	assert.Equal(t, High, ForEventType("deployment-unavailable"))
+	assert.Equal(t, Low, ForEventType("deployment-scaled"))
+	assert.Equal(t, Medium, ForEventType("unknown-type"))
+}
+
+func TestDefaultEventSeveritiesCoverSupportedEventTypes(t *testing.T) {
+	names := make(map[string]bool, len(v1alpha2.SupportedEventTypes))
+	for _, info := range v1alpha2.SupportedEventTypes {
+		names[info.Name] = true
+		assert.Contains(t, defaultEventSeverities, info.Name, "event type %s has no default severity", info.Name)
+	}
+	for eventType := range defaultEventSeverities {
+		assert.True(t, names[eventType], "default severity for unsupported event type %s", eventType)
+	}
+}
+
+func TestMapping(t *testing.T) {
+	mapping, err := NewMapping(map[string]string{
+		"pod-pending":  "low",
+		"probe-failed": "High",
+		"custom-event": "critical",
+	})
+	require.NoError(t, err)
+
+	// Overrides replace the built-in defaults
+	assert.Equal(t, Low, mapping.ForEventType("pod-pending"))
+	assert.Equal(t, High, mapping.ForEventType("probe-failed"))
+	assert.Equal(t, Critical, mapping.ForEventType("custom-event"))
+
+	// Other event types keep their defaults, and unknown types are medium
+	assert.Equal(t, Critical, mapping.ForEventType("oom-kill"))
+	assert.Equal(t, Medium, mapping.ForEventType("unknown-type"))
+
+	var defaults *Mapping
+	assert.Equal(t, Medium, defaults.ForEventType("pod-pending"))
+
+	_, err = NewMapping(map[string]string{"pod-pending": "urgent"})
+	assert.Error(t, err)
+}
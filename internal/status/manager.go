@@ -22,15 +22,33 @@ type Manager struct {
 	client   client.Client
 	recorder record.EventRecorder
 	logger   logr.Logger
+
+	// fieldManager enables server-side apply of status under this field manager when set
+	fieldManager string
+}
+
+// Option configures optional Manager behavior
+type Option func(*Manager)
+
+// WithServerSideApply writes hook status with a server-side apply patch owned by
+// fieldManager instead of a read-modify-write update, avoiding resourceVersion conflicts
+func WithServerSideApply(fieldManager string) Option {
+	return func(m *Manager) {
+		m.fieldManager = fieldManager
+	}
 }
 
 // NewManager creates a new status manager
-func NewManager(client client.Client, recorder record.EventRecorder) *Manager {
-	return &Manager{
+func NewManager(client client.Client, recorder record.EventRecorder, opts ...Option) *Manager {
+	m := &Manager{
 		client:   client,
 		recorder: recorder,
 		logger:   log.Log.WithName("status-manager"),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // UpdateHookStatus updates the status of a Hook resource with active events
@@ -44,11 +62,16 @@ func (m *Manager) UpdateHookStatus(ctx context.Context, hook *v1alpha2.Hook, act
 	statusEvents := make([]v1alpha2.ActiveEventStatus, len(activeEvents))
 	for i, event := range activeEvents {
 		statusEvents[i] = v1alpha2.ActiveEventStatus{
-			EventType:    event.EventType,
-			ResourceName: event.ResourceName,
-			FirstSeen:    metav1.NewTime(event.FirstSeen),
-			LastSeen:     metav1.NewTime(event.LastSeen),
-			Status:       event.Status,
+			EventType:          event.EventType,
+			ResourceName:       event.ResourceName,
+			Namespace:          event.Namespace,
+			Reason:             event.Reason,
+			FirstSeen:          metav1.NewTime(event.FirstSeen),
+			LastSeen:           metav1.NewTime(event.LastSeen),
+			Status:             event.Status,
+			OccurrenceCount:    event.OccurrenceCount,
+			SessionID:          event.SessionID,
+			RemediationSummary: event.RemediationSummary,
 		}
 	}
 
@@ -56,7 +79,7 @@ func (m *Manager) UpdateHookStatus(ctx context.Context, hook *v1alpha2.Hook, act
 	hook.Status.ActiveEvents = statusEvents
 	hook.Status.LastUpdated = metav1.NewTime(time.Now())
 
-	if err := m.client.Status().Update(ctx, hook); err != nil {
+	if err := m.writeStatus(ctx, hook); err != nil {
 		m.logger.Error(err, "Failed to update hook status",
 			"hook", hook.Name,
 			"namespace", hook.Namespace)
@@ -71,6 +94,29 @@ func (m *Manager) UpdateHookStatus(ctx context.Context, hook *v1alpha2.Hook, act
 	return nil
 }
 
+// writeStatus persists hook.Status, using server-side apply when a field manager is configured
+func (m *Manager) writeStatus(ctx context.Context, hook *v1alpha2.Hook) error {
+	if m.fieldManager == "" {
+		return m.client.Status().Update(ctx, hook)
+	}
+
+	// Apply only the fields this manager owns; omitting resourceVersion lets the
+	// API server merge concurrent appliers instead of rejecting stale writes
+	applyConfig := &v1alpha2.Hook{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha2.GroupVersion.String(),
+			Kind:       "Hook",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hook.Name,
+			Namespace: hook.Namespace,
+		},
+		Status: hook.Status,
+	}
+
+	return m.client.Status().Patch(ctx, applyConfig, client.Apply, client.FieldOwner(m.fieldManager), client.ForceOwnership)
+}
+
 // RecordEventFiring records that an event has started firing
 func (m *Manager) RecordEventFiring(ctx context.Context, hook *v1alpha2.Hook, event interfaces.Event, agentRef types.NamespacedName) error {
 	m.logger.Info("Recording event firing",
@@ -173,6 +219,70 @@ func (m *Manager) RecordDuplicateEvent(ctx context.Context, hook *v1alpha2.Hook,
 	return nil
 }
 
+// RecordCrossHookDuplicate records that an event did not call the agent because another
+// hook already called the same agent for the same event and resource
+func (m *Manager) RecordCrossHookDuplicate(ctx context.Context, hook *v1alpha2.Hook, event interfaces.Event, agentRef, callingHook types.NamespacedName) error {
+	m.logger.Info("Recording cross-hook duplicate event ignored",
+		"hook", hook.Name,
+		"namespace", hook.Namespace,
+		"eventType", event.Type,
+		"resourceName", event.ResourceName,
+		"agentRef", agentRef,
+		"callingHook", callingHook)
+
+	m.recorder.Event(hook, corev1.EventTypeNormal, "DuplicateAgentCallIgnored",
+		fmt.Sprintf("Event %s for resource %s not sent to agent %s; hook %s already called it (within deduplication window)",
+			event.Type, event.ResourceName, agentRef, callingHook))
+
+	return nil
+}
+
+// RecordRateLimited records that an event did not call the agent because the hook exceeded
+// its agent call rate limit. A warning is emitted when the limit trips.
+func (m *Manager) RecordRateLimited(ctx context.Context, hook *v1alpha2.Hook, event interfaces.Event, tripped bool) error {
+	m.logger.Info("Recording rate limited event",
+		"hook", hook.Name,
+		"namespace", hook.Namespace,
+		"eventType", event.Type,
+		"resourceName", event.ResourceName,
+		"tripped", tripped)
+
+	// Warn once per trip rather than for every skipped event
+	if tripped {
+		m.recorder.Event(hook, corev1.EventTypeWarning, "RateLimited",
+			fmt.Sprintf("Agent calls rate limited after event %s for resource %s; further events are recorded without calling the agent",
+				event.Type, event.ResourceName))
+	}
+
+	return nil
+}
+
+// RecordHookConflict records that the hook overlaps with another hook calling the same agent
+func (m *Manager) RecordHookConflict(ctx context.Context, hook *v1alpha2.Hook, message string) error {
+	m.logger.Info("Recording hook conflict",
+		"hook", hook.Name,
+		"namespace", hook.Namespace,
+		"message", message)
+
+	// Emit a warning so the overlap is visible on the hook
+	m.recorder.Event(hook, corev1.EventTypeWarning, "HookConflict", message)
+
+	return nil
+}
+
+// RecordMissingAgent records that the hook references an agent the Kagent API does not know
+func (m *Manager) RecordMissingAgent(ctx context.Context, hook *v1alpha2.Hook, message string) error {
+	m.logger.Info("Recording missing agent",
+		"hook", hook.Name,
+		"namespace", hook.Namespace,
+		"message", message)
+
+	// Emit a warning so the broken reference is visible on the hook before any event fires
+	m.recorder.Event(hook, corev1.EventTypeWarning, "AgentNotFound", message)
+
+	return nil
+}
+
 // GetHookStatus retrieves the current status of a Hook resource
 func (m *Manager) GetHookStatus(ctx context.Context, hookRef types.NamespacedName) (*v1alpha2.HookStatus, error) {
 	hook := &v1alpha2.Hook{}
@@ -2,7 +2,10 @@ package status
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/kagent-dev/khook/api/v1alpha2"
 	"github.com/kagent-dev/khook/internal/interfaces"
@@ -62,11 +66,14 @@ func TestUpdateHookStatus(t *testing.T) {
 			},
 			activeEvents: []interfaces.ActiveEvent{
 				{
-					EventType:    "pod-restart",
-					ResourceName: "test-pod",
-					FirstSeen:    time.Now().Add(-5 * time.Minute),
-					LastSeen:     time.Now(),
-					Status:       "firing",
+					EventType:          "pod-restart",
+					ResourceName:       "test-pod",
+					FirstSeen:          time.Now().Add(-5 * time.Minute),
+					LastSeen:           time.Now(),
+					Status:             "firing",
+					OccurrenceCount:    3,
+					SessionID:          "session-1",
+					RemediationSummary: "Restarted the pod",
 				},
 			},
 			expectError: false,
@@ -122,6 +129,9 @@ func TestUpdateHookStatus(t *testing.T) {
 						assert.Equal(t, expectedEvent.EventType, actualEvent.EventType)
 						assert.Equal(t, expectedEvent.ResourceName, actualEvent.ResourceName)
 						assert.Equal(t, expectedEvent.Status, actualEvent.Status)
+						assert.Equal(t, expectedEvent.OccurrenceCount, actualEvent.OccurrenceCount)
+						assert.Equal(t, expectedEvent.SessionID, actualEvent.SessionID)
+						assert.Equal(t, expectedEvent.RemediationSummary, actualEvent.RemediationSummary)
 					}
 				}
 			}
@@ -129,6 +139,83 @@ func TestUpdateHookStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateHookStatus_ServerSideApply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	hook := &v1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-hook",
+			Namespace:       "default",
+			ResourceVersion: "42",
+		},
+	}
+
+	var mu sync.Mutex
+	var applied []map[string]interface{}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(hook.DeepCopy()).
+		WithStatusSubresource(&v1alpha2.Hook{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				return fmt.Errorf("unexpected status update")
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				assert.Equal(t, "status", subResourceName)
+				assert.Equal(t, types.ApplyPatchType, patch.Type())
+
+				patchOpts := &client.SubResourcePatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				assert.Equal(t, "khook", patchOpts.FieldManager)
+				if assert.NotNil(t, patchOpts.Force) {
+					assert.True(t, *patchOpts.Force)
+				}
+
+				data, err := patch.Data(obj)
+				if err != nil {
+					return err
+				}
+				var body map[string]interface{}
+				if err := json.Unmarshal(data, &body); err != nil {
+					return err
+				}
+
+				mu.Lock()
+				applied = append(applied, body)
+				mu.Unlock()
+				return nil
+			},
+		}).
+		Build()
+	manager := NewManager(fakeClient, record.NewFakeRecorder(100), WithServerSideApply("khook"))
+
+	// Concurrent writers all apply without a resourceVersion, so none is rejected as stale
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := manager.UpdateHookStatus(context.Background(), hook.DeepCopy(), []interfaces.ActiveEvent{
+				{EventType: "pod-restart", ResourceName: fmt.Sprintf("pod-%d", i), FirstSeen: time.Now(), LastSeen: time.Now(), Status: "firing"},
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, applied, 5)
+	for _, body := range applied {
+		assert.Equal(t, "kagent.dev/v1alpha2", body["apiVersion"])
+		assert.Equal(t, "Hook", body["kind"])
+		metadata := body["metadata"].(map[string]interface{})
+		assert.Equal(t, "test-hook", metadata["name"])
+		assert.NotContains(t, metadata, "resourceVersion")
+		status := body["status"].(map[string]interface{})
+		assert.Len(t, status["activeEvents"], 1)
+	}
+}
+
 func TestRecordEventFiring(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, v1alpha2.AddToScheme(scheme))
@@ -365,6 +452,131 @@ func TestRecordDuplicateEvent(t *testing.T) {
 	}
 }
 
+func TestRecordHookConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	hook := &v1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-hook",
+			Namespace: "default",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	fakeRecorder := record.NewFakeRecorder(100)
+	manager := NewManager(fakeClient, fakeRecorder)
+
+	err := manager.RecordHookConflict(context.Background(), hook, "Event type pod-restart overlaps with hook other-hook")
+	assert.NoError(t, err)
+
+	select {
+	case recordedEvent := <-fakeRecorder.Events:
+		assert.Contains(t, recordedEvent, "Warning")
+		assert.Contains(t, recordedEvent, "HookConflict")
+		assert.Contains(t, recordedEvent, "other-hook")
+	case <-time.After(time.Second):
+		t.Fatal("Expected event was not recorded")
+	}
+}
+
+func TestRecordMissingAgent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	hook := &v1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-hook",
+			Namespace: "default",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	fakeRecorder := record.NewFakeRecorder(100)
+	manager := NewManager(fakeClient, fakeRecorder)
+
+	err := manager.RecordMissingAgent(context.Background(), hook, "Agent kagent/missing-agent not found")
+	assert.NoError(t, err)
+
+	select {
+	case recordedEvent := <-fakeRecorder.Events:
+		assert.Contains(t, recordedEvent, "Warning")
+		assert.Contains(t, recordedEvent, "AgentNotFound")
+		assert.Contains(t, recordedEvent, "kagent/missing-agent")
+	case <-time.After(time.Second):
+		t.Fatal("Expected event was not recorded")
+	}
+}
+
+func TestRecordCrossHookDuplicate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	hook := &v1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-hook",
+			Namespace: "default",
+		},
+	}
+	event := interfaces.Event{
+		Type:         "pod-restart",
+		ResourceName: "test-pod",
+		Timestamp:    time.Now(),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	fakeRecorder := record.NewFakeRecorder(100)
+	manager := NewManager(fakeClient, fakeRecorder)
+
+	agentRef := types.NamespacedName{Namespace: "default", Name: "test-agent"}
+	callingHook := types.NamespacedName{Namespace: "default", Name: "other-hook"}
+	require.NoError(t, manager.RecordCrossHookDuplicate(context.Background(), hook, event, agentRef, callingHook))
+	select {
+	case recordedEvent := <-fakeRecorder.Events:
+		assert.Contains(t, recordedEvent, "Normal")
+		assert.Contains(t, recordedEvent, "DuplicateAgentCallIgnored")
+		assert.Contains(t, recordedEvent, "default/test-agent")
+		assert.Contains(t, recordedEvent, "default/other-hook")
+	case <-time.After(time.Second):
+		t.Fatal("Expected event was not recorded")
+	}
+}
+
+func TestRecordRateLimited(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+
+	hook := &v1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-hook",
+			Namespace: "default",
+		},
+	}
+	event := interfaces.Event{
+		Type:         "pod-restart",
+		ResourceName: "test-pod",
+		Timestamp:    time.Now(),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	fakeRecorder := record.NewFakeRecorder(100)
+	manager := NewManager(fakeClient, fakeRecorder)
+
+	require.NoError(t, manager.RecordRateLimited(context.Background(), hook, event, true))
+	select {
+	case recordedEvent := <-fakeRecorder.Events:
+		assert.Contains(t, recordedEvent, "Warning")
+		assert.Contains(t, recordedEvent, "RateLimited")
+		assert.Contains(t, recordedEvent, "test-pod")
+	case <-time.After(time.Second):
+		t.Fatal("Expected event was not recorded")
+	}
+
+	// Events skipped while the limit stays tripped do not emit further warnings
+	require.NoError(t, manager.RecordRateLimited(context.Background(), hook, event, false))
+	assert.Empty(t, fakeRecorder.Events)
+}
+
 func TestGetHookStatus(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, v1alpha2.AddToScheme(scheme))
@@ -2,19 +2,30 @@ package workflow
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
+	"github.com/kagent-dev/khook/internal/config"
 	"github.com/kagent-dev/khook/internal/deduplication"
+	"github.com/kagent-dev/khook/internal/event"
 	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/metrics"
+	"github.com/kagent-dev/khook/internal/notify"
+	"github.com/kagent-dev/khook/internal/pipeline"
+	"github.com/kagent-dev/khook/internal/severity"
 	"github.com/kagent-dev/khook/internal/status"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// statusFieldManager is the field manager used for server-side apply of hook status
+const statusFieldManager = "khook"
+
 // Coordinator orchestrates the complete workflow lifecycle
 type Coordinator struct {
 	hookDiscovery   *HookDiscoveryService
@@ -23,6 +34,39 @@ type Coordinator struct {
 
 	// namespaceStates tracks active workflows per namespace
 	namespaceStates map[string]*NamespaceState
+
+	// watchAll runs a single workflow watching every namespace for all hooks
+	watchAll bool
+
+	// statusManager records warnings for conflicting hooks; nil disables conflict detection
+	statusManager interfaces.StatusManager
+
+	// agentChecker verifies that agents referenced by hooks exist; nil disables the check
+	agentChecker AgentChecker
+
+	// agentWarnings records warnings for hooks referencing missing agents
+	agentWarnings interfaces.StatusManager
+
+	// dedupManager is rehydrated from hook status on the first sync after startup
+	dedupManager *deduplication.Manager
+
+	// sinks are shared by all processors so they can be replaced on reload
+	sinks *notify.SinkSet
+
+	// preview builds the agent requests of synthetic events for the preview endpoint
+	preview *pipeline.Processor
+
+	// latencies holds the recent processing latencies of every hook for the latency endpoint
+	latencies *pipeline.LatencyTracker
+
+	// restored records whether active events have been restored from hook status
+	restored bool
+
+	// conflictSignatures holds the hook signature per namespace last checked for conflicts
+	conflictSignatures map[string]string
+
+	// agentRefSignatures holds the hook signature per namespace last checked for missing agents
+	agentRefSignatures map[string]string
 }
 
 // NewCoordinator creates a new workflow coordinator
@@ -31,9 +75,104 @@ func NewCoordinator(
 	ctrlClient client.Client,
 	kagentClient interfaces.KagentClient,
 	eventRecorder interfaces.EventRecorder,
+	cfg *config.Config,
 ) *Coordinator {
-	dedupManager := deduplication.NewManager()
-	statusManager := status.NewManager(ctrlClient, eventRecorder)
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	logger := log.Log.WithName("workflow-coordinator")
+
+	dedupOpts := dedupWindowOptions(cfg)
+	if len(cfg.Controller.DeduplicationKey) > 0 {
+		components, err := deduplication.ParseKeyComponents(cfg.Controller.DeduplicationKey)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid deduplication key")
+		} else {
+			dedupOpts = append(dedupOpts, deduplication.WithKeyComponents(components))
+		}
+	}
+	dedupManager := deduplication.NewManager(dedupOpts...)
+
+	var statusOpts []status.Option
+	if cfg.Controller.StatusServerSideApply {
+		statusOpts = append(statusOpts, status.WithServerSideApply(statusFieldManager))
+	}
+	statusManager := status.NewManager(ctrlClient, eventRecorder, statusOpts...)
+
+	processorOpts := []pipeline.Option{
+		pipeline.WithMatchTimeout(cfg.Controller.EventProcessingTimeout),
+		pipeline.WithMatchConcurrency(cfg.Controller.MatchConcurrency),
+		pipeline.WithIntervals(cfg.Controller.EventCleanupInterval, cfg.Controller.StatusUpdateInterval),
+		pipeline.WithMaxAgentCallsPerMinute(cfg.Controller.MaxAgentCallsPerMinute),
+		pipeline.WithDrainTimeout(cfg.Controller.ShutdownDrainTimeout),
+		pipeline.WithResourceMetadata(event.NewMetadataCache(k8sClient)),
+	}
+	if cfg.Controller.MinAgentSeverity != "" {
+		level, err := severity.Parse(cfg.Controller.MinAgentSeverity)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid minimum agent severity")
+		} else {
+			processorOpts = append(processorOpts, pipeline.WithMinAgentSeverity(level))
+		}
+	}
+
+	if len(cfg.Controller.EventSeverities) > 0 {
+		mapping, err := severity.NewMapping(cfg.Controller.EventSeverities)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid event severities")
+		} else {
+			processorOpts = append(processorOpts, pipeline.WithSeverityMapping(mapping))
+		}
+	}
+
+	// One deduplicator for all namespace workflows, so hooks in different namespaces calling
+	// the same agent for the same event are deduplicated as well
+	if cfg.Controller.AgentDeduplicationWindow > 0 {
+		agentCalls := pipeline.NewAgentCallDeduplicator(cfg.Controller.AgentDeduplicationWindow)
+		processorOpts = append(processorOpts, pipeline.WithAgentDeduplication(agentCalls))
+	}
+
+	if cfg.Controller.AgentTaskReuseWindow > 0 {
+		processorOpts = append(processorOpts, pipeline.WithTaskReuse(cfg.Controller.AgentTaskReuseWindow))
+	}
+
+	if cfg.Controller.FallbackAgentRef != "" {
+		namespace, name, ok := strings.Cut(cfg.Controller.FallbackAgentRef, "/")
+		if !ok || namespace == "" || name == "" {
+			logger.Error(nil, "Ignoring invalid fallback agent reference", "fallbackAgentRef", cfg.Controller.FallbackAgentRef)
+		} else {
+			processorOpts = append(processorOpts, pipeline.WithFallbackAgent(types.NamespacedName{Namespace: namespace, Name: name}))
+		}
+	}
+
+	if cfg.Controller.ResolveWebhookURL != "" {
+		processorOpts = append(processorOpts, pipeline.WithResolveNotifier(notify.NewWebhook(cfg.Controller.ResolveWebhookURL)))
+	}
+
+	sinks := notify.NewSinkSet(newSinks(cfg, logger)...)
+	processorOpts = append(processorOpts, pipeline.WithSinkSet(sinks))
+	latencies := pipeline.NewLatencyTracker(pipeline.DefaultLatencySamples)
+	processorOpts = append(processorOpts, pipeline.WithLatencyTracker(latencies))
+	processorOpts = append(processorOpts, pipeline.WithEventTypeKinds(cfg.Controller.EventTypeKinds))
+
+	watcherOpts := []event.WatcherOption{
+		event.WithExcludedNamespaces(cfg.Controller.ExcludeNamespaces...),
+		event.WithExcludedReportingControllers(cfg.Controller.ExcludeReportingControllers...),
+		event.WithEventTypeKinds(cfg.Controller.EventTypeKinds),
+		event.WithStalenessWindow(cfg.Controller.EventStalenessWindow),
+		event.WithEventBufferSize(cfg.Controller.EventBufferSize),
+		event.WithIgnoreNormalEventsByKind(cfg.Controller.IgnoreNormalEventsByKind),
+	}
+	if cfg.Controller.IgnoreNormalEvents != nil {
+		watcherOpts = append(watcherOpts, event.WithIgnoreNormalEvents(*cfg.Controller.IgnoreNormalEvents))
+	}
+	if cfg.Controller.WatchAllNamespaces {
+		watcherOpts = append(watcherOpts, event.WatchAllNamespaces())
+	}
+	if cfg.Controller.ResolveDeletedPods {
+		watcherOpts = append(watcherOpts, event.WithPodDeletions())
+	}
 
 	hookDiscovery := NewHookDiscoveryService(ctrlClient)
 	workflowManager := NewWorkflowManager(
@@ -43,14 +182,67 @@ func NewCoordinator(
 		kagentClient,
 		statusManager,
 		eventRecorder,
+		WithProcessorOptions(processorOpts...),
+		WithWatcherOptions(watcherOpts...),
+		WithMaxConcurrentWorkflows(cfg.Controller.MaxConcurrentNamespaceWorkflows),
 	)
 
-	return &Coordinator{
-		hookDiscovery:   hookDiscovery,
-		workflowManager: workflowManager,
-		logger:          log.Log.WithName("workflow-coordinator"),
-		namespaceStates: make(map[string]*NamespaceState),
+	coordinator := &Coordinator{
+		hookDiscovery:      hookDiscovery,
+		workflowManager:    workflowManager,
+		dedupManager:       dedupManager,
+		sinks:              sinks,
+		preview:            pipeline.NewProcessor(nil, dedupManager, kagentClient, statusManager, processorOpts...),
+		latencies:          latencies,
+		logger:             logger,
+		namespaceStates:    make(map[string]*NamespaceState),
+		watchAll:           cfg.Controller.WatchAllNamespaces,
+		conflictSignatures: make(map[string]string),
+		agentRefSignatures: make(map[string]string),
+	}
+	if cfg.Controller.DetectHookConflicts {
+		coordinator.statusManager = statusManager
+	}
+	if cfg.Controller.VerifyAgentRefs {
+		if checker, ok := kagentClient.(AgentChecker); ok {
+			coordinator.agentChecker = checker
+			coordinator.agentWarnings = statusManager
+		} else {
+			logger.Error(nil, "Ignoring verifyAgentRefs; the Kagent client cannot look up agents")
+		}
+	}
+	return coordinator
+}
+
+// dedupWindowOptions returns the deduplication windows and notification cooldowns of cfg
+func dedupWindowOptions(cfg *config.Config) []deduplication.Option {
+	return []deduplication.Option{
+		deduplication.WithEventTypeWindows(cfg.Controller.EventDeduplicationWindows, cfg.Controller.EventDeduplicationTimeout),
+		deduplication.WithNotifyCooldowns(cfg.Controller.NotifyCooldowns, cfg.Controller.NotifyCooldown),
+	}
+}
+
+// newSinks creates the sinks configured in cfg, skipping invalid ones
+func newSinks(cfg *config.Config, logger logr.Logger) []notify.Sink {
+	var sinks []notify.Sink
+	for _, sinkCfg := range cfg.Controller.Sinks {
+		sink, err := notify.NewSink(sinkCfg.Type, sinkCfg.URL)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid sink", "type", sinkCfg.Type)
+			continue
+		}
+		sinks = append(sinks, sink)
 	}
+	return sinks
+}
+
+// ApplyConfig applies the options of cfg that can change while events are processed:
+// deduplication windows, notification cooldowns and sinks. Other options only take
+// effect when the coordinator is recreated.
+func (c *Coordinator) ApplyConfig(cfg *config.Config) {
+	c.dedupManager.Reconfigure(dedupWindowOptions(cfg)...)
+	c.sinks.Replace(newSinks(cfg, c.logger)...)
+	c.logger.Info("Applied reloaded configuration", "sinks", len(c.sinks.Sinks()))
 }
 
 // Start begins the workflow coordination process
@@ -92,6 +284,32 @@ func (c *Coordinator) sync(ctx context.Context) error {
 	hookCount := c.hookDiscovery.GetHookCount(hooksByNamespace)
 	c.logger.Info("Discovered hooks", "totalHooks", hookCount)
 
+	// Restore events that were firing before a restart before any workflow processes
+	// events, so they are not sent to agents again
+	if !c.restored {
+		var hooks []*kagentv1alpha2.Hook
+		for _, namespaceHooks := range hooksByNamespace {
+			hooks = append(hooks, namespaceHooks...)
+		}
+		restored := c.dedupManager.RestoreFromStatus(hooks)
+		c.restored = true
+		c.logger.Info("Restored active events from hook status", "eventCount", restored)
+	}
+
+	hooksByNamespace = c.workflowManager.ActiveHooks(hooksByNamespace)
+
+	if c.statusManager != nil {
+		c.reportHookConflicts(ctx, hooksByNamespace)
+	}
+
+	if c.agentChecker != nil {
+		c.reportMissingAgents(ctx, hooksByNamespace)
+	}
+
+	if c.watchAll {
+		hooksByNamespace = c.hookDiscovery.MergeNamespaces(hooksByNamespace)
+	}
+
 	// Start new workflows and restart changed ones
 	for namespace, hooks := range hooksByNamespace {
 		c.manageNamespaceWorkflow(ctx, namespace, hooks)
@@ -104,9 +322,89 @@ func (c *Coordinator) sync(ctx context.Context) error {
 		c.logger.Info("No hooks found; all workflows stopped")
 	}
 
+	stats := c.dedupManager.Stats()
+	metrics.SetDedupStats(stats.EventsPerHook, stats.EventsInCooldown)
+	c.logger.V(1).Info("Deduplication stats",
+		"trackedEvents", stats.TrackedEvents,
+		"eventsInCooldown", stats.EventsInCooldown)
+
 	return nil
 }
 
+// reportHookConflicts records a warning on each hook that overlaps with another hook in its
+// namespace. Namespaces are only rechecked when their hooks change.
+func (c *Coordinator) reportHookConflicts(ctx context.Context, hooksByNamespace map[string][]*kagentv1alpha2.Hook) {
+	for namespace, hooks := range hooksByNamespace {
+		signature := c.workflowManager.CalculateSignature(hooks)
+		if c.conflictSignatures[namespace] == signature {
+			continue
+		}
+		c.conflictSignatures[namespace] = signature
+
+		for _, conflict := range FindHookConflicts(hooks) {
+			c.logger.Info("Detected conflicting hooks",
+				"namespace", namespace,
+				"hook", conflict.Hook.Name,
+				"otherHook", conflict.OtherHook.Name,
+				"eventType", conflict.EventType,
+				"agentRef", conflict.AgentRef)
+
+			// Warn on both hooks so either owner can resolve the overlap
+			for _, side := range []HookConflict{conflict, conflict.Reverse()} {
+				if err := c.statusManager.RecordHookConflict(ctx, side.Hook, side.Message()); err != nil {
+					c.logger.Error(err, "Failed to record hook conflict", "hook", side.Hook.Name, "namespace", namespace)
+				}
+			}
+		}
+	}
+
+	for namespace := range c.conflictSignatures {
+		if _, exists := hooksByNamespace[namespace]; !exists {
+			delete(c.conflictSignatures, namespace)
+		}
+	}
+}
+
+// reportMissingAgents records a warning on each hook that references an agent unknown to
+// Kagent. Namespaces are only rechecked when their hooks change or a lookup failed.
+func (c *Coordinator) reportMissingAgents(ctx context.Context, hooksByNamespace map[string][]*kagentv1alpha2.Hook) {
+	for namespace, hooks := range hooksByNamespace {
+		signature := c.workflowManager.CalculateSignature(hooks)
+		if c.agentRefSignatures[namespace] == signature {
+			continue
+		}
+
+		// Retry on the next sync rather than warning twice about the same agents
+		missing, err := FindMissingAgents(ctx, c.agentChecker, hooks)
+		if err != nil {
+			c.logger.Error(err, "Failed to verify agent references", "namespace", namespace)
+			continue
+		}
+		c.agentRefSignatures[namespace] = signature
+
+		for _, m := range missing {
+			c.logger.Info("Hook references a missing agent",
+				"namespace", namespace,
+				"hook", m.Hook.Name,
+				"agentRef", m.AgentRef.String())
+			if err := c.agentWarnings.RecordMissingAgent(ctx, m.Hook, m.Message()); err != nil {
+				c.logger.Error(err, "Failed to record missing agent", "hook", m.Hook.Name, "namespace", namespace)
+			}
+		}
+	}
+
+	for namespace := range c.agentRefSignatures {
+		if _, exists := hooksByNamespace[namespace]; !exists {
+			delete(c.agentRefSignatures, namespace)
+		}
+	}
+}
+
+// CheckHealth returns an error while any namespace workflow has stopped processing events
+func (c *Coordinator) CheckHealth() error {
+	return c.workflowManager.CheckHealth()
+}
+
 // manageNamespaceWorkflow ensures the correct workflow is running for a namespace
 func (c *Coordinator) manageNamespaceWorkflow(
 	ctx context.Context,
@@ -116,13 +414,17 @@ func (c *Coordinator) manageNamespaceWorkflow(
 	signature := c.workflowManager.CalculateSignature(hooks)
 
 	if state, exists := c.namespaceStates[namespace]; exists {
-		if state.Signature == signature {
+		if state.Signature != signature {
+			c.logger.Info("Restarting namespace workflow due to hook changes", "namespace", namespace)
+			c.workflowManager.StopNamespaceWorkflow(namespace, state)
+		} else if failure := c.workflowManager.Failure(namespace); failure != nil {
+			// Keep reporting the failure until the restarted workflow watches events again
+			c.logger.Info("Restarting stopped namespace workflow", "namespace", namespace, "reason", failure.Error())
+			state.Cancel()
+		} else {
 			c.logger.V(1).Info("No changes in hooks; keeping workflow running", "namespace", namespace)
 			return
 		}
-
-		c.logger.Info("Restarting namespace workflow due to hook changes", "namespace", namespace)
-		c.workflowManager.StopNamespaceWorkflow(namespace, state)
 		delete(c.namespaceStates, namespace)
 	}
 
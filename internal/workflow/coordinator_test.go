@@ -0,0 +1,48 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kagent-dev/khook/internal/config"
+	"github.com/kagent-dev/khook/internal/deduplication"
+	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/notify"
+)
+
+func TestCoordinatorApplyConfig(t *testing.T) {
+	dedupManager := deduplication.NewManager()
+	c := &Coordinator{dedupManager: dedupManager, sinks: notify.NewSinkSet(), logger: log.Log.WithName("test")}
+	hookRef := types.NamespacedName{Namespace: "default", Name: "hook"}
+	event := interfaces.Event{Type: "oom-kill", ResourceName: "web-0", Namespace: "default", Timestamp: time.Now()}
+	require.NoError(t, dedupManager.RecordEvent(hookRef, event))
+
+	current := config.DefaultConfig()
+	current.Logging.Format = "json"
+	updated := config.DefaultConfig()
+	updated.Logging.Level = "debug"
+	updated.Logging.Format = "text"
+	updated.Controller.EventDeduplicationWindows = map[string]time.Duration{"oom-kill": time.Nanosecond}
+	updated.Controller.NotifyCooldown = 5 * time.Minute
+	updated.Controller.Sinks = []config.SinkConfig{
+		{Type: notify.SinkTypeWebhook, URL: "https://example.com/hook"},
+		{Type: "pagerduty", URL: "https://example.com/pd"},
+	}
+	updated.Controller.WatchAllNamespaces = true
+
+	reloaded, applied, ignored := current.Reload(updated)
+	assert.Equal(t, []string{"controller.eventDeduplicationWindows", "controller.notifyCooldown", "controller.sinks", "logging.level"}, applied)
+	assert.Equal(t, []string{"controller.watchAllNamespaces", "logging.format"}, ignored)
+	assert.Equal(t, "json", reloaded.Logging.Format)
+	assert.False(t, reloaded.Controller.WatchAllNamespaces)
+
+	c.ApplyConfig(reloaded)
+	assert.Len(t, c.sinks.Sinks(), 1, "invalid sinks are skipped")
+	time.Sleep(time.Millisecond)
+	assert.True(t, dedupManager.ShouldProcessEvent(hookRef, event), "the new oom-kill window applies to tracked events")
+}
@@ -0,0 +1,153 @@
+package workflow
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kagent-dev/khook/internal/deduplication"
+	"github.com/kagent-dev/khook/internal/interfaces"
+)
+
+const (
+	// DedupDiagnosticsPath is the debug endpoint serving the deduplication state of a hook
+	DedupDiagnosticsPath = "/api/v1/diagnostics/dedup"
+
+	// EventPreviewPath is the debug endpoint previewing the agent requests of an event
+	EventPreviewPath = "/api/v1/diagnostics/preview"
+
+	// HookLatencyPath is the debug endpoint serving the recent processing latencies of a hook
+	HookLatencyPath = "/api/v1/stats/hooks/{namespace}/{name}/latency"
+)
+
+// dedupDiagnostics is the response of the deduplication diagnostics endpoint
+type dedupDiagnostics struct {
+	Hook string `json:"hook"`
+	deduplication.HookSnapshot
+}
+
+// ServeDedupDiagnostics is a debug handler that writes the in-memory deduplication state of
+// the hook given as ?hook=namespace/name as JSON. It only reads state.
+func (c *Coordinator) ServeDedupDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace, name, ok := strings.Cut(r.URL.Query().Get("hook"), "/")
+	if !ok || namespace == "" || name == "" {
+		http.Error(w, "hook query parameter must be namespace/name", http.StatusBadRequest)
+		return
+	}
+	hookRef := types.NamespacedName{Namespace: namespace, Name: name}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dedupDiagnostics{
+		Hook:         hookRef.String(),
+		HookSnapshot: c.dedupManager.Snapshot(hookRef),
+	}); err != nil {
+		c.logger.Error(err, "Failed to write deduplication diagnostics", "hook", hookRef.String())
+	}
+}
+
+// eventPreview is the response of the event preview endpoint
+type eventPreview struct {
+	Event    interfaces.Event          `json:"event"`
+	Requests []interfaces.AgentRequest `json:"requests"`
+}
+
+// ServeEventPreview is a debug handler that matches the event posted as JSON against the
+// hooks of its namespace and writes the agent requests it would produce. It does not
+// deduplicate the event, update any status or call an agent.
+func (c *Coordinator) ServeEventPreview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var event interfaces.Event
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		http.Error(w, "invalid event: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if event.Type == "" || event.ResourceName == "" || event.Namespace == "" {
+		http.Error(w, "event type, resourceName and namespace are required", http.StatusBadRequest)
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	hooksByNamespace, err := c.hookDiscovery.DiscoverHooks(r.Context())
+	if err != nil {
+		c.logger.Error(err, "Failed to discover hooks for event preview")
+		http.Error(w, "failed to discover hooks", http.StatusInternalServerError)
+		return
+	}
+	hooks := hooksByNamespace[event.Namespace]
+	if c.watchAll {
+		hooks = c.hookDiscovery.MergeNamespaces(hooksByNamespace)[metav1.NamespaceAll]
+	}
+
+	requests, err := c.preview.PreviewEvent(event, hooks)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if requests == nil {
+		requests = []interfaces.AgentRequest{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(eventPreview{Event: event, Requests: requests}); err != nil {
+		c.logger.Error(err, "Failed to write event preview", "eventType", event.Type)
+	}
+}
+
+// hookLatency is the response of the hook latency endpoint
+type hookLatency struct {
+	Hook       string  `json:"hook"`
+	Samples    int     `json:"samples"`
+	P50Seconds float64 `json:"p50Seconds"`
+	P90Seconds float64 `json:"p90Seconds"`
+	P99Seconds float64 `json:"p99Seconds"`
+}
+
+// ServeHookLatency is a debug handler that writes the percentiles of the hook's recent
+// processing latencies, from the watcher handing off an event to the agent responding, as
+// JSON. It is served at HookLatencyPath and responds with 404 for hooks without calls.
+func (c *Coordinator) ServeHookLatency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	hookRef := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if hookRef.Namespace == "" || hookRef.Name == "" {
+		http.Error(w, "hook namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	summary, ok := c.latencies.Summary(hookRef)
+	if !ok {
+		http.Error(w, "no agent calls recorded for hook "+hookRef.String(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hookLatency{
+		Hook:       hookRef.String(),
+		Samples:    summary.Samples,
+		P50Seconds: summary.P50.Seconds(),
+		P90Seconds: summary.P90.Seconds(),
+		P99Seconds: summary.P99.Seconds(),
+	}); err != nil {
+		c.logger.Error(err, "Failed to write hook latency", "hook", hookRef.String())
+	}
+}
@@ -0,0 +1,130 @@
+package workflow
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
+	"github.com/kagent-dev/khook/internal/deduplication"
+	"github.com/kagent-dev/khook/internal/interfaces"
+	"github.com/kagent-dev/khook/internal/pipeline"
+)
+
+func TestServeDedupDiagnostics(t *testing.T) {
+	dedupManager := deduplication.NewManager()
+	hookRef := types.NamespacedName{Namespace: "team-a", Name: "hook"}
+	require.NoError(t, dedupManager.RecordEvent(hookRef, interfaces.Event{Type: "pod-restart", ResourceName: "web-0", Namespace: "team-a"}))
+	c := &Coordinator{dedupManager: dedupManager, logger: log.Log.WithName("test")}
+
+	rec := httptest.NewRecorder()
+	c.ServeDedupDiagnostics(rec, httptest.NewRequest(http.MethodGet, DedupDiagnosticsPath+"?hook=team-a/hook", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body struct {
+		Hook   string `json:"hook"`
+		Events []struct {
+			EventType    string `json:"eventType"`
+			ResourceName string `json:"resourceName"`
+			Status       string `json:"status"`
+			InCooldown   bool   `json:"inCooldown"`
+		} `json:"events"`
+		Pending []interface{} `json:"pending"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "team-a/hook", body.Hook)
+	require.Len(t, body.Events, 1)
+	assert.Equal(t, "web-0", body.Events[0].ResourceName)
+	assert.Equal(t, "firing", body.Events[0].Status)
+	assert.NotNil(t, body.Pending)
+
+	for _, query := range []string{"", "?hook=hook", "?hook=/hook", "?hook=team-a/"} {
+		rec := httptest.NewRecorder()
+		c.ServeDedupDiagnostics(rec, httptest.NewRequest(http.MethodGet, DedupDiagnosticsPath+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "query %q", query)
+	}
+
+	rec = httptest.NewRecorder()
+	c.ServeDedupDiagnostics(rec, httptest.NewRequest(http.MethodPost, DedupDiagnosticsPath+"?hook=team-a/hook", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServeEventPreview(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kagentv1alpha2.AddToScheme(scheme))
+	ctrlClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newConflictTestHook("restarts", "team-a", conflictTestConfig("pod-restart", "agent", "")),
+		newConflictTestHook("elsewhere", "team-b", conflictTestConfig("pod-restart", "agent", "")),
+	).Build()
+	c := &Coordinator{
+		hookDiscovery: NewHookDiscoveryService(ctrlClient),
+		preview:       pipeline.NewProcessor(nil, nil, nil, nil),
+		logger:        log.Log.WithName("test"),
+	}
+
+	rec := httptest.NewRecorder()
+	c.ServeEventPreview(rec, httptest.NewRequest(http.MethodPost, EventPreviewPath,
+		strings.NewReader(`{"type": "pod-restart", "resourceName": "web-0", "namespace": "team-a"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body struct {
+		Requests []struct {
+			AgentRef     types.NamespacedName `json:"agentId"`
+			ResourceName string               `json:"resourceName"`
+		} `json:"requests"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Requests, 1, "only hooks of the event's namespace match")
+	assert.Equal(t, types.NamespacedName{Namespace: "team-a", Name: "agent"}, body.Requests[0].AgentRef)
+	assert.Equal(t, "web-0", body.Requests[0].ResourceName)
+
+	for _, payload := range []string{"not json", `{"type": "pod-restart"}`} {
+		rec := httptest.NewRecorder()
+		c.ServeEventPreview(rec, httptest.NewRequest(http.MethodPost, EventPreviewPath, strings.NewReader(payload)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "payload %q", payload)
+	}
+
+	rec = httptest.NewRecorder()
+	c.ServeEventPreview(rec, httptest.NewRequest(http.MethodGet, EventPreviewPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServeHookLatency(t *testing.T) {
+	latencies := pipeline.NewLatencyTracker(10)
+	hookRef := types.NamespacedName{Namespace: "team-a", Name: "hook"}
+	for i := 1; i <= 10; i++ {
+		latencies.Record(hookRef, time.Duration(i)*100*time.Millisecond)
+	}
+	c := &Coordinator{latencies: latencies, logger: log.Log.WithName("test")}
+	mux := http.NewServeMux()
+	mux.HandleFunc(HookLatencyPath, c.ServeHookLatency)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stats/hooks/team-a/hook/latency", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body hookLatency
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, hookLatency{Hook: "team-a/hook", Samples: 10, P50Seconds: 0.5, P90Seconds: 0.9, P99Seconds: 1}, body)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/stats/hooks/team-a/other/latency", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/stats/hooks/team-a/hook/latency", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,146 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
+)
+
+// HookConflict describes two hooks in a namespace whose event configurations send
+// the same events to the same agent, so matching events call the agent twice
+type HookConflict struct {
+	Hook         *kagentv1alpha2.Hook
+	OtherHook    *kagentv1alpha2.Hook
+	EventType    string
+	AgentRef     string
+	Pattern      string
+	OtherPattern string
+}
+
+// Message describes the conflict from the point of view of Hook
+func (c HookConflict) Message() string {
+	return fmt.Sprintf("Event type %s (resourceNamePattern %q) for agent %s overlaps with hook %s (resourceNamePattern %q); matching events call the agent twice",
+		c.EventType, c.Pattern, c.AgentRef, c.OtherHook.Name, c.OtherPattern)
+}
+
+// Reverse returns the same conflict from the point of view of OtherHook
+func (c HookConflict) Reverse() HookConflict {
+	return HookConflict{
+		Hook:         c.OtherHook,
+		OtherHook:    c.Hook,
+		EventType:    c.EventType,
+		AgentRef:     c.AgentRef,
+		Pattern:      c.OtherPattern,
+		OtherPattern: c.Pattern,
+	}
+}
+
+// hookTarget is a single event configuration of a hook, keyed for conflict detection
+type hookTarget struct {
+	hook      *kagentv1alpha2.Hook
+	eventType string
+	agentRef  string
+	pattern   string
+}
+
+// FindHookConflicts returns overlapping (eventType, resourceNamePattern) pairs that target
+// the same agent from different hooks in the same namespace. Patterns overlap when they
+// are equal or either is empty; distinct non-empty patterns are assumed not to overlap.
+func FindHookConflicts(hooks []*kagentv1alpha2.Hook) []HookConflict {
+	sorted := make([]*kagentv1alpha2.Hook, len(hooks))
+	copy(sorted, hooks)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	// Group configurations by hook namespace, event type and agent
+	groups := map[string][]hookTarget{}
+	var keys []string
+	for _, hook := range sorted {
+		for _, config := range hook.Spec.EventConfigurations {
+			agentNamespace := hook.Namespace
+			if config.AgentRef.Namespace != nil {
+				agentNamespace = *config.AgentRef.Namespace
+			}
+			target := hookTarget{
+				hook:      hook,
+				eventType: config.EventType,
+				agentRef:  agentNamespace + "/" + config.AgentRef.Name,
+				pattern:   config.ResourceNamePattern,
+			}
+			key := hook.Namespace + "|" + target.eventType + "|" + target.agentRef
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], target)
+		}
+	}
+
+	var conflicts []HookConflict
+	for _, key := range keys {
+		group := groups[key]
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				a, b := group[i], group[j]
+				if a.hook == b.hook || !patternsOverlap(a.pattern, b.pattern) {
+					continue
+				}
+				conflicts = append(conflicts, HookConflict{
+					Hook:         a.hook,
+					OtherHook:    b.hook,
+					EventType:    a.eventType,
+					AgentRef:     a.agentRef,
+					Pattern:      a.pattern,
+					OtherPattern: b.pattern,
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// patternsOverlap reports whether two resource name patterns can match the same resource
+func patternsOverlap(a, b string) bool {
+	return a == "" || b == "" || a == b
+}
+
+// HookConflictWarner warns on admission when a hook overlaps with another hook in its
+// namespace, so the conflict is reported before the hook is stored
+type HookConflictWarner struct {
+	Reader client.Reader
+}
+
+// HookWarnings implements kagentv1alpha2.HookWarner
+func (w *HookConflictWarner) HookWarnings(ctx context.Context, hook *kagentv1alpha2.Hook) (admission.Warnings, error) {
+	var hookList kagentv1alpha2.HookList
+	if err := w.Reader.List(ctx, &hookList, client.InNamespace(hook.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list hooks: %w", err)
+	}
+
+	// Compare the admitted hook with the others as they are stored
+	hooks := []*kagentv1alpha2.Hook{hook}
+	for i := range hookList.Items {
+		if hookList.Items[i].Name != hook.Name {
+			hooks = append(hooks, &hookList.Items[i])
+		}
+	}
+
+	var warnings admission.Warnings
+	for _, conflict := range FindHookConflicts(hooks) {
+		if conflict.OtherHook == hook {
+			conflict = conflict.Reverse()
+		}
+		if conflict.Hook == hook {
+			warnings = append(warnings, conflict.Message())
+		}
+	}
+	return warnings, nil
+}
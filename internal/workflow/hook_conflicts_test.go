@@ -0,0 +1,140 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
+)
+
+func newConflictTestHook(name, namespace string, configs ...kagentv1alpha2.EventConfiguration) *kagentv1alpha2.Hook {
+	return &kagentv1alpha2.Hook{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       kagentv1alpha2.HookSpec{EventConfigurations: configs},
+	}
+}
+
+func conflictTestConfig(eventType, agent, pattern string) kagentv1alpha2.EventConfiguration {
+	return kagentv1alpha2.EventConfiguration{
+		EventType:           eventType,
+		AgentRef:            kagentv1alpha2.ObjectReference{Name: agent},
+		Prompt:              "prompt",
+		ResourceNamePattern: pattern,
+	}
+}
+
+func TestFindHookConflicts(t *testing.T) {
+	otherNamespace := "ops"
+
+	tests := []struct {
+		name     string
+		hooks    []*kagentv1alpha2.Hook
+		expected int
+	}{
+		{
+			name: "same event type and agent",
+			hooks: []*kagentv1alpha2.Hook{
+				newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent", "")),
+				newConflictTestHook("b", "default", conflictTestConfig("pod-restart", "agent", "")),
+			},
+			expected: 1,
+		},
+		{
+			name: "empty pattern overlaps a specific pattern",
+			hooks: []*kagentv1alpha2.Hook{
+				newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent", "payment-*")),
+				newConflictTestHook("b", "default", conflictTestConfig("pod-restart", "agent", "")),
+			},
+			expected: 1,
+		},
+		{
+			name: "distinct patterns",
+			hooks: []*kagentv1alpha2.Hook{
+				newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent", "payment-*")),
+				newConflictTestHook("b", "default", conflictTestConfig("pod-restart", "agent", "checkout-*")),
+			},
+		},
+		{
+			name: "different agents",
+			hooks: []*kagentv1alpha2.Hook{
+				newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent-1", "")),
+				newConflictTestHook("b", "default", conflictTestConfig("pod-restart", "agent-2", "")),
+			},
+		},
+		{
+			name: "different event types",
+			hooks: []*kagentv1alpha2.Hook{
+				newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent", "")),
+				newConflictTestHook("b", "default", conflictTestConfig("oom-kill", "agent", "")),
+			},
+		},
+		{
+			name: "different namespaces",
+			hooks: []*kagentv1alpha2.Hook{
+				newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent", "")),
+				newConflictTestHook("b", otherNamespace, conflictTestConfig("pod-restart", "agent", "")),
+			},
+		},
+		{
+			name: "configurations within one hook",
+			hooks: []*kagentv1alpha2.Hook{
+				newConflictTestHook("a", "default",
+					conflictTestConfig("pod-restart", "agent", "payment-*"),
+					conflictTestConfig("pod-restart", "agent", "")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, FindHookConflicts(tt.hooks), tt.expected)
+		})
+	}
+}
+
+func TestFindHookConflictsMessage(t *testing.T) {
+	conflicts := FindHookConflicts([]*kagentv1alpha2.Hook{
+		newConflictTestHook("b", "default", conflictTestConfig("pod-restart", "agent", "")),
+		newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent", "payment-*")),
+	})
+	require.Len(t, conflicts, 1)
+
+	conflict := conflicts[0]
+	assert.Equal(t, "a", conflict.Hook.Name)
+	assert.Equal(t, "b", conflict.OtherHook.Name)
+	assert.Equal(t, "default/agent", conflict.AgentRef)
+	assert.Contains(t, conflict.Message(), "overlaps with hook b")
+	assert.Contains(t, conflict.Reverse().Message(), "overlaps with hook a")
+}
+
+func TestHookConflictWarner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kagentv1alpha2.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newConflictTestHook("existing", "default", conflictTestConfig("oom-kill", "agent", "")),
+		newConflictTestHook("elsewhere", "ops", conflictTestConfig("oom-kill", "agent", "")),
+		newConflictTestHook("updated", "default", conflictTestConfig("pod-restart", "agent", "")),
+	).Build()
+	warner := &HookConflictWarner{Reader: reader}
+
+	t.Run("overlapping hook is warned about", func(t *testing.T) {
+		hook := newConflictTestHook("new", "default", conflictTestConfig("oom-kill", "agent", "api-*"))
+		warnings, err := warner.HookWarnings(context.Background(), hook)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "overlaps with hook existing")
+	})
+
+	t.Run("stored version of an updated hook is ignored", func(t *testing.T) {
+		hook := newConflictTestHook("updated", "default", conflictTestConfig("pod-restart", "agent", "api-*"))
+		warnings, err := warner.HookWarnings(context.Background(), hook)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+}
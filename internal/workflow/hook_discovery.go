@@ -3,7 +3,9 @@ package workflow
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
@@ -44,3 +46,24 @@ func (s *HookDiscoveryService) GetHookCount(hooksByNamespace map[string][]*kagen
 	}
 	return count
 }
+
+// MergeNamespaces groups all hooks under metav1.NamespaceAll for a single cluster-wide
+// workflow. Hooks are ordered by namespace and name so the workflow signature is stable.
+func (s *HookDiscoveryService) MergeNamespaces(hooksByNamespace map[string][]*kagentv1alpha2.Hook) map[string][]*kagentv1alpha2.Hook {
+	var all []*kagentv1alpha2.Hook
+	for _, hooks := range hooksByNamespace {
+		all = append(all, hooks...)
+	}
+	if len(all) == 0 {
+		return map[string][]*kagentv1alpha2.Hook{}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Namespace != all[j].Namespace {
+			return all[i].Namespace < all[j].Namespace
+		}
+		return all[i].Name < all[j].Name
+	})
+
+	return map[string][]*kagentv1alpha2.Hook{metav1.NamespaceAll: all}
+}
@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
+)
+
+// admissionAgentLookupTimeout bounds the agent lookups of one admission request so that a
+// slow Kagent API does not hit the API server's webhook timeout
+const admissionAgentLookupTimeout = 5 * time.Second
+
+// AgentChecker looks up agents in the Kagent API
+type AgentChecker interface {
+	AgentExists(ctx context.Context, agentRef types.NamespacedName) (bool, error)
+}
+
+// MissingAgent is an agent referenced by a hook that the Kagent API does not know
+type MissingAgent struct {
+	Hook     *kagentv1alpha2.Hook
+	AgentRef types.NamespacedName
+}
+
+// Message describes the missing agent from the point of view of Hook
+func (m MissingAgent) Message() string {
+	return fmt.Sprintf("Agent %s was not found in Kagent; matching events will fail to call it", m.AgentRef)
+}
+
+// HookAgentRefs returns the distinct agents a hook can call, including off-hours agents,
+// in the order they are first referenced
+func HookAgentRefs(hook *kagentv1alpha2.Hook) []types.NamespacedName {
+	var refs []types.NamespacedName
+	seen := map[types.NamespacedName]bool{}
+	add := func(ref kagentv1alpha2.ObjectReference) {
+		agentRef := types.NamespacedName{Namespace: hook.Namespace, Name: ref.Name}
+		if ref.Namespace != nil {
+			agentRef.Namespace = *ref.Namespace
+		}
+		if !seen[agentRef] {
+			seen[agentRef] = true
+			refs = append(refs, agentRef)
+		}
+	}
+
+	for _, config := range hook.Spec.EventConfigurations {
+		add(config.AgentRef)
+		if config.Schedule != nil {
+			add(config.Schedule.OffHoursAgentRef)
+		}
+	}
+	return refs
+}
+
+// FindMissingAgents returns the agents referenced by hooks that the Kagent API does not
+// know, looking up each agent once. Failed lookups are returned as an error and never
+// reported as missing, so a Kagent outage does not flag every hook.
+func FindMissingAgents(ctx context.Context, checker AgentChecker, hooks []*kagentv1alpha2.Hook) ([]MissingAgent, error) {
+	exists := map[types.NamespacedName]bool{}
+	failed := map[types.NamespacedName]bool{}
+	var missing []MissingAgent
+	var errs []error
+
+	for _, hook := range hooks {
+		for _, agentRef := range HookAgentRefs(hook) {
+			if failed[agentRef] {
+				continue
+			}
+			found, checked := exists[agentRef]
+			if !checked {
+				var err error
+				found, err = checker.AgentExists(ctx, agentRef)
+				if err != nil {
+					failed[agentRef] = true
+					errs = append(errs, err)
+					continue
+				}
+				exists[agentRef] = found
+			}
+			if !found {
+				missing = append(missing, MissingAgent{Hook: hook, AgentRef: agentRef})
+			}
+		}
+	}
+	return missing, errors.Join(errs...)
+}
+
+// MissingAgentWarner warns on admission when a hook references agents the Kagent API does
+// not know. The hook is still admitted.
+type MissingAgentWarner struct {
+	Checker AgentChecker
+}
+
+// HookWarnings implements kagentv1alpha2.HookWarner
+func (w *MissingAgentWarner) HookWarnings(ctx context.Context, hook *kagentv1alpha2.Hook) (admission.Warnings, error) {
+	ctx, cancel := context.WithTimeout(ctx, admissionAgentLookupTimeout)
+	defer cancel()
+
+	missing, err := FindMissingAgents(ctx, w.Checker, []*kagentv1alpha2.Hook{hook})
+	var warnings admission.Warnings
+	for _, m := range missing {
+		warnings = append(warnings, m.Message())
+	}
+	if err != nil {
+		return warnings, fmt.Errorf("failed to verify agent references: %w", err)
+	}
+	return warnings, nil
+}
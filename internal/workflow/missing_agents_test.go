@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
+)
+
+// fakeAgentChecker knows a fixed set of agents and counts lookups
+type fakeAgentChecker struct {
+	agents  map[types.NamespacedName]bool
+	err     error
+	lookups int
+}
+
+func (f *fakeAgentChecker) AgentExists(_ context.Context, agentRef types.NamespacedName) (bool, error) {
+	f.lookups++
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.agents[agentRef], nil
+}
+
+func TestHookAgentRefs(t *testing.T) {
+	otherNamespace := "ops"
+	scheduled := conflictTestConfig("oom-kill", "day-agent", "")
+	scheduled.Schedule = &kagentv1alpha2.AgentSchedule{
+		OffHoursAgentRef: kagentv1alpha2.ObjectReference{Name: "night-agent", Namespace: &otherNamespace},
+	}
+	hook := newConflictTestHook("hook", "default",
+		conflictTestConfig("pod-restart", "day-agent", ""),
+		scheduled,
+	)
+
+	assert.Equal(t, []types.NamespacedName{
+		{Namespace: "default", Name: "day-agent"},
+		{Namespace: "ops", Name: "night-agent"},
+	}, HookAgentRefs(hook))
+}
+
+func TestFindMissingAgents(t *testing.T) {
+	hooks := []*kagentv1alpha2.Hook{
+		newConflictTestHook("a", "default", conflictTestConfig("pod-restart", "agent", "")),
+		newConflictTestHook("b", "default", conflictTestConfig("oom-kill", "agent", ""), conflictTestConfig("pod-pending", "missing", "")),
+		newConflictTestHook("c", "default", conflictTestConfig("pod-restart", "missing", "")),
+	}
+
+	checker := &fakeAgentChecker{agents: map[types.NamespacedName]bool{{Namespace: "default", Name: "agent"}: true}}
+	missing, err := FindMissingAgents(context.Background(), checker, hooks)
+	require.NoError(t, err)
+	require.Len(t, missing, 2)
+	assert.Equal(t, "b", missing[0].Hook.Name)
+	assert.Equal(t, "c", missing[1].Hook.Name)
+	assert.Contains(t, missing[0].Message(), "default/missing")
+	assert.Equal(t, 2, checker.lookups, "each agent is looked up once")
+
+	// Failed lookups are not reported as missing agents
+	checker = &fakeAgentChecker{err: errors.New("connection refused")}
+	missing, err = FindMissingAgents(context.Background(), checker, hooks)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Empty(t, missing)
+	assert.Equal(t, 2, checker.lookups)
+}
+
+func TestMissingAgentWarner(t *testing.T) {
+	hook := newConflictTestHook("hook", "default", conflictTestConfig("pod-restart", "agent", ""), conflictTestConfig("oom-kill", "missing", ""))
+	warner := &MissingAgentWarner{Checker: &fakeAgentChecker{agents: map[types.NamespacedName]bool{{Namespace: "default", Name: "agent"}: true}}}
+
+	warnings, err := warner.HookWarnings(context.Background(), hook)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "default/missing")
+
+	// A Kagent outage is reported as an error rather than as missing agents
+	warner = &MissingAgentWarner{Checker: &fakeAgentChecker{err: errors.New("connection refused")}}
+	warnings, err = warner.HookWarnings(context.Background(), hook)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Empty(t, warnings)
+}
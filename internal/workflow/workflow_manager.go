@@ -2,8 +2,13 @@ package workflow
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -24,7 +29,46 @@ type WorkflowManager struct {
 	kagentClient  interfaces.KagentClient
 	statusManager interfaces.StatusManager
 	eventRecorder interfaces.EventRecorder
+	processorOpts []pipeline.Option
+	watcherOpts   []event.WatcherOption
 	logger        logr.Logger
+
+	// slots bounds how many namespace workflows run at once; nil runs them all.
+	// Workflows started over the limit wait for a running one to stop.
+	slots chan struct{}
+
+	// failed records namespace workflows that stopped on their own, e.g. because the
+	// event watch could not be established. Entries are kept until a restarted workflow
+	// establishes its watch or the workflow is stopped.
+	mu     sync.Mutex
+	failed map[string]error
+}
+
+// WorkflowManagerOption configures optional WorkflowManager behavior
+type WorkflowManagerOption func(*WorkflowManager)
+
+// WithProcessorOptions applies the given options to every namespace processor
+func WithProcessorOptions(opts ...pipeline.Option) WorkflowManagerOption {
+	return func(wm *WorkflowManager) {
+		wm.processorOpts = append(wm.processorOpts, opts...)
+	}
+}
+
+// WithWatcherOptions applies the given options to every namespace event watcher
+func WithWatcherOptions(opts ...event.WatcherOption) WorkflowManagerOption {
+	return func(wm *WorkflowManager) {
+		wm.watcherOpts = append(wm.watcherOpts, opts...)
+	}
+}
+
+// WithMaxConcurrentWorkflows limits how many namespace workflows run at once. Workflows
+// started over the limit are queued until a running workflow stops. Zero is unlimited.
+func WithMaxConcurrentWorkflows(n int) WorkflowManagerOption {
+	return func(wm *WorkflowManager) {
+		if n > 0 {
+			wm.slots = make(chan struct{}, n)
+		}
+	}
 }
 
 // NewWorkflowManager creates a new workflow manager
@@ -35,8 +79,9 @@ func NewWorkflowManager(
 	kagentClient interfaces.KagentClient,
 	statusManager interfaces.StatusManager,
 	eventRecorder interfaces.EventRecorder,
+	opts ...WorkflowManagerOption,
 ) *WorkflowManager {
-	return &WorkflowManager{
+	wm := &WorkflowManager{
 		k8sClient:     k8sClient,
 		ctrlClient:    ctrlClient,
 		dedupManager:  dedupManager,
@@ -44,7 +89,12 @@ func NewWorkflowManager(
 		statusManager: statusManager,
 		eventRecorder: eventRecorder,
 		logger:        log.Log.WithName("workflow-manager"),
+		failed:        map[string]error{},
+	}
+	for _, opt := range opts {
+		opt(wm)
 	}
+	return wm
 }
 
 // NamespaceState tracks per-namespace workflow state
@@ -82,6 +132,81 @@ func (wm *WorkflowManager) StartNamespaceWorkflow(
 func (wm *WorkflowManager) StopNamespaceWorkflow(namespace string, state *NamespaceState) {
 	wm.logger.Info("Stopping namespace workflow", "namespace", namespace)
 	state.Cancel()
+	wm.clearFailure(namespace)
+}
+
+// CheckHealth returns an error naming the namespace workflows that stopped without being
+// asked to, or nil when every started workflow is running
+func (wm *WorkflowManager) CheckHealth() error {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	if len(wm.failed) == 0 {
+		return nil
+	}
+	namespaces := make([]string, 0, len(wm.failed))
+	for namespace := range wm.failed {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	errs := make([]error, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		errs = append(errs, fmt.Errorf("namespace %s: %w", namespace, wm.failed[namespace]))
+	}
+	return fmt.Errorf("event workflows not running: %w", errors.Join(errs...))
+}
+
+// Failure returns why the namespace workflow stopped on its own, or nil while it runs
+func (wm *WorkflowManager) Failure(namespace string) error {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	return wm.failed[namespace]
+}
+
+// recordFailure marks a namespace workflow as stopped on its own
+func (wm *WorkflowManager) recordFailure(namespace string, err error) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	wm.failed[namespace] = err
+}
+
+// clearFailure forgets a namespace workflow failure once the workflow starts watching
+// events again or is stopped
+func (wm *WorkflowManager) clearFailure(namespace string) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	delete(wm.failed, namespace)
+}
+
+// acquireSlot waits for a free workflow slot, returning false if ctx ends first
+func (wm *WorkflowManager) acquireSlot(ctx context.Context, namespace string) bool {
+	if wm.slots == nil {
+		return true
+	}
+	select {
+	case wm.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	wm.logger.Info("Namespace workflow limit reached, queueing workflow",
+		"namespace", namespace,
+		"maxConcurrentWorkflows", cap(wm.slots))
+	select {
+	case wm.slots <- struct{}{}:
+		wm.logger.Info("Starting queued namespace workflow", "namespace", namespace)
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// releaseSlot frees the workflow slot taken by acquireSlot
+func (wm *WorkflowManager) releaseSlot() {
+	if wm.slots != nil {
+		<-wm.slots
+	}
 }
 
 // runNamespaceWorkflow runs the actual workflow for a namespace
@@ -93,21 +218,79 @@ func (wm *WorkflowManager) runNamespaceWorkflow(
 ) {
 	defer func() {
 		if r := recover(); r != nil {
-			wm.logger.Error(fmt.Errorf("namespace workflow panic: %v", r),
-				"namespace workflow panicked", "namespace", namespace)
+			err := fmt.Errorf("namespace workflow panic: %v", r)
+			wm.logger.Error(err, "namespace workflow panicked", "namespace", namespace)
+			wm.recordFailure(namespace, err)
 		}
 	}()
 
+	// A workflow stopped while queued never takes a slot
+	if !wm.acquireSlot(ctx, namespace) {
+		wm.logger.Info("Queued namespace workflow stopped before starting", "namespace", namespace)
+		return
+	}
+	defer wm.releaseSlot()
+
 	wm.logger.Info("Namespace workflow started", "namespace", namespace)
 
-	watcher := event.NewWatcher(wm.k8sClient, namespace)
-	processor := pipeline.NewProcessor(watcher, wm.dedupManager, wm.kagentClient, wm.statusManager)
+	watcherOpts := wm.watcherOpts
+	if slices.Contains(eventTypes, "container-started") {
+		// Container start events are only mapped when a hook subscribes to them
+		watcherOpts = append(slices.Clone(watcherOpts), event.WithContainerStartedEvents())
+	}
+
+	watcher := &startedWatcher{
+		EventWatcher: event.NewWatcher(wm.k8sClient, namespace, watcherOpts...),
+		started:      func() { wm.clearFailure(namespace) },
+	}
+	processor := pipeline.NewProcessor(watcher, wm.dedupManager, wm.kagentClient, wm.statusManager, wm.processorOpts...)
 
-	if err := processor.ProcessEventWorkflow(ctx, eventTypes, hooks); err != nil {
+	err := processor.ProcessEventWorkflow(ctx, eventTypes, hooks)
+	if err != nil {
 		wm.logger.Error(err, "Namespace workflow exited with error", "namespace", namespace)
 	} else {
 		wm.logger.Info("Namespace workflow finished", "namespace", namespace)
 	}
+
+	// A workflow that stops while its context is live no longer processes events
+	if ctx.Err() == nil {
+		if err == nil {
+			err = errors.New("event processing stopped")
+		}
+		wm.recordFailure(namespace, err)
+	}
+}
+
+// startedWatcher calls started once its event watch is established
+type startedWatcher struct {
+	interfaces.EventWatcher
+	started func()
+}
+
+// WatchEvents starts watching events, calling started on success
+func (w *startedWatcher) WatchEvents(ctx context.Context) (<-chan interfaces.Event, error) {
+	eventCh, err := w.EventWatcher.WatchEvents(ctx)
+	if err == nil {
+		w.started()
+	}
+	return eventCh, err
+}
+
+// ActiveHooks returns the hooks that are not suspended, grouped by namespace. Namespaces
+// whose hooks are all suspended are omitted so their workflows stop. A hook's suspended
+// flag is part of its spec, so toggling it changes the namespace signature.
+func (wm *WorkflowManager) ActiveHooks(hooksByNamespace map[string][]*kagentv1alpha2.Hook) map[string][]*kagentv1alpha2.Hook {
+	active := make(map[string][]*kagentv1alpha2.Hook, len(hooksByNamespace))
+	for namespace, hooks := range hooksByNamespace {
+		for _, h := range hooks {
+			if h.Spec.Suspended {
+				wm.logger.V(1).Info("Skipping suspended hook", "hook", h.Namespace+"/"+h.Name)
+				continue
+			}
+			active[namespace] = append(active[namespace], h)
+		}
+	}
+	return active
 }
 
 // uniqueEventTypes extracts unique event types from hooks
@@ -129,11 +312,13 @@ func (wm *WorkflowManager) uniqueEventTypes(hooks []*kagentv1alpha2.Hook) []stri
 func (wm *WorkflowManager) CalculateSignature(hooks []*kagentv1alpha2.Hook) string {
 	parts := make([]string, 0, len(hooks))
 	for _, h := range hooks {
-		cfgs := make([]string, 0, len(h.Spec.EventConfigurations))
-		for _, ec := range h.Spec.EventConfigurations {
-			cfgs = append(cfgs, ec.EventType+"|"+ec.AgentRef.Name+"|"+ec.Prompt)
+		// Serialize the full spec so changes to any field (agent namespace, schedule, ...) restart the workflow
+		spec, err := json.Marshal(h.Spec)
+		if err != nil {
+			wm.logger.Error(err, "Failed to serialize hook spec for signature", "hook", h.Namespace+"/"+h.Name)
+			continue
 		}
-		parts = append(parts, h.Namespace+"/"+h.Name+"@"+strings.Join(cfgs, ";"))
+		parts = append(parts, h.Namespace+"/"+h.Name+"@"+string(spec))
 	}
 	return strings.Join(parts, ",")
 }
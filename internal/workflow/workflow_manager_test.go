@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kagentv1alpha2 "github.com/kagent-dev/khook/api/v1alpha2"
+)
+
+func TestWorkflowManagerActiveHooks(t *testing.T) {
+	wm := &WorkflowManager{}
+	config := conflictTestConfig("pod-restart", "agent", "")
+
+	active := newConflictTestHook("active", "team-a", config)
+	suspended := newConflictTestHook("suspended", "team-a", config)
+	suspended.Spec.Suspended = true
+	onlySuspended := newConflictTestHook("paused", "team-b", config)
+	onlySuspended.Spec.Suspended = true
+
+	hooksByNamespace := map[string][]*kagentv1alpha2.Hook{
+		"team-a": {active, suspended},
+		"team-b": {onlySuspended},
+	}
+
+	activeHooks := wm.ActiveHooks(hooksByNamespace)
+	assert.Equal(t, map[string][]*kagentv1alpha2.Hook{"team-a": {active}}, activeHooks)
+
+	// Resuming a hook changes the namespace signature so its workflow restarts
+	before := wm.CalculateSignature(activeHooks["team-a"])
+	suspended.Spec.Suspended = false
+	after := wm.CalculateSignature(wm.ActiveHooks(hooksByNamespace)["team-a"])
+	assert.NotEqual(t, before, after)
+}
+
+func TestWorkflowManagerCheckHealth(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	k8sClient.PrependWatchReactor("events", func(clienttesting.Action) (bool, watch.Interface, error) {
+		return true, nil, errors.New("events are forbidden")
+	})
+	wm := NewWorkflowManager(k8sClient, nil, nil, nil, nil, nil)
+	require.NoError(t, wm.CheckHealth())
+
+	hooks := []*kagentv1alpha2.Hook{newConflictTestHook("hook", "team-a", conflictTestConfig("pod-restart", "agent", ""))}
+	state, err := wm.StartNamespaceWorkflow(context.Background(), "team-a", hooks, wm.CalculateSignature(hooks))
+	require.NoError(t, err)
+
+	// The workflow stops on its own when the watch cannot be established
+	require.Eventually(t, func() bool { return wm.CheckHealth() != nil }, 5*time.Second, 10*time.Millisecond)
+	assert.ErrorContains(t, wm.CheckHealth(), "namespace team-a")
+	assert.ErrorContains(t, wm.CheckHealth(), "events are forbidden")
+
+	// Stopping the workflow forgets its failure
+	wm.StopNamespaceWorkflow("team-a", state)
+	assert.NoError(t, wm.CheckHealth())
+}
+
+func TestWorkflowManagerCheckHealthRecovery(t *testing.T) {
+	var forbidden atomic.Bool
+	forbidden.Store(true)
+	k8sClient := fake.NewSimpleClientset()
+	k8sClient.PrependWatchReactor("events", func(clienttesting.Action) (bool, watch.Interface, error) {
+		if forbidden.Load() {
+			return true, nil, errors.New("events are forbidden")
+		}
+		return false, nil, nil
+	})
+	wm := NewWorkflowManager(k8sClient, nil, nil, nil, nil, nil)
+	c := &Coordinator{
+		workflowManager: wm,
+		namespaceStates: make(map[string]*NamespaceState),
+		logger:          log.Log.WithName("test"),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hooks := []*kagentv1alpha2.Hook{newConflictTestHook("hook", "team-a", conflictTestConfig("pod-restart", "agent", ""))}
+	c.manageNamespaceWorkflow(ctx, "team-a", hooks)
+	require.Eventually(t, func() bool { return wm.CheckHealth() != nil }, 5*time.Second, 10*time.Millisecond)
+
+	// The next sync restarts the stopped workflow even though its hooks did not change,
+	// and the failure is cleared once the new workflow watches events
+	forbidden.Store(false)
+	c.manageNamespaceWorkflow(ctx, "team-a", hooks)
+	require.Eventually(t, func() bool { return wm.CheckHealth() == nil }, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, c.namespaceStates, "team-a")
+}
+
+func TestWorkflowManagerMaxConcurrentWorkflows(t *testing.T) {
+	wm := NewWorkflowManager(fake.NewSimpleClientset(), nil, nil, nil, nil, nil, WithMaxConcurrentWorkflows(1))
+
+	require.True(t, wm.acquireSlot(context.Background(), "team-a"))
+
+	// A second workflow queues until a slot frees up, or gives up when stopped while queued
+	ctx, cancel := context.WithCancel(context.Background())
+	acquired := make(chan bool, 1)
+	go func() { acquired <- wm.acquireSlot(ctx, "team-b") }()
+	select {
+	case <-acquired:
+		t.Fatal("workflow started over the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+	cancel()
+	assert.False(t, <-acquired)
+
+	wm.releaseSlot()
+	assert.True(t, wm.acquireSlot(context.Background(), "team-c"))
+}
+
+func TestWorkflowManagerMaxConcurrentWorkflowsReleasesSlots(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	k8sClient.PrependWatchReactor("events", func(clienttesting.Action) (bool, watch.Interface, error) {
+		return true, nil, errors.New("events are forbidden")
+	})
+	wm := NewWorkflowManager(k8sClient, nil, nil, nil, nil, nil, WithMaxConcurrentWorkflows(1))
+
+	// Each workflow stops as soon as its watch fails, freeing the slot for the next one
+	for _, namespace := range []string{"team-a", "team-b"} {
+		hooks := []*kagentv1alpha2.Hook{newConflictTestHook("hook", namespace, conflictTestConfig("pod-restart", "agent", ""))}
+		_, err := wm.StartNamespaceWorkflow(context.Background(), namespace, hooks, wm.CalculateSignature(hooks))
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		err := wm.CheckHealth()
+		return err != nil && strings.Contains(err.Error(), "team-a") && strings.Contains(err.Error(), "team-b")
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, wm.slots)
+}